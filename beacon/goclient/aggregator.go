@@ -24,25 +24,33 @@ func (gc *goClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeInd
 		return nil, errors.New("validator is not an aggregator")
 	}
 
-	data, err := gc.client.AttestationData(gc.ctx, slot, committeeIndex)
-	if err != nil {
-		return nil, err
-	}
-	if data == nil {
-		return nil, errors.New("attestation data is nil")
-	}
+	// the attestation data and the aggregate are fetched from the same node
+	var aggregateData *phase0.Attestation
+	err = gc.call(func(client Client) error {
+		data, err := client.AttestationData(gc.ctx, slot, committeeIndex)
+		if err != nil {
+			return err
+		}
+		if data == nil {
+			return errors.New("attestation data is nil")
+		}
 
-	// Get aggregate attestation data.
-	root, err := data.HashTreeRoot()
+		// Get aggregate attestation data.
+		root, err := data.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "AttestationData.HashTreeRoot")
+		}
+		aggregateData, err = client.AggregateAttestation(gc.ctx, slot, root)
+		if err != nil {
+			return errors.Wrap(err, "failed to get aggregate attestation")
+		}
+		if aggregateData == nil {
+			return errors.New("aggregation data is nil")
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "AttestationData.HashTreeRoot")
-	}
-	aggregateData, err := gc.client.AggregateAttestation(gc.ctx, slot, root)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get aggregate attestation")
-	}
-	if aggregateData == nil {
-		return nil, errors.New("aggregation data is nil")
+		return nil, err
 	}
 
 	var selectionProof phase0.BLSSignature
@@ -57,7 +65,9 @@ func (gc *goClient) SubmitAggregateSelectionProof(slot phase0.Slot, committeeInd
 
 // SubmitSignedAggregateSelectionProof broadcasts a signed aggregator msg
 func (gc *goClient) SubmitSignedAggregateSelectionProof(msg *phase0.SignedAggregateAndProof) error {
-	return gc.client.SubmitAggregateAttestations(gc.ctx, []*phase0.SignedAggregateAndProof{msg})
+	return gc.broadcast(func(client Client) error {
+		return client.SubmitAggregateAttestations(gc.ctx, []*phase0.SignedAggregateAndProof{msg})
+	})
 }
 
 // IsAggregator returns true if the signature is from the input validator. The committee
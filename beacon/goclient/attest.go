@@ -4,15 +4,18 @@ import (
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
-	spectypes "github.com/bloxapp/ssv-spec/types"
-	"github.com/pkg/errors"
 )
 
 func (gc *goClient) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
 	gc.waitOneThirdOrValidBlock(slot)
 
 	startTime := time.Now()
-	attestationData, err := gc.client.AttestationData(gc.ctx, slot, committeeIndex)
+	var attestationData *phase0.AttestationData
+	err := gc.call(func(client Client) error {
+		var err error
+		attestationData, err = client.AttestationData(gc.ctx, slot, committeeIndex)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -23,33 +26,13 @@ func (gc *goClient) GetAttestationData(slot phase0.Slot, committeeIndex phase0.C
 
 // SubmitAttestation implements Beacon interface
 func (gc *goClient) SubmitAttestation(attestation *phase0.Attestation) error {
-	signingRoot, err := gc.getSigningRoot(attestation.Data)
-	if err != nil {
-		return errors.Wrap(err, "failed to get signing root")
-	}
-
-	if err := gc.slashableAttestationCheck(gc.ctx, signingRoot); err != nil {
-		return errors.Wrap(err, "failed attestation slashing protection check")
-	}
-
-	return gc.client.SubmitAttestations(gc.ctx, []*phase0.Attestation{attestation})
-}
-
-// getSigningRoot returns signing root
-func (gc *goClient) getSigningRoot(data *phase0.AttestationData) ([32]byte, error) {
-	epoch := gc.network.EstimatedEpochAtSlot(data.Slot)
-	domain, err := gc.DomainData(epoch, spectypes.DomainAttester)
-	if err != nil {
-		return [32]byte{}, err
-	}
-	root, err := gc.ComputeSigningRoot(data, domain)
-	if err != nil {
-		return [32]byte{}, err
-	}
-	return root, nil
+	return gc.broadcast(func(client Client) error {
+		return client.SubmitAttestations(gc.ctx, []*phase0.Attestation{attestation})
+	})
 }
 
-// waitOneThirdOrValidBlock waits until one-third of the slot has transpired (SECONDS_PER_SLOT / 3 seconds after the start of slot)
+// waitOneThirdOrValidBlock waits until one-third of the slot has transpired (SECONDS_PER_SLOT / 3 seconds after the start of slot),
+// or until the block of the slot arrived, whichever comes first
 func (gc *goClient) waitOneThirdOrValidBlock(slot phase0.Slot) {
 	delay := gc.network.SlotDurationSec() / 3 /* a third of the slot duration */
 	finalTime := gc.slotStartTime(slot).Add(delay)
@@ -57,5 +40,10 @@ func (gc *goClient) waitOneThirdOrValidBlock(slot phase0.Slot) {
 	if wait <= 0 {
 		return
 	}
-	time.Sleep(wait)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-gc.blocks.wait(slot):
+	}
 }
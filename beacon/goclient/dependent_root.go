@@ -0,0 +1,143 @@
+package goclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"strings"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// dependentRootDutiesProvider provides the attester and proposer duties with their dependent root,
+// which is not exposed by go-eth2-client
+type dependentRootDutiesProvider interface {
+	AttesterDutiesWithDependentRoot(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*api.AttesterDuty, phase0.Root, error)
+	ProposerDutiesWithDependentRoot(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*api.ProposerDuty, phase0.Root, error)
+}
+
+// httpClient is the go-eth2-client http client, with the requests that are not exposed by go-eth2-client.
+// it is the client of a single beacon node, so its requests are sent by gc.call and fail over between the nodes
+type httpClient struct {
+	*http.Service
+	base   string
+	client *nethttp.Client
+}
+
+func newDependentRootClient(service *http.Service, addr string) *httpClient {
+	base := addr
+	if !strings.HasPrefix(base, "http") {
+		base = fmt.Sprintf("http://%s", base)
+	}
+	return &httpClient{
+		Service: service,
+		base:    strings.TrimSuffix(base, "/"),
+		client:  &nethttp.Client{Timeout: requestTimeout},
+	}
+}
+
+type attesterDutiesResponse struct {
+	DependentRoot string              `json:"dependent_root"`
+	Data          []*api.AttesterDuty `json:"data"`
+}
+
+type proposerDutiesResponse struct {
+	DependentRoot string              `json:"dependent_root"`
+	Data          []*api.ProposerDuty `json:"data"`
+}
+
+// AttesterDutiesWithDependentRoot returns the attester duties of the given validators, and their dependent root
+func (c *httpClient) AttesterDutiesWithDependentRoot(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*api.AttesterDuty, phase0.Root, error) {
+	indices := make([]string, 0, len(validatorIndices))
+	for _, index := range validatorIndices {
+		indices = append(indices, fmt.Sprint(index))
+	}
+	body, err := json.Marshal(indices)
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to marshal validator indices")
+	}
+	var resp attesterDutiesResponse
+	if err := c.do(ctx, nethttp.MethodPost, fmt.Sprintf("/eth/v1/validator/duties/attester/%d", epoch), body, &resp); err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to request attester duties")
+	}
+	root, err := parseRoot(resp.DependentRoot)
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "invalid dependent root")
+	}
+	return resp.Data, root, nil
+}
+
+// ProposerDutiesWithDependentRoot returns the proposer duties of the given validators, and their dependent root
+func (c *httpClient) ProposerDutiesWithDependentRoot(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*api.ProposerDuty, phase0.Root, error) {
+	var resp proposerDutiesResponse
+	if err := c.do(ctx, nethttp.MethodGet, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), nil, &resp); err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "failed to request proposer duties")
+	}
+	root, err := parseRoot(resp.DependentRoot)
+	if err != nil {
+		return nil, phase0.Root{}, errors.Wrap(err, "invalid dependent root")
+	}
+	// the endpoint returns the proposers of all the validators
+	validators := make(map[phase0.ValidatorIndex]bool, len(validatorIndices))
+	for _, index := range validatorIndices {
+		validators[index] = true
+	}
+	duties := make([]*api.ProposerDuty, 0)
+	for _, duty := range resp.Data {
+		if validators[duty.ValidatorIndex] {
+			duties = append(duties, duty)
+		}
+	}
+	return duties, root, nil
+}
+
+// do sends a request to the beacon node api, and decodes the json response into res
+func (c *httpClient) do(ctx context.Context, method, endpoint string, body []byte, res interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := nethttp.NewRequestWithContext(ctx, method, c.base+endpoint, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != nethttp.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("%s failed with status %d: %s", method, resp.StatusCode, data)
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
+
+func parseRoot(s string) (phase0.Root, error) {
+	var root phase0.Root
+	if len(s) == 0 {
+		return root, nil
+	}
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return root, err
+	}
+	if len(b) != len(root) {
+		return root, errors.Errorf("invalid length %d", len(b))
+	}
+	copy(root[:], b)
+	return root, nil
+}
@@ -0,0 +1,58 @@
+package goclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClient_DutiesWithDependentRoot(t *testing.T) {
+	root := "0x" + "ab" + strings.Repeat("00", 31)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/validator/duties/attester/10":
+			require.Equal(t, http.MethodPost, r.Method)
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, `["1","2"]`, string(body))
+			_, _ = w.Write([]byte(`{"dependent_root":"` + root + `","data":[{"pubkey":"0x` + pubKeyHex + `","validator_index":"1","committee_index":"3","committee_length":"128","committees_at_slot":"4","validator_committee_index":"7","slot":"321"}]}`))
+		case "/eth/v1/validator/duties/proposer/10":
+			require.Equal(t, http.MethodGet, r.Method)
+			_, _ = w.Write([]byte(`{"dependent_root":"` + root + `","data":[` +
+				`{"pubkey":"0x` + pubKeyHex + `","validator_index":"1","slot":"320"},` +
+				`{"pubkey":"0x` + pubKeyHex + `","validator_index":"5","slot":"322"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	expectedRoot := phase0.Root{0xab}
+	client := newDependentRootClient(nil, server.URL)
+	attesterDuties, attesterRoot, err := client.AttesterDutiesWithDependentRoot(context.Background(), 10, []phase0.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, attesterRoot)
+	require.Len(t, attesterDuties, 1)
+	require.Equal(t, phase0.Slot(321), attesterDuties[0].Slot)
+	require.Equal(t, phase0.CommitteeIndex(3), attesterDuties[0].CommitteeIndex)
+
+	// only the duties of the given validators are returned
+	proposerDuties, proposerRoot, err := client.ProposerDutiesWithDependentRoot(context.Background(), 10, []phase0.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, proposerRoot)
+	require.Len(t, proposerDuties, 1)
+	require.Equal(t, phase0.Slot(320), proposerDuties[0].Slot)
+
+	_, _, err = client.ProposerDutiesWithDependentRoot(context.Background(), 11, nil)
+	require.EqualError(t, err, `failed to request proposer duties: GET failed with status 404: {"code":404,"message":"not found"}`)
+}
+
+// pubKeyHex is a 48 bytes public key
+var pubKeyHex = strings.Repeat("a9", 48)
@@ -2,17 +2,22 @@ package goclient
 
 import (
 	"fmt"
-	"go.uber.org/zap"
 	"sync"
 	"time"
 
 	api "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 )
 
-func (gc *goClient) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error) {
-	type FetchFunc func(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error)
+// GetDuties returns the duties of the given validators in the given epoch and their dependent roots.
+// an error is returned if the duties of any of the roles failed to fetch, so an epoch isn't cached without them
+func (gc *goClient) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, beaconprotocol.DutiesDependentRoots, error) {
+	type FetchFunc func(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, phase0.Root, error)
 
 	fetchers := map[spectypes.BeaconRole]FetchFunc{
 		spectypes.BNRoleAttester:      gc.fetchAttesterDuties,
@@ -20,6 +25,8 @@ func (gc *goClient) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.Vali
 		spectypes.BNRoleSyncCommittee: gc.fetchSyncCommitteeDuties,
 	}
 	duties := make([]*spectypes.Duty, 0)
+	var roots beaconprotocol.DutiesDependentRoots
+	var fetchErr error
 	var lock sync.Mutex
 	var wg sync.WaitGroup
 
@@ -28,27 +35,44 @@ func (gc *goClient) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.Vali
 		wg.Add(1)
 		go func(role spectypes.BeaconRole, fetchFunc FetchFunc) {
 			defer wg.Done()
-			if fetchedDuties, err := fetchFunc(epoch, validatorIndices); err == nil {
-				lock.Lock()
-				duties = append(duties, fetchedDuties...)
-				lock.Unlock()
-			} else {
+			fetchedDuties, root, err := fetchFunc(epoch, validatorIndices)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
 				gc.logger.Warn(fmt.Sprintf("failed to get %s duties", role.String()), zap.Error(err))
+				fetchErr = errors.Wrapf(err, "failed to get %s duties", role.String())
+				return
+			}
+			duties = append(duties, fetchedDuties...)
+			switch role {
+			case spectypes.BNRoleAttester:
+				roots.Attester = root
+			case spectypes.BNRoleProposer:
+				roots.Proposer = root
 			}
 		}(role, fetcher)
 	}
 	wg.Wait()
+	if fetchErr != nil {
+		return nil, roots, fetchErr
+	}
 
 	gc.logger.Debug("fetched duties", zap.Int("count", len(duties)), zap.Float64("duration (sec)", time.Since(start).Seconds()))
-	return duties, nil
+	return duties, roots, nil
 }
 
 // fetchAttesterDuties applies attester + aggregator duties
-func (gc *goClient) fetchAttesterDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error) {
+func (gc *goClient) fetchAttesterDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, phase0.Root, error) {
 	var duties []*spectypes.Duty
-	attesterDuties, err := gc.client.AttesterDuties(gc.ctx, epoch, validatorIndices)
+	var attesterDuties []*api.AttesterDuty
+	var root phase0.Root
+	err := gc.call(func(client Client) error {
+		var err error
+		attesterDuties, root, err = client.AttesterDutiesWithDependentRoot(gc.ctx, epoch, validatorIndices)
+		return err
+	})
 	if err != nil {
-		return duties, err
+		return duties, root, err
 	}
 	toBeaconDuty := func(duty *api.AttesterDuty, role spectypes.BeaconRole) *spectypes.Duty {
 		return &spectypes.Duty{
@@ -67,15 +91,21 @@ func (gc *goClient) fetchAttesterDuties(epoch phase0.Epoch, validatorIndices []p
 		duties = append(duties, toBeaconDuty(attesterDuty, spectypes.BNRoleAttester))
 		duties = append(duties, toBeaconDuty(attesterDuty, spectypes.BNRoleAggregator)) // always trigger aggregator as well
 	}
-	return duties, nil
+	return duties, root, nil
 }
 
 // fetchProposerDuties applies proposer duties
-func (gc *goClient) fetchProposerDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error) {
+func (gc *goClient) fetchProposerDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, phase0.Root, error) {
 	var duties []*spectypes.Duty
-	proposerDuties, err := gc.client.ProposerDuties(gc.ctx, epoch, validatorIndices)
+	var proposerDuties []*api.ProposerDuty
+	var root phase0.Root
+	err := gc.call(func(client Client) error {
+		var err error
+		proposerDuties, root, err = client.ProposerDutiesWithDependentRoot(gc.ctx, epoch, validatorIndices)
+		return err
+	})
 	if err != nil {
-		return duties, err
+		return duties, root, err
 	}
 	for _, proposerDuty := range proposerDuties {
 		duties = append(duties, &spectypes.Duty{
@@ -85,15 +115,20 @@ func (gc *goClient) fetchProposerDuties(epoch phase0.Epoch, validatorIndices []p
 			ValidatorIndex: proposerDuty.ValidatorIndex,
 		})
 	}
-	return duties, nil
+	return duties, root, nil
 }
 
-// fetchSyncCommitteeDuties applies sync committee + sync committee contributor duties
-func (gc *goClient) fetchSyncCommitteeDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error) {
+// fetchSyncCommitteeDuties applies sync committee + sync committee contributor duties, they have no dependent root
+func (gc *goClient) fetchSyncCommitteeDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, phase0.Root, error) {
 	var duties []*spectypes.Duty
-	syncCommitteeDuties, err := gc.client.SyncCommitteeDuties(gc.ctx, epoch, validatorIndices)
+	var syncCommitteeDuties []*api.SyncCommitteeDuty
+	err := gc.call(func(client Client) error {
+		var err error
+		syncCommitteeDuties, err = client.SyncCommitteeDuties(gc.ctx, epoch, validatorIndices)
+		return err
+	})
 	if err != nil {
-		return duties, err
+		return duties, phase0.Root{}, err
 	}
 	toBeaconDuty := func(duty *api.SyncCommitteeDuty, slot phase0.Slot, role spectypes.BeaconRole) *spectypes.Duty {
 		return &spectypes.Duty{
@@ -115,5 +150,5 @@ func (gc *goClient) fetchSyncCommitteeDuties(epoch phase0.Epoch, validatorIndice
 		}
 	}
 
-	return duties, nil
+	return duties, phase0.Root{}, nil
 }
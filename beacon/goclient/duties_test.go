@@ -0,0 +1,63 @@
+package goclient
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func (n *fakeNode) AttesterDutiesWithDependentRoot(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*api.AttesterDuty, phase0.Root, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.down {
+		return nil, phase0.Root{}, errors.New("node is down")
+	}
+	return []*api.AttesterDuty{{ValidatorIndex: 1, Slot: 320}}, phase0.Root{1}, nil
+}
+
+func (n *fakeNode) ProposerDutiesWithDependentRoot(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*api.ProposerDuty, phase0.Root, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.down || n.proposerDutiesDown {
+		return nil, phase0.Root{}, errors.New("proposer duties are not available")
+	}
+	return []*api.ProposerDuty{{ValidatorIndex: 1, Slot: 321}}, phase0.Root{2}, nil
+}
+
+func (n *fakeNode) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*api.SyncCommitteeDuty, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.down {
+		return nil, errors.New("node is down")
+	}
+	return nil, nil
+}
+
+func TestGoClient_GetDuties(t *testing.T) {
+	a, b := &fakeNode{addr: "a:5052"}, &fakeNode{addr: "b:5052"}
+	gc, err := newTestClient(t, []*fakeNode{a, b}, nil)
+	require.NoError(t, err)
+
+	// the duties with dependent roots fail over between the nodes
+	a.lock.Lock()
+	a.proposerDutiesDown = true
+	a.lock.Unlock()
+	duties, roots, err := gc.GetDuties(10, []phase0.ValidatorIndex{1})
+	require.NoError(t, err)
+	// an attester duty triggers an aggregator duty as well
+	require.Len(t, duties, 3)
+	require.Equal(t, phase0.Root{1}, roots.Attester)
+	require.Equal(t, phase0.Root{2}, roots.Proposer)
+
+	// the duties are not returned without the proposer duties, so the epoch isn't cached without them
+	b.lock.Lock()
+	b.proposerDutiesDown = true
+	b.lock.Unlock()
+	duties, _, err = gc.GetDuties(10, []phase0.ValidatorIndex{1})
+	require.EqualError(t, err, "failed to get PROPOSER duties: proposer duties are not available")
+	require.Nil(t, duties)
+}
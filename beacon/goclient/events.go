@@ -0,0 +1,133 @@
+package goclient
+
+import (
+	"sync"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/prysm/async/event"
+	"go.uber.org/zap"
+)
+
+const (
+	topicHead       = "head"
+	topicBlock      = "block"
+	topicChainReorg = "chain_reorg"
+)
+
+// eventTopics are the topics of the events stream of the beacon nodes
+var eventTopics = []string{topicHead, topicBlock, topicChainReorg}
+
+// EventsFeed returns the feed of the head and chain reorg events of the beacon nodes
+func (gc *goClient) EventsFeed() *event.Feed {
+	return gc.eventsFeed
+}
+
+// subscribeEvents subscribes to the events stream of the given node, the stream reconnects until the context is done
+func (gc *goClient) subscribeEvents(addr string, client Client) {
+	if err := client.Events(gc.ctx, eventTopics, gc.handleEvent); err != nil {
+		gc.logger.Warn("could not subscribe to beacon node events", zap.String("address", addr), zap.Error(err))
+	}
+}
+
+// handleEvent handles an event of any of the nodes, head and chain reorg events are sent to the feed once
+func (gc *goClient) handleEvent(e *eth2apiv1.Event) {
+	switch data := e.Data.(type) {
+	case *eth2apiv1.BlockEvent:
+		gc.blocks.arrived(data.Slot)
+	case *eth2apiv1.HeadEvent:
+		gc.blocks.arrived(data.Slot)
+		if gc.events.isNew(e.Topic, data.Block, data.Slot) {
+			gc.eventsFeed.Send(e)
+		}
+	case *eth2apiv1.ChainReorgEvent:
+		if gc.events.isNew(e.Topic, data.NewHeadBlock, data.Slot) {
+			gc.logger.Debug("chain reorg", zap.Uint64("slot", uint64(data.Slot)), zap.Uint64("depth", data.Depth))
+			gc.eventsFeed.Send(e)
+		}
+	}
+}
+
+type eventKey struct {
+	topic string
+	root  phase0.Root
+}
+
+// seenEvents dedups the events of the nodes
+type seenEvents struct {
+	lock      sync.Mutex
+	seen      map[eventKey]phase0.Slot
+	retention phase0.Slot
+}
+
+func newSeenEvents(retention phase0.Slot) *seenEvents {
+	return &seenEvents{
+		seen:      make(map[eventKey]phase0.Slot),
+		retention: retention,
+	}
+}
+
+// isNew returns true if the event wasn't seen before, events older than the retention are forgotten
+func (s *seenEvents) isNew(topic string, root phase0.Root, slot phase0.Slot) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := eventKey{topic: topic, root: root}
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+	s.seen[key] = slot
+	for k, seenSlot := range s.seen {
+		if seenSlot+s.retention < slot {
+			delete(s.seen, k)
+		}
+	}
+	return true
+}
+
+// blockArrivals notifies the waiters for the block of a slot
+type blockArrivals struct {
+	lock     sync.Mutex
+	lastSlot phase0.Slot
+	waiters  map[phase0.Slot]chan struct{}
+}
+
+func newBlockArrivals() *blockArrivals {
+	return &blockArrivals{
+		waiters: make(map[phase0.Slot]chan struct{}),
+	}
+}
+
+// arrived releases the waiters for the given slot and the slots before it
+func (b *blockArrivals) arrived(slot phase0.Slot) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if slot > b.lastSlot {
+		b.lastSlot = slot
+	}
+	for s, ch := range b.waiters {
+		if s <= slot {
+			close(ch)
+			delete(b.waiters, s)
+		}
+	}
+}
+
+// wait returns a channel that is closed once the block of the given slot, or of a later slot, arrived
+func (b *blockArrivals) wait(slot phase0.Slot) <-chan struct{} {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.lastSlot >= slot {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
+	ch, ok := b.waiters[slot]
+	if !ok {
+		ch = make(chan struct{})
+		b.waiters[slot] = ch
+	}
+	return ch
+}
@@ -0,0 +1,75 @@
+package goclient
+
+import (
+	"testing"
+	"time"
+
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockArrivals(t *testing.T) {
+	b := newBlockArrivals()
+	waitA, waitB := b.wait(10), b.wait(11)
+	requireOpen := func(ch <-chan struct{}) {
+		select {
+		case <-ch:
+			t.Fatal("channel is closed")
+		default:
+		}
+	}
+
+	b.arrived(9)
+	requireOpen(waitA)
+	requireOpen(waitB)
+
+	b.arrived(10)
+	<-waitA
+	requireOpen(waitB)
+	<-b.wait(9)
+
+	// a later block releases the waiters of the slots before it
+	b.arrived(12)
+	<-waitB
+}
+
+func TestSeenEvents(t *testing.T) {
+	s := newSeenEvents(32)
+	require.True(t, s.isNew(topicHead, phase0.Root{1}, 10))
+	require.False(t, s.isNew(topicHead, phase0.Root{1}, 10))
+	require.True(t, s.isNew(topicChainReorg, phase0.Root{1}, 10))
+
+	// old events are forgotten
+	require.True(t, s.isNew(topicHead, phase0.Root{2}, 100))
+	require.True(t, s.isNew(topicHead, phase0.Root{1}, 10))
+}
+
+func TestGoClient_Events(t *testing.T) {
+	gc, err := newTestClient(t, []*fakeNode{{addr: "a:5052"}, {addr: "b:5052"}}, nil)
+	require.NoError(t, err)
+	cn := make(chan *eth2apiv1.Event, 4)
+	sub := gc.EventsFeed().Subscribe(cn)
+	defer sub.Unsubscribe()
+
+	// the same head of both nodes is sent once
+	head := &eth2apiv1.Event{Topic: topicHead, Data: &eth2apiv1.HeadEvent{Slot: 10, Block: phase0.Root{1}}}
+	gc.handleEvent(head)
+	gc.handleEvent(head)
+	gc.handleEvent(&eth2apiv1.Event{Topic: topicBlock, Data: &eth2apiv1.BlockEvent{Slot: 11}})
+	reorg := &eth2apiv1.Event{Topic: topicChainReorg, Data: &eth2apiv1.ChainReorgEvent{Slot: 11, NewHeadBlock: phase0.Root{2}}}
+	gc.handleEvent(reorg)
+	require.Equal(t, head, <-cn)
+	require.Equal(t, reorg, <-cn)
+	require.Len(t, cn, 0)
+
+	// the wait for the data of the next slot ends once its block arrived, before a third of the slot
+	slot := gc.network.EstimatedCurrentSlot() + 1
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		gc.handleEvent(&eth2apiv1.Event{Topic: topicBlock, Data: &eth2apiv1.BlockEvent{Slot: slot}})
+	}()
+	start := time.Now()
+	gc.waitOneThirdOrValidBlock(slot)
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -4,18 +4,18 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
-	"github.com/attestantio/go-eth2-client/http"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/eth2-key-manager/core"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"github.com/rs/zerolog"
+	"github.com/prysmaticlabs/prysm/async/event"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/monitoring/metrics"
@@ -32,16 +32,31 @@ var (
 	allMetrics = []prometheus.Collector{
 		metricsBeaconNodeStatus,
 		metricsAttestationDataRequest,
+		metricsNodeStatus,
+		metricsNodeLatency,
+		metricsNodeFailedRequests,
 	}
 	metricsBeaconNodeStatus = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "ssv:beacon:node_status",
-		Help: "Status of the connected beacon node",
+		Help: "Status of the best connected beacon node",
 	})
 	metricsAttestationDataRequest = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "ssv:beacon:attestation_data_request_duration_seconds",
 		Help:    "Attestation data request duration (seconds)",
 		Buckets: []float64{0.02, 0.05, 0.1, 0.2, 0.5, 1, 5},
 	}, []string{})
+	metricsNodeStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:beacon:endpoint_status",
+		Help: "Status of the beacon nodes by their index",
+	}, []string{"endpoint"})
+	metricsNodeLatency = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:beacon:endpoint_latency_seconds",
+		Help: "Moving average of the requests latency of the beacon nodes by their index (seconds)",
+	}, []string{"endpoint"})
+	metricsNodeFailedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:beacon:endpoint_failed_requests",
+		Help: "Count failed requests of the beacon nodes by their index",
+	}, []string{"endpoint"})
 	statusUnknown beaconNodeStatus = 0
 	statusSyncing beaconNodeStatus = 1
 	statusOK      beaconNodeStatus = 2
@@ -55,6 +70,18 @@ func init() {
 	}
 }
 
+func reportNodeStatus(index int, status beaconNodeStatus) {
+	metricsNodeStatus.WithLabelValues(strconv.Itoa(index)).Set(float64(status))
+}
+
+func reportNodeLatency(index int, latency time.Duration) {
+	metricsNodeLatency.WithLabelValues(strconv.Itoa(index)).Set(latency.Seconds())
+}
+
+func reportNodeFailedRequest(index int) {
+	metricsNodeFailedRequests.WithLabelValues(strconv.Itoa(index)).Inc()
+}
+
 // Client defines all go-eth2-client interfaces used in ssv
 type Client interface {
 	eth2client.Service
@@ -66,8 +93,6 @@ type Client interface {
 	eth2client.AttestationsSubmitter
 	eth2client.BeaconCommitteeSubscriptionsSubmitter
 	eth2client.SyncCommitteeSubscriptionsSubmitter
-	eth2client.AttesterDutiesProvider
-	eth2client.ProposerDutiesProvider
 	eth2client.SyncCommitteeDutiesProvider
 	eth2client.NodeSyncingProvider
 	eth2client.BeaconBlockProposalProvider
@@ -80,6 +105,11 @@ type Client interface {
 	eth2client.SyncCommitteeContributionsSubmitter
 	eth2client.ValidatorsProvider
 	eth2client.ProposalPreparationsSubmitter
+	eth2client.EventsProvider
+	eth2client.VoluntaryExitSubmitter
+
+	dependentRootDutiesProvider
+	livenessProvider
 }
 
 // goClient implementing Beacon struct
@@ -87,7 +117,12 @@ type goClient struct {
 	ctx            context.Context
 	logger         *zap.Logger
 	network        beaconprotocol.Network
-	client         Client
+	nodes          []*beaconNode
+	nodesLock      sync.Mutex
+	connect        connectFunc
+	eventsFeed     *event.Feed
+	events         *seenEvents
+	blocks         *blockArrivals
 	indicesMapLock sync.Mutex
 	graffiti       []byte
 }
@@ -97,54 +132,64 @@ var _ metrics.HealthCheckAgent = &goClient{}
 
 // New init new client and go-client instance
 func New(opt beaconprotocol.Options) (beaconprotocol.Beacon, error) {
-	logger := opt.Logger
-	logger.Info("connecting to consensus client...", zap.String("address", opt.BeaconNodeAddr), zap.String("network", opt.Network))
-
-	httpClient, err := http.New(opt.Context,
-		// WithAddress supplies the address of the beacon node, in host:port format.
-		http.WithAddress(opt.BeaconNodeAddr),
-		// LogLevel supplies the level of logging to carry out.
-		http.WithLogLevel(zerolog.DebugLevel),
-		http.WithTimeout(time.Second*5),
-	)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed to create http client")
-	}
+	return newGoClient(opt, newHTTPClient)
+}
 
-	logger.Info("successfully connected to consensus client", zap.String("name", httpClient.Name()), zap.String("address", httpClient.Address()))
+func newGoClient(opt beaconprotocol.Options, connect connectFunc) (*goClient, error) {
+	addrs := opt.Addrs()
+	if len(addrs) == 0 {
+		return nil, errors.New("no beacon node address")
+	}
+	opt.Logger.Info("connecting to consensus clients...", zap.Strings("addresses", addrs), zap.String("network", opt.Network))
 
 	network := beaconprotocol.NewNetwork(core.NetworkFromString(opt.Network), opt.MinGenesisTime)
 	_client := &goClient{
 		ctx:            opt.Context,
-		logger:         logger,
+		logger:         opt.Logger,
 		network:        network,
-		client:         httpClient.(*http.Service),
+		nodes:          newBeaconNodes(addrs),
+		connect:        connect,
+		eventsFeed:     new(event.Feed),
+		events:         newSeenEvents(phase0.Slot(network.SlotsPerEpoch())),
+		blocks:         newBlockArrivals(),
 		indicesMapLock: sync.Mutex{},
 		graffiti:       opt.Graffiti,
 	}
 
+	_client.checkNodes()
+	if len(_client.byScore()) == 0 {
+		return nil, errNoBeaconNode
+	}
+	go _client.monitorNodes()
+
 	return _client, nil
 }
 
-// HealthCheck provides health status of beacon node
+// HealthCheck provides health status of beacon nodes, the client is healthy if any of the nodes is synced
 func (gc *goClient) HealthCheck() []string {
-	if gc.client == nil {
-		return []string{"not connected to beacon node"}
-	}
-	ctx, cancel := context.WithTimeout(gc.ctx, healthCheckTimeout)
-	defer cancel()
-	syncState, err := gc.client.NodeSyncing(ctx)
-	if err != nil {
-		metricsBeaconNodeStatus.Set(float64(statusUnknown))
-		return []string{"could not get beacon node sync state"}
+	gc.nodesLock.Lock()
+	defer gc.nodesLock.Unlock()
+
+	best := statusUnknown
+	var issues []string
+	for _, n := range gc.nodes {
+		if n.client != nil && n.status > best {
+			best = n.status
+		}
+		switch {
+		case n.client == nil:
+			issues = append(issues, fmt.Sprintf("not connected to beacon node %s", n.addr))
+		case n.status == statusUnknown:
+			issues = append(issues, fmt.Sprintf("could not get beacon node %s sync state", n.addr))
+		case n.status == statusSyncing:
+			issues = append(issues, fmt.Sprintf("beacon node %s is currently syncing: distance=%d", n.addr, n.syncDistance))
+		}
 	}
-	if syncState != nil && syncState.IsSyncing {
-		metricsBeaconNodeStatus.Set(float64(statusSyncing))
-		return []string{fmt.Sprintf("beacon node is currently syncing: head=%d, distance=%d",
-			syncState.HeadSlot, syncState.SyncDistance)}
+	metricsBeaconNodeStatus.Set(float64(best))
+	if best == statusOK {
+		return []string{}
 	}
-	metricsBeaconNodeStatus.Set(float64(statusOK))
-	return []string{}
+	return issues
 }
 
 // GetBeaconNetwork returns the beacon network the node is on
@@ -0,0 +1,51 @@
+package goclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// livenessProvider provides the liveness of validators, which is not exposed by go-eth2-client
+type livenessProvider interface {
+	ValidatorsLiveness(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error)
+}
+
+type validatorLiveness struct {
+	Index  string `json:"index"`
+	IsLive bool   `json:"is_live"`
+}
+
+type livenessResponse struct {
+	Data []*validatorLiveness `json:"data"`
+}
+
+// ValidatorsLiveness returns whether the given validators were seen on the network (attesting or proposing) in the given epoch
+func (c *httpClient) ValidatorsLiveness(ctx context.Context, epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error) {
+	indices := make([]string, 0, len(validatorIndices))
+	for _, index := range validatorIndices {
+		indices = append(indices, fmt.Sprint(index))
+	}
+	body, err := json.Marshal(indices)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal validator indices")
+	}
+	var resp livenessResponse
+	if err := c.do(ctx, nethttp.MethodPost, fmt.Sprintf("/eth/v1/validator/liveness/%d", epoch), body, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to request validators liveness")
+	}
+	res := make(map[phase0.ValidatorIndex]bool, len(resp.Data))
+	for _, liveness := range resp.Data {
+		index, err := strconv.ParseUint(liveness.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid validator index")
+		}
+		res[phase0.ValidatorIndex(index)] = liveness.IsLive
+	}
+	return res, nil
+}
@@ -0,0 +1,29 @@
+package goclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClient_ValidatorsLiveness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/validator/liveness/10", r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, `["1","2"]`, string(body))
+		_, _ = w.Write([]byte(`{"data":[{"index":"1","is_live":true},{"index":"2","is_live":false}]}`))
+	}))
+	defer server.Close()
+
+	client := newDependentRootClient(nil, server.URL)
+	liveness, err := client.ValidatorsLiveness(context.Background(), 10, []phase0.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, map[phase0.ValidatorIndex]bool{1: true, 2: false}, liveness)
+}
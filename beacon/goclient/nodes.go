@@ -0,0 +1,265 @@
+package goclient
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+)
+
+const (
+	// nodesCheckInterval is the interval of checking the sync status and latency of the beacon nodes
+	nodesCheckInterval = 12 * time.Second
+	// requestTimeout is the timeout of the requests to a beacon node
+	requestTimeout = 5 * time.Second
+	// syncingPenalty, slotLagPenalty and errorPenalty weigh the sync status and errors against latency, when scoring nodes
+	syncingPenalty = float64(10 * time.Second)
+	slotLagPenalty = float64(time.Second)
+	errorPenalty   = float64(5 * time.Second)
+	// latencyWeight is the weight of a new sample in the moving average of the latency
+	latencyWeight = 0.2
+)
+
+// errNoBeaconNode is returned when none of the beacon nodes is connected
+var errNoBeaconNode = errors.New("no beacon node is connected")
+
+// connectFunc creates a client of the beacon node in the given address
+type connectFunc func(ctx context.Context, addr string) (Client, error)
+
+// beaconNode is a consensus client, reads are sent to the nodes by their scores and submissions to all the healthy nodes
+type beaconNode struct {
+	index  int
+	addr   string
+	client Client
+	status beaconNodeStatus
+	// syncDistance is the number of slots the node is behind, when it is syncing
+	syncDistance phase0.Slot
+	// latency is a moving average of the latency of requests
+	latency time.Duration
+	// errors is the number of recent errors, it decays on every check
+	errors float64
+}
+
+// score returns the score of the node, lower is better
+func (n *beaconNode) score() float64 {
+	if n.client == nil {
+		return math.MaxFloat64
+	}
+	score := float64(n.latency) + n.errors*errorPenalty
+	if n.status != statusOK {
+		score += syncingPenalty + float64(n.syncDistance)*slotLagPenalty
+	}
+	return score
+}
+
+// healthy returns true if the node is connected and synced
+func (n *beaconNode) healthy() bool {
+	return n.client != nil && n.status == statusOK
+}
+
+// observe records the result of a request
+func (n *beaconNode) observe(latency time.Duration, err error) {
+	if err != nil {
+		n.errors++
+		reportNodeFailedRequest(n.index)
+		return
+	}
+	if n.latency == 0 {
+		n.latency = latency
+	} else {
+		n.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(n.latency))
+	}
+	reportNodeLatency(n.index, n.latency)
+}
+
+func newBeaconNodes(addrs []string) []*beaconNode {
+	res := make([]*beaconNode, 0, len(addrs))
+	for i, addr := range addrs {
+		res = append(res, &beaconNode{index: i, addr: addr})
+	}
+	return res
+}
+
+// newHTTPClient creates an http client of the beacon node in the given address
+func newHTTPClient(ctx context.Context, addr string) (Client, error) {
+	httpClient, err := http.New(ctx,
+		// WithAddress supplies the address of the beacon node, in host:port format.
+		http.WithAddress(addr),
+		// LogLevel supplies the level of logging to carry out.
+		http.WithLogLevel(zerolog.DebugLevel),
+		http.WithTimeout(requestTimeout),
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create http client")
+	}
+	return newDependentRootClient(httpClient.(*http.Service), addr), nil
+}
+
+// connectNode connects to the given node
+func (gc *goClient) connectNode(n *beaconNode) error {
+	gc.logger.Info("connecting to consensus client...", zap.String("address", n.addr))
+	client, err := gc.connect(gc.ctx, n.addr)
+	if err != nil {
+		gc.logger.Error("could not connect to consensus client", zap.String("address", n.addr), zap.Error(err))
+		return err
+	}
+	gc.logger.Info("successfully connected to consensus client", zap.String("name", client.Name()), zap.String("address", n.addr))
+	gc.nodesLock.Lock()
+	n.client = client
+	gc.nodesLock.Unlock()
+	gc.subscribeEvents(n.addr, client)
+	return nil
+}
+
+// byScore returns the connected nodes by their scores
+func (gc *goClient) byScore() []*beaconNode {
+	gc.nodesLock.Lock()
+	defer gc.nodesLock.Unlock()
+	res := make([]*beaconNode, 0, len(gc.nodes))
+	for _, n := range gc.nodes {
+		if n.client != nil {
+			res = append(res, n)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].score() < res[j].score()
+	})
+	return res
+}
+
+// run runs the given request on the given node, and records its result
+func (gc *goClient) run(n *beaconNode, fn func(client Client) error) error {
+	gc.nodesLock.Lock()
+	client := n.client
+	gc.nodesLock.Unlock()
+	start := time.Now()
+	err := fn(client)
+	gc.nodesLock.Lock()
+	n.observe(time.Since(start), err)
+	gc.nodesLock.Unlock()
+	return err
+}
+
+// call runs the given request on the best node, and fails over to the other nodes by their scores
+func (gc *goClient) call(fn func(client Client) error) error {
+	err := errNoBeaconNode
+	for _, n := range gc.byScore() {
+		if err = gc.run(n, fn); err == nil {
+			return nil
+		}
+		gc.logger.Warn("beacon node request failed", zap.String("address", n.addr), zap.Error(err))
+		if gc.ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// broadcast sends the given submission to all the healthy nodes, or to all the connected nodes if none of them is healthy.
+// it returns once any of the nodes accepted the submission, or all of them failed
+func (gc *goClient) broadcast(fn func(client Client) error) error {
+	nodes := gc.byScore()
+	var healthy []*beaconNode
+	gc.nodesLock.Lock()
+	for _, n := range nodes {
+		if n.healthy() {
+			healthy = append(healthy, n)
+		}
+	}
+	gc.nodesLock.Unlock()
+	if len(healthy) > 0 {
+		nodes = healthy
+	}
+	if len(nodes) == 0 {
+		return errNoBeaconNode
+	}
+
+	results := make(chan error, len(nodes))
+	for _, n := range nodes {
+		go func(n *beaconNode) {
+			err := gc.run(n, fn)
+			if err != nil {
+				gc.logger.Warn("beacon node submission failed", zap.String("address", n.addr), zap.Error(err))
+			}
+			results <- err
+		}(n)
+	}
+	var err error
+	for range nodes {
+		if err = <-results; err == nil {
+			return nil
+		}
+	}
+	return errors.Wrap(err, "all the beacon nodes failed")
+}
+
+// monitorNodes checks the nodes every nodesCheckInterval
+func (gc *goClient) monitorNodes() {
+	ticker := time.NewTicker(nodesCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-gc.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		gc.checkNodes()
+	}
+}
+
+// checkNodes connects the disconnected nodes, and updates the sync status and latency of the nodes
+func (gc *goClient) checkNodes() {
+	var wg sync.WaitGroup
+	for _, n := range gc.nodes {
+		wg.Add(1)
+		go func(n *beaconNode) {
+			defer wg.Done()
+			gc.checkNode(n)
+		}(n)
+	}
+	wg.Wait()
+}
+
+func (gc *goClient) checkNode(n *beaconNode) {
+	gc.nodesLock.Lock()
+	client := n.client
+	gc.nodesLock.Unlock()
+	if client == nil {
+		if err := gc.connectNode(n); err != nil {
+			reportNodeStatus(n.index, statusUnknown)
+			return
+		}
+		gc.nodesLock.Lock()
+		client = n.client
+		gc.nodesLock.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(gc.ctx, healthCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	syncState, err := client.NodeSyncing(ctx)
+
+	gc.nodesLock.Lock()
+	defer gc.nodesLock.Unlock()
+	n.errors /= 2
+	n.observe(time.Since(start), err)
+	switch {
+	case err != nil:
+		gc.logger.Warn("could not get beacon node sync state", zap.String("address", n.addr), zap.Error(err))
+		n.status = statusUnknown
+	case syncState != nil && syncState.IsSyncing:
+		n.status = statusSyncing
+		n.syncDistance = syncState.SyncDistance
+	default:
+		n.status = statusOK
+		n.syncDistance = 0
+	}
+	reportNodeStatus(n.index, n.status)
+}
@@ -0,0 +1,224 @@
+package goclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	api "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// fakeNode is a beacon node that implements the requests used in the tests
+type fakeNode struct {
+	Client
+
+	addr string
+	lock sync.Mutex
+	// down fails all the requests
+	down    bool
+	syncing bool
+	// proposerDutiesDown fails the proposer duties requests
+	proposerDutiesDown bool
+	reads              int
+	attestations       int
+}
+
+func (n *fakeNode) set(down, syncing bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.down = down
+	n.syncing = syncing
+}
+
+func (n *fakeNode) counts() (reads int, attestations int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.reads, n.attestations
+}
+
+func (n *fakeNode) Name() string {
+	return "fake"
+}
+
+func (n *fakeNode) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	return nil
+}
+
+func (n *fakeNode) NodeSyncing(ctx context.Context) (*api.SyncState, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.down {
+		return nil, errors.New("node is down")
+	}
+	return &api.SyncState{IsSyncing: n.syncing, SyncDistance: 10}, nil
+}
+
+func (n *fakeNode) ValidatorsByPubKey(ctx context.Context, stateID string, validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*api.Validator, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.down {
+		return nil, errors.New("node is down")
+	}
+	n.reads++
+	return map[phase0.ValidatorIndex]*api.Validator{}, nil
+}
+
+func (n *fakeNode) SubmitAttestations(ctx context.Context, attestations []*phase0.Attestation) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.down {
+		return errors.New("node is down")
+	}
+	n.attestations++
+	return nil
+}
+
+// newTestClient creates a client of the given nodes, the nodes in unreachable can't be connected
+func newTestClient(t *testing.T, nodes []*fakeNode, unreachable map[string]bool) (*goClient, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	var addrs string
+	byAddr := make(map[string]*fakeNode)
+	for i, n := range nodes {
+		if i > 0 {
+			addrs += ","
+		}
+		addrs += n.addr
+		byAddr[n.addr] = n
+	}
+	connect := func(ctx context.Context, addr string) (Client, error) {
+		if unreachable[addr] {
+			return nil, errors.New("connection refused")
+		}
+		return byAddr[addr], nil
+	}
+	return newGoClient(beaconprotocol.Options{
+		Context:        ctx,
+		Logger:         zap.L(),
+		Network:        "prater",
+		BeaconNodeAddr: addrs,
+	}, connect)
+}
+
+func TestGoClient_ReadsFailover(t *testing.T) {
+	a, b := &fakeNode{addr: "a:5052"}, &fakeNode{addr: "b:5052"}
+	gc, err := newTestClient(t, []*fakeNode{a, b}, nil)
+	require.NoError(t, err)
+
+	// the node with the lower latency serves the read
+	_, err = gc.GetValidatorData(nil)
+	require.NoError(t, err)
+	best, other := a, b
+	if reads, _ := b.counts(); reads == 1 {
+		best, other = b, a
+	}
+
+	// the best node is down, the request fails over to the other node which is preferred from now on
+	best.set(true, false)
+	_, err = gc.GetValidatorData(nil)
+	require.NoError(t, err)
+	reads, _ := other.counts()
+	require.Equal(t, 1, reads)
+	best.set(false, false)
+	_, err = gc.GetValidatorData(nil)
+	require.NoError(t, err)
+	reads, _ = other.counts()
+	require.Equal(t, 2, reads)
+
+	a.set(true, false)
+	b.set(true, false)
+	_, err = gc.GetValidatorData(nil)
+	require.EqualError(t, err, "node is down")
+}
+
+func TestGoClient_ReadsPreferSyncedNodes(t *testing.T) {
+	a, b := &fakeNode{addr: "a:5052", syncing: true}, &fakeNode{addr: "b:5052"}
+	gc, err := newTestClient(t, []*fakeNode{a, b}, nil)
+	require.NoError(t, err)
+
+	_, err = gc.GetValidatorData(nil)
+	require.NoError(t, err)
+	readsA, _ := a.counts()
+	readsB, _ := b.counts()
+	require.Equal(t, 0, readsA)
+	require.Equal(t, 1, readsB)
+
+	// once b is syncing and a is synced, a is preferred
+	a.set(false, false)
+	b.set(false, true)
+	gc.checkNodes()
+	_, err = gc.GetValidatorData(nil)
+	require.NoError(t, err)
+	readsA, _ = a.counts()
+	require.Equal(t, 1, readsA)
+}
+
+func TestGoClient_BroadcastSubmissions(t *testing.T) {
+	a, b, c := &fakeNode{addr: "a:5052"}, &fakeNode{addr: "b:5052"}, &fakeNode{addr: "c:5052", syncing: true}
+	gc, err := newTestClient(t, []*fakeNode{a, b, c}, nil)
+	require.NoError(t, err)
+
+	waitAttestations := func(n *fakeNode, expected int) {
+		require.Eventually(t, func() bool {
+			_, attestations := n.counts()
+			return attestations == expected
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	// submitted to the healthy nodes only
+	require.NoError(t, gc.SubmitAttestation(&phase0.Attestation{}))
+	waitAttestations(a, 1)
+	waitAttestations(b, 1)
+	_, attestations := c.counts()
+	require.Equal(t, 0, attestations)
+
+	// succeeds if any of the nodes accepted the submission
+	a.set(true, false)
+	require.NoError(t, gc.SubmitAttestation(&phase0.Attestation{}))
+	waitAttestations(b, 2)
+
+	b.set(true, false)
+	require.Error(t, gc.SubmitAttestation(&phase0.Attestation{}))
+
+	// once none of the nodes is healthy, the submission is sent to all the connected nodes
+	gc.checkNodes()
+	require.NoError(t, gc.SubmitAttestation(&phase0.Attestation{}))
+	waitAttestations(c, 1)
+}
+
+func TestGoClient_HealthCheck(t *testing.T) {
+	a, b := &fakeNode{addr: "a:5052", syncing: true}, &fakeNode{addr: "b:5052"}
+	gc, err := newTestClient(t, []*fakeNode{a, b}, map[string]bool{"b:5052": true})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"beacon node a:5052 is currently syncing: distance=10",
+		"not connected to beacon node b:5052",
+	}, gc.HealthCheck())
+
+	// b is connected on the next check, the client is healthy as b is synced
+	gc.connect = func(ctx context.Context, addr string) (Client, error) {
+		return b, nil
+	}
+	gc.checkNodes()
+	require.Empty(t, gc.HealthCheck())
+
+	b.set(true, false)
+	gc.checkNodes()
+	require.Equal(t, []string{
+		"beacon node a:5052 is currently syncing: distance=10",
+		"could not get beacon node b:5052 sync state",
+	}, gc.HealthCheck())
+}
+
+func TestGoClient_NoNodeConnected(t *testing.T) {
+	_, err := newTestClient(t, []*fakeNode{{addr: "a:5052"}}, map[string]bool{"a:5052": true})
+	require.Equal(t, errNoBeaconNode, err)
+}
@@ -18,7 +18,12 @@ func (gc *goClient) GetBeaconBlock(slot phase0.Slot, committeeIndex phase0.Commi
 	sig := phase0.BLSSignature{}
 	copy(sig[:], randao[:])
 
-	beaconBlockRoot, err := gc.client.BeaconBlockProposal(gc.ctx, slot, sig, graffiti)
+	var beaconBlockRoot *spec.VersionedBeaconBlock
+	err := gc.call(func(client Client) error {
+		var err error
+		beaconBlockRoot, err = client.BeaconBlockProposal(gc.ctx, slot, sig, graffiti)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +51,9 @@ func (gc *goClient) SubmitBeaconBlock(block *bellatrix.SignedBeaconBlock) error
 		Bellatrix: block,
 	}
 
-	return gc.client.SubmitBeaconBlock(gc.ctx, versionedBlock)
+	return gc.broadcast(func(client Client) error {
+		return client.SubmitBeaconBlock(gc.ctx, versionedBlock)
+	})
 }
 
 func (gc *goClient) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
@@ -57,5 +64,7 @@ func (gc *goClient) SubmitProposalPreparation(feeRecipients map[phase0.Validator
 			FeeRecipient:   recipient,
 		})
 	}
-	return gc.client.SubmitProposalPreparations(gc.ctx, preparations)
+	return gc.broadcast(func(client Client) error {
+		return client.SubmitProposalPreparations(gc.ctx, preparations)
+	})
 }
@@ -11,7 +11,12 @@ import (
 )
 
 func (gc *goClient) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (phase0.Domain, error) {
-	data, err := gc.client.Domain(gc.ctx, domain, epoch)
+	var data phase0.Domain
+	err := gc.call(func(client Client) error {
+		var err error
+		data, err = client.Domain(gc.ctx, domain, epoch)
+		return err
+	})
 	if err != nil {
 		return phase0.Domain{}, err
 	}
@@ -12,7 +12,12 @@ import (
 func (gc *goClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, error) {
 	// Wait a 1/3 into the slot.
 	gc.waitOneThirdOrValidBlock(slot)
-	root, err := gc.client.BeaconBlockRoot(gc.ctx, fmt.Sprint(slot))
+	var root *phase0.Root
+	err := gc.call(func(client Client) error {
+		var err error
+		root, err = client.BeaconBlockRoot(gc.ctx, fmt.Sprint(slot))
+		return err
+	})
 	if err != nil {
 		return phase0.Root{}, err
 	}
@@ -24,8 +29,7 @@ func (gc *goClient) GetSyncMessageBlockRoot(slot phase0.Slot) (phase0.Root, erro
 
 // SubmitSyncMessage submits a signed sync committee msg
 func (gc *goClient) SubmitSyncMessage(msg *altair.SyncCommitteeMessage) error {
-	if err := gc.client.SubmitSyncCommitteeMessages(gc.ctx, []*altair.SyncCommitteeMessage{msg}); err != nil {
-		return err
-	}
-	return nil
+	return gc.broadcast(func(client Client) error {
+		return client.SubmitSyncCommitteeMessages(gc.ctx, []*altair.SyncCommitteeMessage{msg})
+	})
 }
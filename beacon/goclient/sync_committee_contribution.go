@@ -31,19 +31,23 @@ func (gc *goClient) SyncCommitteeSubnetID(index phase0.CommitteeIndex) (uint64,
 
 // GetSyncCommitteeContribution returns
 func (gc *goClient) GetSyncCommitteeContribution(slot phase0.Slot, subnetID uint64) (*altair.SyncCommitteeContribution, error) {
-	gc.waitOneThirdOrValidBlock(slot)
-
-	blockRoot, err := gc.client.BeaconBlockRoot(gc.ctx, fmt.Sprint(slot))
-	if err != nil {
-		return nil, err
-	}
-	if blockRoot == nil {
-		return nil, errors.New("block root is nil")
-	}
-
+	// the contribution is produced at two thirds of the slot like the aggregate, not on the arrival of the block,
+	// as the sync committee messages of the slot are still being collected
 	gc.waitToSlotTwoThirds(slot)
 
-	contribution, err := gc.client.SyncCommitteeContribution(gc.ctx, slot, subnetID, *blockRoot)
+	// the block root and the contribution are fetched from the same node
+	var contribution *altair.SyncCommitteeContribution
+	err := gc.call(func(client Client) error {
+		blockRoot, err := client.BeaconBlockRoot(gc.ctx, fmt.Sprint(slot))
+		if err != nil {
+			return err
+		}
+		if blockRoot == nil {
+			return errors.New("block root is nil")
+		}
+		contribution, err = client.SyncCommitteeContribution(gc.ctx, slot, subnetID, *blockRoot)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -52,5 +56,7 @@ func (gc *goClient) GetSyncCommitteeContribution(slot phase0.Slot, subnetID uint
 
 // SubmitSignedContributionAndProof broadcasts to the network
 func (gc *goClient) SubmitSignedContributionAndProof(contribution *altair.SignedContributionAndProof) error {
-	return gc.client.SubmitSyncCommitteeContributions(gc.ctx, []*altair.SignedContributionAndProof{contribution})
+	return gc.broadcast(func(client Client) error {
+		return client.SubmitSyncCommitteeContributions(gc.ctx, []*altair.SignedContributionAndProof{contribution})
+	})
 }
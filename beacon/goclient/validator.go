@@ -7,5 +7,29 @@ import (
 
 // GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 func (gc *goClient) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*api.Validator, error) {
-	return gc.client.ValidatorsByPubKey(gc.ctx, "head", validatorPubKeys) // TODO maybe need to get the chainId (head) as var
+	var validators map[phase0.ValidatorIndex]*api.Validator
+	err := gc.call(func(client Client) error {
+		var err error
+		validators, err = client.ValidatorsByPubKey(gc.ctx, "head", validatorPubKeys) // TODO maybe need to get the chainId (head) as var
+		return err
+	})
+	return validators, err
+}
+
+// GetValidatorsLiveness returns whether the given validators were live in the given epoch
+func (gc *goClient) GetValidatorsLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error) {
+	var liveness map[phase0.ValidatorIndex]bool
+	err := gc.call(func(client Client) error {
+		var err error
+		liveness, err = client.ValidatorsLiveness(gc.ctx, epoch, validatorIndices)
+		return err
+	})
+	return liveness, err
+}
+
+// SubmitVoluntaryExit submits a signed voluntary exit of a validator
+func (gc *goClient) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	return gc.broadcast(func(client Client) error {
+		return client.SubmitVoluntaryExit(gc.ctx, voluntaryExit)
+	})
 }
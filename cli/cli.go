@@ -7,6 +7,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/cli/bootnode"
+	"github.com/bloxapp/ssv/cli/db"
 	"github.com/bloxapp/ssv/cli/operator"
 )
 
@@ -35,4 +36,11 @@ func Execute(appName, version string) {
 func init() {
 	RootCmd.AddCommand(bootnode.StartBootNodeCmd)
 	RootCmd.AddCommand(operator.StartNodeCmd)
+	RootCmd.AddCommand(operator.ExportSlashingProtectionCmd)
+	RootCmd.AddCommand(operator.ImportSlashingProtectionCmd)
+	RootCmd.AddCommand(operator.VerifySharesCmd)
+	RootCmd.AddCommand(operator.ExportEventsCmd)
+	RootCmd.AddCommand(operator.MigrationsCmd)
+	RootCmd.AddCommand(operator.ExitValidatorCmd)
+	RootCmd.AddCommand(db.DBCmd)
 }
@@ -0,0 +1,267 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/inspect"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// DBCmd is the parent command of the offline db commands, which run against the db of a stopped node
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Inspects, backs up and restores the db of a stopped node",
+}
+
+// statsCmd prints the number and size of the keys of every collection
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Prints the number and size of the keys of every collection in the db",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(cmd.Root().Short, zapcore.InfoLevel, nil)
+		db := openExistingDB(cmd, logger)
+		defer db.Close()
+
+		stats, err := inspect.Stats(db)
+		if err != nil {
+			logger.Fatal("failed to collect db stats", zap.Error(err))
+		}
+		printJSON(logger, stats)
+	},
+}
+
+// dumpCmd prints the decoded shares, operators, sync offset and QBFT instances
+var dumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Prints the shares, operators, sync offset and QBFT instances in the db as JSON, secrets are not included",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(cmd.Root().Short, zapcore.InfoLevel, nil)
+		db := openExistingDB(cmd, logger)
+		defer db.Close()
+
+		contents, err := inspect.Dump(db)
+		if err != nil {
+			logger.Fatal("failed to dump db", zap.Error(err))
+		}
+		printJSON(logger, contents)
+	},
+}
+
+// backupCmd writes a consistent snapshot of the db into a file
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Writes a consistent snapshot of the db into a file",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(cmd.Root().Short, zapcore.InfoLevel, nil)
+		file, err := flags.GetBackupFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		db := openExistingDB(cmd, logger)
+		defer db.Close()
+
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			logger.Fatal("failed to create backup file", zap.Error(err))
+		}
+		b, ok := db.(backuper)
+		if !ok {
+			_ = f.Close()
+			logger.Fatal("backup is not supported by the db type")
+		}
+		if err := b.Backup(f); err != nil {
+			_ = f.Close()
+			logger.Fatal("failed to backup db", zap.Error(err))
+		}
+		if err := f.Close(); err != nil {
+			logger.Fatal("failed to close backup file", zap.Error(err))
+		}
+		logger.Info("db backup is ready", zap.String("file", file))
+	},
+}
+
+// restoreCmd loads a backup into a new db
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores a backup into a new db, the db path must not exist or be empty",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(cmd.Root().Short, zapcore.InfoLevel, nil)
+		file, err := flags.GetBackupFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		path, err := flags.GetDBPathFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get db path flag value", zap.Error(err))
+		}
+		dbType, err := flags.GetDBTypeFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get db type flag value", zap.Error(err))
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil && !os.IsNotExist(err) {
+			logger.Fatal("failed to read db path", zap.Error(err))
+		}
+		if len(entries) > 0 {
+			logger.Fatal("db path is not empty, restoring is allowed only into a new db", zap.String("path", path))
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			logger.Fatal("failed to open backup file", zap.Error(err))
+		}
+		defer f.Close()
+
+		db, err := openDB(path, dbType)
+		if err != nil {
+			logger.Fatal("failed to open db", zap.Error(err))
+		}
+		defer db.Close()
+		r, ok := db.(restorer)
+		if !ok {
+			logger.Fatal("restore is not supported by the db type, a bolt-db backup is restored by placing it as the db file of an empty db path")
+		}
+		if err := r.Restore(f); err != nil {
+			logger.Fatal("failed to restore db", zap.Error(err))
+		}
+		logger.Info("db is restored", zap.String("path", path))
+	},
+}
+
+// verifyCmd verifies the checksums of the db, and that all known values can be decoded
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifies the checksums of the db, and that the shares, operators and QBFT instances can be decoded",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(cmd.Root().Short, zapcore.InfoLevel, nil)
+		db := openExistingDB(cmd, logger)
+		defer db.Close()
+
+		problems, err := inspect.Verify(db)
+		if err != nil {
+			logger.Fatal("failed to verify db", zap.Error(err))
+		}
+		if len(problems) > 0 {
+			logger.Fatal("db verification failed", zap.Strings("problems", problems))
+		}
+		logger.Info("db is valid")
+	},
+}
+
+// migrateBackendCmd copies all the data of the db into a db of another type
+var migrateBackendCmd = &cobra.Command{
+	Use:   "migrate-backend",
+	Short: "Copies all the data of the db into a new db of another type, e.g. from badger-db to bolt-db",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(cmd.Root().Short, zapcore.InfoLevel, nil)
+		path, err := flags.GetDBPathFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get db path flag value", zap.Error(err))
+		}
+		dbType, err := flags.GetDBTypeFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get db type flag value", zap.Error(err))
+		}
+		targetPath, err := flags.GetTargetDBPathFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get target db path flag value", zap.Error(err))
+		}
+		targetType, err := flags.GetTargetDBTypeFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get target db type flag value", zap.Error(err))
+		}
+		if _, err := os.Stat(path); err != nil {
+			logger.Fatal("failed to find db", zap.String("path", path), zap.Error(err))
+		}
+
+		source, err := openDB(path, dbType)
+		if err != nil {
+			logger.Fatal("failed to open db, make sure the node is stopped", zap.Error(err))
+		}
+		defer source.Close()
+		target, err := openDB(targetPath, targetType)
+		if err != nil {
+			logger.Fatal("failed to open target db", zap.Error(err))
+		}
+		defer target.Close()
+
+		copied, err := storage.Copy(source, target)
+		if err != nil {
+			logger.Fatal("failed to copy db", zap.Int("copied", copied), zap.Error(err))
+		}
+		logger.Info("db is migrated, set the db path and type of the node to the target db",
+			zap.String("path", targetPath), zap.String("type", targetType), zap.Int("items", copied))
+	},
+}
+
+// backuper is implemented by the db types that can write a snapshot of the db
+type backuper interface {
+	Backup(w io.Writer) error
+}
+
+// restorer is implemented by the db types that can load a snapshot into an open db
+type restorer interface {
+	Restore(r io.Reader) error
+}
+
+// openExistingDB opens the db of the db path and type flags, it must exist so that a wrong path won't create a new db
+func openExistingDB(cmd *cobra.Command, logger *zap.Logger) basedb.IDb {
+	path, err := flags.GetDBPathFlagValue(cmd)
+	if err != nil {
+		logger.Fatal("failed to get db path flag value", zap.Error(err))
+	}
+	dbType, err := flags.GetDBTypeFlagValue(cmd)
+	if err != nil {
+		logger.Fatal("failed to get db type flag value", zap.Error(err))
+	}
+	if _, err := os.Stat(path); err != nil {
+		logger.Fatal("failed to find db", zap.String("path", path), zap.Error(err))
+	}
+	db, err := openDB(path, dbType)
+	if err != nil {
+		logger.Fatal("failed to open db, make sure the node is stopped", zap.Error(err))
+	}
+	return db
+}
+
+func openDB(path, dbType string) (basedb.IDb, error) {
+	return storage.GetStorageFactory(basedb.Options{
+		Type:   dbType,
+		Path:   path,
+		Logger: zap.NewNop(),
+	})
+}
+
+func printJSON(logger *zap.Logger, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		logger.Fatal("failed to marshal json", zap.Error(err))
+	}
+	fmt.Println(string(data))
+}
+
+func init() {
+	flags.AddDBPathFlag(DBCmd)
+	flags.AddDBTypeFlag(DBCmd)
+	flags.AddBackupFileFlag(backupCmd)
+	flags.AddBackupFileFlag(restoreCmd)
+	flags.AddTargetDBPathFlag(migrateBackendCmd)
+	flags.AddTargetDBTypeFlag(migrateBackendCmd)
+
+	DBCmd.AddCommand(statsCmd)
+	DBCmd.AddCommand(dumpCmd)
+	DBCmd.AddCommand(backupCmd)
+	DBCmd.AddCommand(restoreCmd)
+	DBCmd.AddCommand(verifyCmd)
+	DBCmd.AddCommand(migrateBackendCmd)
+}
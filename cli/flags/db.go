@@ -0,0 +1,66 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	dbPathFlag       = "db-path"
+	dbTypeFlag       = "db-type"
+	targetDBPathFlag = "target-db-path"
+	targetDBTypeFlag = "target-db-type"
+	backupFileFlag   = "file"
+)
+
+// AddDBPathFlag adds the db path flag to the command
+func AddDBPathFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dbPathFlag, "./data/db", "Path of the node db, the node must be stopped", false)
+}
+
+// GetDBPathFlagValue gets the db path flag from the command
+func GetDBPathFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dbPathFlag)
+}
+
+// AddBackupFileFlag adds the backup file flag to the command
+func AddBackupFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, backupFileFlag, "", "Path of the backup file", true)
+}
+
+// GetBackupFileFlagValue gets the backup file flag from the command
+func GetBackupFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(backupFileFlag)
+}
+
+// AddDBTypeFlag adds the db type flag to the command
+func AddDBTypeFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dbTypeFlag, "badger-db", "Type of the node db, badger-db or bolt-db", false)
+}
+
+// GetDBTypeFlagValue gets the db type flag from the command
+func GetDBTypeFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dbTypeFlag)
+}
+
+// AddTargetDBPathFlag adds the target db path flag to the command
+func AddTargetDBPathFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, targetDBPathFlag, "", "Path of the target db, it must not exist or be empty", true)
+}
+
+// GetTargetDBPathFlagValue gets the target db path flag from the command
+func GetTargetDBPathFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(targetDBPathFlag)
+}
+
+// AddTargetDBTypeFlag adds the target db type flag to the command
+func AddTargetDBTypeFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, targetDBTypeFlag, "bolt-db", "Type of the target db, badger-db or bolt-db", false)
+}
+
+// GetTargetDBTypeFlagValue gets the target db type flag from the command
+func GetTargetDBTypeFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(targetDBTypeFlag)
+}
@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	exitPubKeyFlag = "pubkey"
+	exitEpochFlag  = "epoch"
+)
+
+// AddExitPubKeyFlag adds the public key of the validator to exit flag to the command
+func AddExitPubKeyFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, exitPubKeyFlag, "", "Hex encoded public key of the validator to exit", true)
+}
+
+// GetExitPubKeyFlagValue gets the public key of the validator to exit flag from the command
+func GetExitPubKeyFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(exitPubKeyFlag)
+}
+
+// AddExitEpochFlag adds the exit epoch flag to the command
+func AddExitEpochFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, exitEpochFlag, 0, "Epoch of the voluntary exit, all the operators of the validator must use the same epoch", true)
+}
+
+// GetExitEpochFlagValue gets the exit epoch flag from the command
+func GetExitEpochFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(exitEpochFlag)
+}
@@ -0,0 +1,44 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	fromBlockFlag  = "from"
+	toBlockFlag    = "to"
+	eventsFileFlag = "file"
+)
+
+// AddFromBlockFlag adds the from block flag to the command
+func AddFromBlockFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, fromBlockFlag, 0, "First block of the range to export", true)
+}
+
+// GetFromBlockFlagValue gets the from block flag from the command
+func GetFromBlockFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(fromBlockFlag)
+}
+
+// AddToBlockFlag adds the to block flag to the command
+func AddToBlockFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, toBlockFlag, 0, "Last block of the range to export", true)
+}
+
+// GetToBlockFlagValue gets the to block flag from the command
+func GetToBlockFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(toBlockFlag)
+}
+
+// AddEventsFileFlag adds the local events file flag to the command
+func AddEventsFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, eventsFileFlag, "", "Path to the local events YAML file to write", true)
+}
+
+// GetEventsFileFlagValue gets the local events file flag from the command
+func GetEventsFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(eventsFileFlag)
+}
@@ -0,0 +1,66 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	operatorsCountFlag  = "operators"
+	validatorsCountFlag = "validators"
+	ownerAddressFlag    = "owner-address"
+	outputDirFlag       = "output-dir"
+	dbDirFlag           = "db-dir"
+)
+
+// AddOperatorsCountFlag adds the operators count flag to the command
+func AddOperatorsCountFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, operatorsCountFlag, 4, "Count of operators (3f+1)", false)
+}
+
+// GetOperatorsCountFlagValue gets the operators count flag from the command
+func GetOperatorsCountFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(operatorsCountFlag)
+}
+
+// AddValidatorsCountFlag adds the validators count flag to the command
+func AddValidatorsCountFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, validatorsCountFlag, 1, "Count of validators", false)
+}
+
+// GetValidatorsCountFlagValue gets the validators count flag from the command
+func GetValidatorsCountFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(validatorsCountFlag)
+}
+
+// AddOwnerAddressFlag adds the owner address flag to the command
+func AddOwnerAddressFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, ownerAddressFlag, "0x0000000000000000000000000000000000000000", "Owner address of the operators and validators", false)
+}
+
+// GetOwnerAddressFlagValue gets the owner address flag from the command
+func GetOwnerAddressFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(ownerAddressFlag)
+}
+
+// AddOutputDirFlag adds the output dir flag to the command
+func AddOutputDirFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, outputDirFlag, "./config", "Dir of the generated events and share configs", false)
+}
+
+// GetOutputDirFlagValue gets the output dir flag from the command
+func GetOutputDirFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(outputDirFlag)
+}
+
+// AddDBDirFlag adds the db dir flag to the command
+func AddDBDirFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dbDirFlag, "./data/db", "Parent dir of the operators dbs", false)
+}
+
+// GetDBDirFlagValue gets the db dir flag from the command
+func GetDBDirFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dbDirFlag)
+}
@@ -0,0 +1,22 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	dryRunFlag = "dry-run"
+)
+
+// AddDryRunFlag adds the dry run flag to the command
+func AddDryRunFlag(c *cobra.Command) {
+	cliflag.AddPersistentBoolFlag(c, dryRunFlag, false, "Report the changes of the pending migrations without applying them", false)
+}
+
+// GetDryRunFlagValue gets the dry run flag from the command
+func GetDryRunFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(dryRunFlag)
+}
@@ -0,0 +1,44 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	passwordFileFlag   = "password-file"
+	keystoreOutputFlag = "output"
+	operatorKeyFlag    = "operator-key"
+)
+
+// AddPasswordFileFlag adds the keystore password file flag to the command
+func AddPasswordFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, passwordFileFlag, "", "Path to a file containing the password used to encrypt the operator key", false)
+}
+
+// GetPasswordFileFlagValue gets the keystore password file flag from the command
+func GetPasswordFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(passwordFileFlag)
+}
+
+// AddKeystoreOutputFlag adds the keystore output path flag to the command
+func AddKeystoreOutputFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, keystoreOutputFlag, "./encrypted_private_key.json", "Path of the encrypted operator key file", false)
+}
+
+// GetKeystoreOutputFlagValue gets the keystore output path flag from the command
+func GetKeystoreOutputFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(keystoreOutputFlag)
+}
+
+// AddOperatorKeyFlag adds the existing operator key flag to the command
+func AddOperatorKeyFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, operatorKeyFlag, "", "Existing base64 operator private key to encrypt instead of generating a new one", false)
+}
+
+// GetOperatorKeyFlagValue gets the existing operator key flag from the command
+func GetOperatorKeyFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(operatorKeyFlag)
+}
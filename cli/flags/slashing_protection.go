@@ -0,0 +1,22 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	interchangeFileFlag = "file"
+)
+
+// AddInterchangeFileFlag adds the slashing protection interchange file flag to the command
+func AddInterchangeFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, interchangeFileFlag, "", "Path to EIP-3076 slashing protection interchange JSON file", true)
+}
+
+// GetInterchangeFileFlagValue gets the slashing protection interchange file flag from the command
+func GetInterchangeFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(interchangeFileFlag)
+}
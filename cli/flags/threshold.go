@@ -8,10 +8,18 @@ import (
 
 // Flag names.
 const (
-	privKeyFlag   = "private-key"
-	keysCountFlag = "count"
+	privKeyFlag                 = "private-key"
+	keysCountFlag               = "count"
+	thresholdFlag               = "threshold"
+	operatorIDsFlag             = "operator-ids"
+	operatorPublicKeysFlag      = "operator-keys"
+	keySharesFileFlag           = "file"
+	operatorPrivateKeyFilesFlag = "operator-private-key-files"
+	keySharesOutputFlag         = "output"
 )
 
+const defaultKeySharesOutput = "./keyshares.json"
+
 // AddPrivKeyFlag adds the private key flag to the command
 func AddPrivKeyFlag(c *cobra.Command) {
 	cliflag.AddPersistentStringFlag(c, privKeyFlag, "", "Hex encoded private key", true)
@@ -24,10 +32,70 @@ func GetPrivKeyFlagValue(c *cobra.Command) (string, error) {
 
 // AddKeysCountFlag adds the keys count flag to the command
 func AddKeysCountFlag(c *cobra.Command) {
-	cliflag.AddPersistentIntFlag(c, keysCountFlag, 4, "Count of threshold keys to be generated", false)
+	cliflag.AddPersistentIntFlag(c, keysCountFlag, 4, "Count of threshold keys to be generated, the committee size (3f+1)", false)
 }
 
 // GetKeysCountFlagValue gets the keys count flag from the command
 func GetKeysCountFlagValue(c *cobra.Command) (uint64, error) {
 	return c.Flags().GetUint64(keysCountFlag)
 }
+
+// AddThresholdFlag adds the threshold flag to the command
+func AddThresholdFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, thresholdFlag, 0, "Count of shares needed to reconstruct a signature, defaults to the committee quorum (2f+1)", false)
+}
+
+// GetThresholdFlagValue gets the threshold flag from the command
+func GetThresholdFlagValue(c *cobra.Command) (uint64, error) {
+	return c.Flags().GetUint64(thresholdFlag)
+}
+
+// AddOperatorIDsFlag adds the operator ids flag to the command
+func AddOperatorIDsFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringSliceFlag(c, operatorIDsFlag, nil, "Comma separated operator ids, defaults to 1..count", false)
+}
+
+// GetOperatorIDsFlagValue gets the operator ids flag from the command
+func GetOperatorIDsFlagValue(c *cobra.Command) ([]string, error) {
+	return c.Flags().GetStringSlice(operatorIDsFlag)
+}
+
+// AddOperatorPublicKeysFlag adds the operator public keys flag to the command
+func AddOperatorPublicKeysFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringSliceFlag(c, operatorPublicKeysFlag, nil, "Comma separated base64 operator public keys, in the same order as the operator ids", true)
+}
+
+// GetOperatorPublicKeysFlagValue gets the operator public keys flag from the command
+func GetOperatorPublicKeysFlagValue(c *cobra.Command) ([]string, error) {
+	return c.Flags().GetStringSlice(operatorPublicKeysFlag)
+}
+
+// AddKeySharesOutputFlag adds the key shares output path flag to the command
+func AddKeySharesOutputFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, keySharesOutputFlag, defaultKeySharesOutput, "Path of the key shares JSON file", false)
+}
+
+// GetKeySharesOutputFlagValue gets the key shares output path flag from the command
+func GetKeySharesOutputFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(keySharesOutputFlag)
+}
+
+// AddKeySharesFileFlag adds the key shares file flag to the command
+func AddKeySharesFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, keySharesFileFlag, "", "Path of the key shares JSON file created by create-threshold", true)
+}
+
+// GetKeySharesFileFlagValue gets the key shares file flag from the command
+func GetKeySharesFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(keySharesFileFlag)
+}
+
+// AddOperatorPrivateKeyFilesFlag adds the operator private key files flag to the command
+func AddOperatorPrivateKeyFilesFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringSliceFlag(c, operatorPrivateKeyFilesFlag, nil, "Comma separated paths of files containing base64 operator private keys, used to decrypt the shares", true)
+}
+
+// GetOperatorPrivateKeyFilesFlagValue gets the operator private key files flag from the command
+func GetOperatorPrivateKeyFilesFlagValue(c *cobra.Command) ([]string, error) {
+	return c.Flags().GetStringSlice(operatorPrivateKeyFilesFlag)
+}
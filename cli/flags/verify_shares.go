@@ -0,0 +1,22 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/bloxapp/ssv/utils/cliflag"
+)
+
+// Flag names.
+const (
+	registrationPayloadFileFlag = "payload"
+)
+
+// AddRegistrationPayloadFileFlag adds the validator registration payload file flag to the command
+func AddRegistrationPayloadFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, registrationPayloadFileFlag, "", "Path to validator registration payload JSON file", true)
+}
+
+// GetRegistrationPayloadFileFlagValue gets the validator registration payload file flag from the command
+func GetRegistrationPayloadFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(registrationPayloadFileFlag)
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/utils/localnetwork"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// generateLocalNetworkCmd is the command to generate the keys, local events and share configs of a local network
+var generateLocalNetworkCmd = &cobra.Command{
+	Use:   "generate-local-network",
+	Short: "Generates operators, validators and their local events and share configs for a local network",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+
+		operators, err := flags.GetOperatorsCountFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get operators flag value", zap.Error(err))
+		}
+		validators, err := flags.GetValidatorsCountFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get validators flag value", zap.Error(err))
+		}
+		ownerAddress, err := flags.GetOwnerAddressFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get owner address flag value", zap.Error(err))
+		}
+		if !common.IsHexAddress(ownerAddress) {
+			logger.Fatal("invalid owner address", zap.String("ownerAddress", ownerAddress))
+		}
+		outputDir, err := flags.GetOutputDirFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get output dir flag value", zap.Error(err))
+		}
+		dbDir, err := flags.GetDBDirFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get db dir flag value", zap.Error(err))
+		}
+
+		threshold.Init()
+		n, err := localnetwork.Generate(localnetwork.Options{
+			Operators:    operators,
+			Validators:   validators,
+			OwnerAddress: common.HexToAddress(ownerAddress),
+			OutputDir:    outputDir,
+			DBDir:        dbDir,
+		})
+		if err != nil {
+			logger.Fatal("failed to generate local network", zap.Error(err))
+		}
+		if err := n.Write(); err != nil {
+			logger.Fatal("failed to write local network", zap.Error(err))
+		}
+
+		for _, o := range n.Operators {
+			logger.Info("generated operator", zap.Uint64("id", o.ID),
+				zap.String("shareConfig", n.ShareConfigPath(o.ID)))
+		}
+		for _, v := range n.Validators {
+			logger.Info("generated validator", zap.String("pubKey", v.PublicKey))
+		}
+		logger.Info("local network is ready", zap.String("dir", outputDir))
+	},
+}
+
+func init() {
+	flags.AddOperatorsCountFlag(generateLocalNetworkCmd)
+	flags.AddValidatorsCountFlag(generateLocalNetworkCmd)
+	flags.AddOwnerAddressFlag(generateLocalNetworkCmd)
+	flags.AddOutputDirFlag(generateLocalNetworkCmd)
+	flags.AddDBDirFlag(generateLocalNetworkCmd)
+
+	RootCmd.AddCommand(generateLocalNetworkCmd)
+}
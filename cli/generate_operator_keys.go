@@ -1,11 +1,16 @@
 package cli
 
 import (
+	"encoding/base64"
+	"os"
+	"strings"
+
 	"github.com/bloxapp/ssv/utils/logex"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/bloxapp/ssv/cli/flags"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 )
 
@@ -16,15 +21,67 @@ var generateOperatorKeysCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
 
-		pk, sk, err := rsaencryption.GenerateKeys()
+		passwordFile, err := flags.GetPasswordFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get password file flag value", zap.Error(err))
+		}
+		operatorKey, err := flags.GetOperatorKeyFlagValue(cmd)
 		if err != nil {
-			logger.Fatal("Failed to generate operator keys", zap.Error(err))
+			logger.Fatal("failed to get operator key flag value", zap.Error(err))
+		}
+
+		var pk, sk []byte
+		if len(operatorKey) > 0 {
+			sk, err = base64.StdEncoding.DecodeString(operatorKey)
+			if err != nil {
+				logger.Fatal("failed to decode operator key", zap.Error(err))
+			}
+			parsedSk, err := rsaencryption.ConvertPemToPrivateKey(string(sk))
+			if err != nil {
+				logger.Fatal("failed to parse operator key", zap.Error(err))
+			}
+			pkBase64, err := rsaencryption.ExtractPublicKey(parsedSk)
+			if err != nil {
+				logger.Fatal("failed to extract operator public key", zap.Error(err))
+			}
+			pk, _ = base64.StdEncoding.DecodeString(pkBase64)
+		} else {
+			pk, sk, err = rsaencryption.GenerateKeys()
+			if err != nil {
+				logger.Fatal("Failed to generate operator keys", zap.Error(err))
+			}
 		}
 		logger.Info("generated public key (base64)", zap.Any("pk", pk))
-		logger.Info("generated private key (base64)", zap.Any("sk", sk))
+
+		// without a password the private key is printed in plaintext
+		if len(passwordFile) == 0 {
+			logger.Info("generated private key (base64)", zap.Any("sk", sk))
+			return
+		}
+
+		password, err := os.ReadFile(passwordFile)
+		if err != nil {
+			logger.Fatal("failed to read password file", zap.Error(err))
+		}
+		keystore, err := rsaencryption.EncryptKeystore(sk, strings.TrimSpace(string(password)), rsaencryption.KdfScrypt)
+		if err != nil {
+			logger.Fatal("failed to encrypt operator key", zap.Error(err))
+		}
+		output, err := flags.GetKeystoreOutputFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get output flag value", zap.Error(err))
+		}
+		if err := os.WriteFile(output, keystore, 0600); err != nil {
+			logger.Fatal("failed to write encrypted operator key", zap.Error(err))
+		}
+		logger.Info("saved encrypted private key", zap.String("file", output))
 	},
 }
 
 func init() {
+	flags.AddPasswordFileFlag(generateOperatorKeysCmd)
+	flags.AddKeystoreOutputFlag(generateOperatorKeysCmd)
+	flags.AddOperatorKeyFlag(generateOperatorKeysCmd)
+
 	RootCmd.AddCommand(generateOperatorKeysCmd)
 }
@@ -0,0 +1,66 @@
+package operator
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/operator/validator"
+)
+
+// ExitValidatorCmd is the command to request a voluntary exit of a validator,
+// the exit is signed and submitted once the node starts and a threshold of the operators requested it
+var ExitValidatorCmd = &cobra.Command{
+	Use:   "exit-validator",
+	Short: "Requests a voluntary exit of a validator, which is signed with the other operators once the node starts",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setupGlobal(cmd)
+		setupSSVNetwork(logger)
+
+		// the request is saved for the node, which runs the migrations once it starts
+		cfg.DBOptions.Ctx = cmd.Context()
+		db := openDb(logger)
+		defer db.Close()
+		_, operatorPubKey := readOperatorStorage(db)
+
+		pubKey, err := flags.GetExitPubKeyFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get pubkey flag value", zap.Error(err))
+		}
+		pubKey = strings.ToLower(strings.TrimPrefix(pubKey, "0x"))
+		epoch, err := flags.GetExitEpochFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get epoch flag value", zap.Error(err))
+		}
+
+		pk, err := hex.DecodeString(pubKey)
+		if err != nil {
+			logger.Fatal("invalid validator public key", zap.Error(err))
+		}
+		share, found, err := validator.NewCollection(validator.CollectionOptions{DB: db, Logger: logger}).GetValidatorShare(pk)
+		if err != nil {
+			logger.Fatal("failed to get validator share", zap.Error(err))
+		}
+		if !found || !share.BelongsToOperator(operatorPubKey) {
+			logger.Fatal("validator is not managed by this operator", zap.String("pubKey", pubKey))
+		}
+
+		request := &validator.ExitRequest{PubKey: pubKey, Epoch: phase0.Epoch(epoch)}
+		if err := validator.NewExitRequests(db).SaveExitRequest(request); err != nil {
+			logger.Fatal("failed to save exit request", zap.Error(err))
+		}
+		logger.Info("saved exit request, the exit is dispatched once the node starts",
+			zap.String("pubKey", pubKey), zap.Uint64("epoch", epoch))
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, ExitValidatorCmd)
+	flags.AddExitPubKeyFlag(ExitValidatorCmd)
+	flags.AddExitEpochFlag(ExitValidatorCmd)
+}
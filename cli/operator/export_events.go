@@ -0,0 +1,65 @@
+package operator
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/eth1"
+)
+
+// ExportEventsCmd is the command to export the registry contract events of a blocks range
+// in the local events format, which is loaded by the node with LocalEventsPath
+var ExportEventsCmd = &cobra.Command{
+	Use:   "export-events",
+	Short: "Exports the registry contract events of a blocks range as local events YAML",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setupGlobal(cmd)
+
+		fromBlock, err := flags.GetFromBlockFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get from flag value", zap.Error(err))
+		}
+		toBlock, err := flags.GetToBlockFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get to flag value", zap.Error(err))
+		}
+		if fromBlock > toBlock {
+			logger.Fatal("invalid blocks range", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+		}
+		filePath, err := flags.GetEventsFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+
+		cfg.ETH2Options.Context = cmd.Context()
+		fetcher, ok := setupEth1Client(logger).(eth1.EventsFetcher)
+		if !ok {
+			logger.Fatal("the eth1 client can't fetch events")
+		}
+		events, err := fetcher.FetchEvents(fromBlock, toBlock)
+		if err != nil {
+			logger.Fatal("failed to fetch events", zap.Error(err))
+		}
+		data, err := yaml.Marshal(events)
+		if err != nil {
+			logger.Fatal("failed to marshal events", zap.Error(err))
+		}
+		if err := os.WriteFile(filePath, data, 0600); err != nil {
+			logger.Fatal("failed to write events file", zap.Error(err))
+		}
+		logger.Info("exported events", zap.String("file", filePath),
+			zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Int("events", len(events)))
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, ExportEventsCmd)
+	flags.AddFromBlockFlag(ExportEventsCmd)
+	flags.AddToBlockFlag(ExportEventsCmd)
+	flags.AddEventsFileFlag(ExportEventsCmd)
+}
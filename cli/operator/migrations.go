@@ -0,0 +1,91 @@
+package operator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/migrations"
+)
+
+// MigrationsCmd is the parent command of the migrations commands, which run against the db of a stopped node
+var MigrationsCmd = &cobra.Command{
+	Use:   "migrations",
+	Short: "Lists and runs the db migrations of a stopped node",
+}
+
+// migrationsStatusCmd lists the applied and pending migrations
+var migrationsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Lists the applied and pending migrations, by their order",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setupGlobal(cmd)
+		eth2Network, _ := setupSSVNetwork(logger)
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db := openDb(logger)
+		defer db.Close()
+
+		status, err := migrations.Status(migrationOptions(db, logger, eth2Network))
+		if err != nil {
+			logger.Fatal("failed to get migrations status", zap.Error(err))
+		}
+		for _, s := range status {
+			marker, backup := "[ ]", ""
+			if s.Applied {
+				marker = "[x]"
+			}
+			if s.Backup {
+				backup = " (backup)"
+			}
+			fmt.Printf("%s %s%s\n", marker, s.Name, backup)
+		}
+	},
+}
+
+// migrationsRunCmd runs the pending migrations, or reports their changes in a dry run
+var migrationsRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Runs the pending migrations, a snapshot of the db is taken before destructive migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setupGlobal(cmd)
+		eth2Network, _ := setupSSVNetwork(logger)
+		dryRun, err := flags.GetDryRunFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get dry run flag value", zap.Error(err))
+		}
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db := openDb(logger)
+		defer db.Close()
+		opt := migrationOptions(db, logger, eth2Network)
+
+		if !dryRun {
+			if err := migrations.Run(cmd.Context(), opt); err != nil {
+				logger.Fatal("failed to run migrations", zap.Error(err))
+			}
+			return
+		}
+		reports, err := migrations.DryRun(cmd.Context(), opt)
+		if err != nil {
+			logger.Fatal("failed to dry run migrations", zap.Error(err))
+		}
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			logger.Fatal("failed to marshal json", zap.Error(err))
+		}
+		fmt.Println(string(data))
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, MigrationsCmd)
+	flags.AddDryRunFlag(migrationsRunCmd)
+
+	MigrationsCmd.AddCommand(migrationsStatusCmd)
+	MigrationsCmd.AddCommand(migrationsRunCmd)
+}
@@ -2,14 +2,20 @@ package operator
 
 import (
 	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/bloxapp/eth2-key-manager/core"
 	"github.com/ilyakaznacheev/cleanenv"
 	logging "github.com/ipfs/go-log"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
@@ -42,6 +48,18 @@ import (
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 )
 
+// KeyStore holds the paths of the encrypted operator private key and its password
+type KeyStore struct {
+	PrivateKeyFile string `yaml:"PrivateKeyFile" env:"PRIVATE_KEY_FILE" env-description:"Path to operator private key file, encrypted with a password (generate-operator-keys --password-file)"`
+	PasswordFile   string `yaml:"PasswordFile" env:"PASSWORD_FILE" env-description:"Path to password file of the operator private key file"`
+}
+
+// ShareEncryption holds the configuration of share secrets encryption at rest
+type ShareEncryption struct {
+	Mode         string `yaml:"Mode" env:"SHARE_ENCRYPTION_MODE" env-default:"none" env-description:"Encryption at rest of share secrets, valid values are 'none' (default), 'operator-key' or 'password'"`
+	PasswordFile string `yaml:"PasswordFile" env:"SHARE_ENCRYPTION_PASSWORD_FILE" env-description:"Path to password file of share secrets encryption, used by 'password' mode"`
+}
+
 type config struct {
 	global_config.GlobalConfig `yaml:"global"`
 	DBOptions                  basedb.Options         `yaml:"db"`
@@ -49,6 +67,8 @@ type config struct {
 	ETH1Options                eth1.Options           `yaml:"eth1"`
 	ETH2Options                beaconprotocol.Options `yaml:"eth2"`
 	P2pNetworkConfig           p2pv1.Config           `yaml:"p2p"`
+	KeyStore                   KeyStore               `yaml:"KeyStore"`
+	ShareEncryption            ShareEncryption        `yaml:"ShareEncryption"`
 
 	OperatorPrivateKey         string `yaml:"OperatorPrivateKey" env:"OPERATOR_KEY" env-description:"Operator private key, used to decrypt contract events"`
 	GenerateOperatorPrivateKey bool   `yaml:"GenerateOperatorPrivateKey" env:"GENERATE_OPERATOR_KEY" env-description:"Whether to generate operator key if none is passed by config"`
@@ -60,6 +80,9 @@ type config struct {
 	WithPing  bool `yaml:"WithPing" env:"WITH_PING" env-description:"Whether to send websocket ping messages'"`
 
 	LocalEventsPath string `yaml:"LocalEventsPath" env:"EVENTS_PATH" env-description:"path to local events"`
+
+	MigrationsBackupDir string `yaml:"MigrationsBackupDir" env:"MIGRATIONS_BACKUP_DIR" env-default:"./data/backups" env-description:"Dir of the db snapshots that are taken before destructive migrations"`
+	MigrationsDryRunDir string `yaml:"MigrationsDryRunDir" env:"MIGRATIONS_DRY_RUN_DIR" env-description:"Dir of the temporary db copy of the migrations dry run, the OS temp dir if it's empty"`
 }
 
 var cfg config
@@ -75,13 +98,21 @@ var StartNodeCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		logger := setupGlobal(cmd)
 
+		if err := cfg.SSVOptions.ValidatorOptions.HistoryRetention.Validate(); err != nil {
+			logger.Fatal("invalid history retention options", zap.Error(err))
+		}
+
 		eth2Network, forkVersion := setupSSVNetwork(logger)
 
 		cfg.DBOptions.Ctx = cmd.Context()
 		db := setupDb(logger, eth2Network)
 		operatorStorage, operatorPubKey := setupOperatorStorage(db)
 
-		keyManager, err := ekm.NewETHKeyManagerSigner(db, eth2Network, types.GetDefaultDomain(), logger)
+		encryptionSecret, err := shareEncryptionSecret(operatorStorage)
+		if err != nil {
+			logger.Fatal("could not get share encryption secret", zap.Error(err))
+		}
+		keyManager, err := ekm.NewETHKeyManagerSigner(db, eth2Network, types.GetDefaultDomain(), logger, encryptionSecret)
 		if err != nil {
 			logger.Fatal("could not create new eth-key-manager signer", zap.Error(err))
 		}
@@ -171,7 +202,7 @@ func init() {
 }
 
 func setupGlobal(cmd *cobra.Command) *zap.Logger {
-	commons.SetBuildData(cmd.Parent().Short, cmd.Parent().Version)
+	commons.SetBuildData(cmd.Root().Short, cmd.Root().Version)
 	log.Printf("starting %s", commons.GetBuildData())
 	if err := cleanenv.ReadConfig(globalArgs.ConfigPath, &cfg); err != nil {
 		log.Fatalf("could not read config %s", err)
@@ -197,30 +228,52 @@ func setupGlobal(cmd *cobra.Command) *zap.Logger {
 }
 
 func setupDb(logger *zap.Logger, eth2Network beaconprotocol.Network) basedb.IDb {
+	db := openDb(logger)
+
+	err := migrations.Run(cfg.DBOptions.Ctx, migrationOptions(db, logger, eth2Network))
+	if err != nil {
+		logger.Fatal("failed to run migrations", zap.Error(err))
+	}
+
+	return db
+}
+
+// openDb opens the db without running the migrations
+func openDb(logger *zap.Logger) basedb.IDb {
 	cfg.DBOptions.Logger = logger
 
 	db, err := storage.GetStorageFactory(cfg.DBOptions)
 	if err != nil {
 		logger.Fatal("failed to create db!", zap.Error(err))
 	}
+	return db
+}
 
-	migrationOpts := migrations.Options{
+func migrationOptions(db basedb.IDb, logger *zap.Logger, eth2Network beaconprotocol.Network) migrations.Options {
+	return migrations.Options{
 		Db:      db,
 		Logger:  logger,
 		DbPath:  cfg.DBOptions.Path,
+		DbType:  cfg.DBOptions.Type,
 		Network: eth2Network,
-	}
-	err = migrations.Run(cfg.DBOptions.Ctx, migrationOpts)
-	if err != nil {
-		logger.Fatal("failed to run migrations", zap.Error(err))
-	}
 
-	return db
+		CompactHistory: cfg.SSVOptions.ValidatorOptions.CompactHistory,
+		BackupDir:      cfg.MigrationsBackupDir,
+		DryRunDir:      cfg.MigrationsDryRunDir,
+	}
 }
 
 func setupOperatorStorage(db basedb.IDb) (operatorstorage.Storage, string) {
 	nodeStorage := operatorstorage.NewNodeStorage(db, cfg.DBOptions.Logger)
-	if err := nodeStorage.SetupPrivateKey(cfg.GenerateOperatorPrivateKey, cfg.OperatorPrivateKey); err != nil {
+	if len(cfg.KeyStore.PrivateKeyFile) > 0 {
+		skPem, err := decryptOperatorKeystore(cfg.KeyStore.PrivateKeyFile, cfg.KeyStore.PasswordFile)
+		if err != nil {
+			cfg.DBOptions.Logger.Fatal("failed to decrypt operator private key file", zap.Error(err))
+		}
+		if err := nodeStorage.SetupKeystorePrivateKey(skPem); err != nil {
+			cfg.DBOptions.Logger.Fatal("failed to setup operator private key", zap.Error(err))
+		}
+	} else if err := nodeStorage.SetupPrivateKey(cfg.GenerateOperatorPrivateKey, cfg.OperatorPrivateKey); err != nil {
 		cfg.DBOptions.Logger.Fatal("failed to setup operator private key", zap.Error(err))
 	}
 	operatorPrivateKey, found, err := nodeStorage.GetPrivateKey()
@@ -234,6 +287,97 @@ func setupOperatorStorage(db basedb.IDb) (operatorstorage.Storage, string) {
 	return nodeStorage, operatorPubKey
 }
 
+// readOperatorStorage opens the node storage without changing the db, for the offline commands that read the db.
+// the operator key is read from the db, or from the configured keystore or private key when it isn't saved in the db
+func readOperatorStorage(db basedb.IDb) (operatorstorage.Storage, string) {
+	nodeStorage := operatorstorage.NewNodeStorage(db, cfg.DBOptions.Logger)
+	operatorPrivateKey, found, err := nodeStorage.GetPrivateKey()
+	if err != nil {
+		cfg.DBOptions.Logger.Fatal("failed to get operator private key", zap.Error(err))
+	}
+	if !found {
+		operatorPrivateKey, err = configuredOperatorPrivateKey()
+		if err != nil {
+			cfg.DBOptions.Logger.Fatal("failed to get operator private key", zap.Error(err))
+		}
+		nodeStorage.SetPrivateKey(operatorPrivateKey)
+	}
+	operatorPubKey, err := rsaencryption.ExtractPublicKey(operatorPrivateKey)
+	if err != nil {
+		cfg.DBOptions.Logger.Fatal("failed to extract operator public key", zap.Error(err))
+	}
+	return nodeStorage, operatorPubKey
+}
+
+// configuredOperatorPrivateKey reads the operator private key from the configured keystore or private key
+func configuredOperatorPrivateKey() (*rsa.PrivateKey, error) {
+	var skPem []byte
+	var err error
+	switch {
+	case len(cfg.KeyStore.PrivateKeyFile) > 0:
+		skPem, err = decryptOperatorKeystore(cfg.KeyStore.PrivateKeyFile, cfg.KeyStore.PasswordFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt operator private key file")
+		}
+	case len(cfg.OperatorPrivateKey) > 0:
+		skPem, err = base64.StdEncoding.DecodeString(cfg.OperatorPrivateKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode operator private key")
+		}
+	default:
+		return nil, errors.New("operator private key was not found in the db nor configured")
+	}
+	return rsaencryption.ConvertPemToPrivateKey(string(skPem))
+}
+
+// decryptOperatorKeystore reads and decrypts the operator private key file with the given password file
+func decryptOperatorKeystore(privateKeyFile, passwordFile string) ([]byte, error) {
+	if len(passwordFile) == 0 {
+		return nil, errors.New("password file is required for the operator private key file")
+	}
+	keystore, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read private key file")
+	}
+	password, err := os.ReadFile(passwordFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read password file")
+	}
+	return rsaencryption.DecryptKeystore(keystore, strings.TrimSpace(string(password)))
+}
+
+// shareEncryptionSecret returns the secret used to derive the share encryption key, according to the configured mode
+func shareEncryptionSecret(operatorStorage operatorstorage.Storage) ([]byte, error) {
+	switch cfg.ShareEncryption.Mode {
+	case "", "none":
+		return nil, nil
+	case "operator-key":
+		sk, found, err := operatorStorage.GetPrivateKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get operator private key")
+		}
+		if !found {
+			return nil, errors.New("operator private key not found")
+		}
+		return x509.MarshalPKCS1PrivateKey(sk), nil
+	case "password":
+		if len(cfg.ShareEncryption.PasswordFile) == 0 {
+			return nil, errors.New("password file is required for 'password' share encryption mode")
+		}
+		password, err := os.ReadFile(cfg.ShareEncryption.PasswordFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read share encryption password file")
+		}
+		password = []byte(strings.TrimSpace(string(password)))
+		if len(password) == 0 {
+			return nil, errors.New("share encryption password is empty")
+		}
+		return password, nil
+	default:
+		return nil, errors.Errorf("unknown share encryption mode %s", cfg.ShareEncryption.Mode)
+	}
+}
+
 func setupSSVNetwork(logger *zap.Logger) (beaconprotocol.Network, forksprotocol.ForkVersion) {
 	if len(cfg.P2pNetworkConfig.NetworkID) == 0 {
 		cfg.P2pNetworkConfig.NetworkID = string(types.GetDefaultDomain())
@@ -280,22 +424,29 @@ func setupNodes(logger *zap.Logger) (beaconprotocol.Beacon, eth1.Client) {
 	cl, err := goclient.New(cfg.ETH2Options)
 	if err != nil {
 		logger.Fatal("failed to create beacon go-client", zap.Error(err),
-			zap.String("addr", cfg.ETH2Options.BeaconNodeAddr))
+			zap.Strings("addrs", cfg.ETH2Options.Addrs()))
 	}
 
-	// execution client
+	return cl, setupEth1Client(logger)
+}
+
+// setupEth1Client creates the execution client of the registry contract
+func setupEth1Client(logger *zap.Logger) eth1.Client {
 	logger.Info("using registry contract address", zap.String("address", cfg.ETH1Options.RegistryContractAddr), zap.String("abi version", cfg.ETH1Options.AbiVersion.String()))
 	if len(cfg.ETH1Options.RegistryContractABI) > 0 {
 		logger.Info("using registry contract abi", zap.String("abi", cfg.ETH1Options.RegistryContractABI))
-		if err = eth1.LoadABI(cfg.ETH1Options.RegistryContractABI); err != nil {
+		if err := eth1.LoadABI(cfg.ETH1Options.RegistryContractABI); err != nil {
 			logger.Fatal("failed to load ABI JSON", zap.Error(err))
 		}
 	}
 	el, err := goeth.NewEth1Client(goeth.ClientOptions{
 		Ctx:                  cfg.ETH2Options.Context,
 		Logger:               logger,
-		NodeAddr:             cfg.ETH1Options.ETH1Addr,
+		NodeAddrs:            cfg.ETH1Options.Addrs(),
 		ConnectionTimeout:    cfg.ETH1Options.ETH1ConnectionTimeout,
+		FollowDistance:       cfg.ETH1Options.ETH1FollowDistance,
+		PollingInterval:      cfg.ETH1Options.ETH1PollingInterval,
+		CrossCheck:           cfg.ETH1Options.ETH1CrossCheck,
 		ContractABI:          eth1.ContractABI(cfg.ETH1Options.AbiVersion),
 		RegistryContractAddr: cfg.ETH1Options.RegistryContractAddr,
 		AbiVersion:           cfg.ETH1Options.AbiVersion,
@@ -304,7 +455,7 @@ func setupNodes(logger *zap.Logger) (beaconprotocol.Beacon, eth1.Client) {
 		logger.Fatal("failed to create eth1 client", zap.Error(err))
 	}
 
-	return cl, el
+	return el
 }
 
 func startMetricsHandler(ctx context.Context, logger *zap.Logger, db basedb.IDb, port int, enableProf bool) {
@@ -0,0 +1,91 @@
+package operator
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/ekm"
+)
+
+// ExportSlashingProtectionCmd is the command to export the slashing protection data of all shares
+// as an EIP-3076 interchange file
+var ExportSlashingProtectionCmd = &cobra.Command{
+	Use:   "export-slashing-protection",
+	Short: "Exports the slashing protection data of all shares as EIP-3076 interchange JSON",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setupGlobal(cmd)
+		eth2Network, _ := setupSSVNetwork(logger)
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db := openDb(logger)
+		defer db.Close()
+
+		filePath, err := flags.GetInterchangeFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+
+		signerStorage := ekm.NewSignerStorage(db, eth2Network, logger)
+		interchange, err := ekm.ExportSlashingProtection(signerStorage, eth2Network)
+		if err != nil {
+			logger.Fatal("failed to export slashing protection", zap.Error(err))
+		}
+		data, err := json.MarshalIndent(interchange, "", "  ")
+		if err != nil {
+			logger.Fatal("failed to marshal interchange", zap.Error(err))
+		}
+		if err := os.WriteFile(filePath, data, 0600); err != nil {
+			logger.Fatal("failed to write interchange file", zap.Error(err))
+		}
+		logger.Info("exported slashing protection", zap.String("file", filePath),
+			zap.Int("validators", len(interchange.Data)))
+	},
+}
+
+// ImportSlashingProtectionCmd is the command to import an EIP-3076 interchange file,
+// merging it with the existing slashing protection data
+var ImportSlashingProtectionCmd = &cobra.Command{
+	Use:   "import-slashing-protection",
+	Short: "Imports EIP-3076 slashing protection interchange JSON, keeping the higher values",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setupGlobal(cmd)
+		eth2Network, _ := setupSSVNetwork(logger)
+
+		cfg.DBOptions.Ctx = cmd.Context()
+		db := openDb(logger)
+		defer db.Close()
+
+		filePath, err := flags.GetInterchangeFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			logger.Fatal("failed to read interchange file", zap.Error(err))
+		}
+		var interchange ekm.Interchange
+		if err := json.Unmarshal(data, &interchange); err != nil {
+			logger.Fatal("failed to unmarshal interchange", zap.Error(err))
+		}
+
+		signerStorage := ekm.NewSignerStorage(db, eth2Network, logger)
+		if err := ekm.ImportSlashingProtection(signerStorage, eth2Network, &interchange); err != nil {
+			logger.Fatal("failed to import slashing protection", zap.Error(err))
+		}
+		logger.Info("imported slashing protection", zap.String("file", filePath),
+			zap.Int("validators", len(interchange.Data)))
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, ExportSlashingProtectionCmd)
+	flags.AddInterchangeFileFlag(ExportSlashingProtectionCmd)
+
+	global_config.ProcessArgs(&cfg, &globalArgs, ImportSlashingProtectionCmd)
+	flags.AddInterchangeFileFlag(ImportSlashingProtectionCmd)
+}
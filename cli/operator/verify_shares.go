@@ -0,0 +1,60 @@
+package operator
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	global_config "github.com/bloxapp/ssv/cli/config"
+	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/operator/validator"
+)
+
+// VerifySharesCmd is the command to verify a validator registration payload before it is submitted to the contract
+var VerifySharesCmd = &cobra.Command{
+	Use:   "verify-shares",
+	Short: "Verifies the share of this operator in a validator registration payload, against the synced registry",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := setupGlobal(cmd)
+		setupSSVNetwork(logger)
+
+		// the db is only read, so the migrations aren't run
+		cfg.DBOptions.Ctx = cmd.Context()
+		db := openDb(logger)
+		defer db.Close()
+		operatorStorage, operatorPubKey := readOperatorStorage(db)
+
+		filePath, err := flags.GetRegistrationPayloadFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get payload flag value", zap.Error(err))
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			logger.Fatal("failed to read payload file", zap.Error(err))
+		}
+		var payload validator.ValidatorRegistrationPayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			logger.Fatal("failed to unmarshal payload", zap.Error(err))
+		}
+		event, err := payload.ToEvent()
+		if err != nil {
+			logger.Fatal("invalid payload", zap.Error(err))
+		}
+
+		share, err := validator.VerifyValidatorRegistration(*event, operatorStorage, operatorStorage.GetPrivateKey, operatorPubKey)
+		if err != nil {
+			logger.Fatal("share verification failed", zap.Error(err))
+		}
+		logger.Info("share verified",
+			zap.String("validator", payload.PublicKey),
+			zap.Uint64("operatorId", uint64(share.OperatorID)),
+			zap.Int("committeeSize", len(share.Committee)))
+	},
+}
+
+func init() {
+	global_config.ProcessArgs(&cfg, &globalArgs, VerifySharesCmd)
+	flags.AddRegistrationPayloadFileFlag(VerifySharesCmd)
+}
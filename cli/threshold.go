@@ -1,13 +1,22 @@
 package cli
 
 import (
-	"fmt"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/bloxapp/ssv/cli/flags"
+	"github.com/bloxapp/ssv/utils/logex"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
 	"github.com/bloxapp/ssv/utils/threshold"
 )
 
@@ -16,42 +25,170 @@ var createThresholdCmd = &cobra.Command{
 	Use:   "create-threshold",
 	Short: "Turns a private key into a threshold key",
 	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+
 		privKey, err := flags.GetPrivKeyFlagValue(cmd)
 		if err != nil {
-			Logger.Fatal("failed to get private key flag value", zap.Error(err))
+			logger.Fatal("failed to get private key flag value", zap.Error(err))
 		}
 
 		keysCount, err := flags.GetKeysCountFlagValue(cmd)
 		if err != nil {
-			Logger.Fatal("failed to get keys count flag value", zap.Error(err))
+			logger.Fatal("failed to get keys count flag value", zap.Error(err))
+		}
+
+		k, err := flags.GetThresholdFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get threshold flag value", zap.Error(err))
+		}
+		if k == 0 {
+			// the committee quorum (2f+1) of n (3f+1) operators
+			k, err = threshold.Quorum(keysCount)
+			if err != nil {
+				logger.Fatal("failed to compute threshold, use --threshold to set it explicitly", zap.Error(err))
+			}
+		}
+
+		operatorIDs, err := getOperatorIDs(cmd, keysCount)
+		if err != nil {
+			logger.Fatal("failed to get operator ids", zap.Error(err))
+		}
+
+		operatorPublicKeys, err := flags.GetOperatorPublicKeysFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get operator public keys flag value", zap.Error(err))
+		}
+		if uint64(len(operatorPublicKeys)) != keysCount {
+			logger.Fatal("operator public keys count must match keys count",
+				zap.Int("operatorPublicKeys", len(operatorPublicKeys)), zap.Uint64("count", keysCount))
+		}
+
+		output, err := flags.GetKeySharesOutputFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get output flag value", zap.Error(err))
 		}
 
 		baseKey := &bls.SecretKey{}
 		if err := baseKey.SetHexString(privKey); err != nil {
-			Logger.Fatal("failed to set hex private key", zap.Error(err))
+			logger.Fatal("failed to set hex private key", zap.Error(err))
 		}
 
-		// https://github.com/ethereum/eth2-ssv/issues/22
-		// currently support 4 nodes threshold is keysCount-1(3). need to align based open the issue to
-		// support k(2f+1) and n (3f+1) and allow to pass it as flag
-		privKeys, err := threshold.Create(baseKey.Serialize(), keysCount-1, keysCount)
+		keyShares, err := threshold.CreateKeyShares(baseKey, k, operatorIDs, operatorPublicKeys)
 		if err != nil {
-			Logger.Fatal("failed to turn a private key into a threshold key", zap.Error(err))
+			logger.Fatal("failed to turn a private key into a threshold key", zap.Error(err))
 		}
 
-		// TODO: export to json file
-		fmt.Println("Generating threshold keys for validator", baseKey.GetPublicKey().SerializeToHexStr())
-		for i, pk := range privKeys {
-			fmt.Println()
-			fmt.Println("Public key", i, pk.GetPublicKey().SerializeToHexStr())
-			fmt.Println("Private key", i, pk.SerializeToHexStr())
+		data, err := json.MarshalIndent(keyShares, "", "  ")
+		if err != nil {
+			logger.Fatal("failed to marshal key shares", zap.Error(err))
+		}
+		if err := os.WriteFile(output, data, 0600); err != nil {
+			logger.Fatal("failed to write key shares file", zap.Error(err))
 		}
+		logger.Info("saved threshold keys",
+			zap.String("validator", keyShares.ValidatorPublicKey),
+			zap.Uint64("threshold", k),
+			zap.Uint64("count", keysCount),
+			zap.String("file", output))
 	},
 }
 
+// verifyThresholdCmd is the command to verify that the shares created by create-threshold reconstruct the validator key
+var verifyThresholdCmd = &cobra.Command{
+	Use:   "verify-threshold",
+	Short: "Verifies that any quorum of the shares created by create-threshold reconstructs the validator key",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := logex.Build(RootCmd.Short, zapcore.DebugLevel, nil)
+
+		file, err := flags.GetKeySharesFileFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get file flag value", zap.Error(err))
+		}
+		keyFiles, err := flags.GetOperatorPrivateKeyFilesFlagValue(cmd)
+		if err != nil {
+			logger.Fatal("failed to get operator private key files flag value", zap.Error(err))
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			logger.Fatal("failed to read key shares file", zap.Error(err))
+		}
+		var keyShares threshold.KeyShares
+		if err := json.Unmarshal(data, &keyShares); err != nil {
+			logger.Fatal("failed to unmarshal key shares", zap.Error(err))
+		}
+
+		operatorKeys := make([]*rsa.PrivateKey, 0, len(keyFiles))
+		for _, keyFile := range keyFiles {
+			sk, err := readOperatorPrivateKey(keyFile)
+			if err != nil {
+				logger.Fatal("failed to read operator private key", zap.String("file", keyFile), zap.Error(err))
+			}
+			operatorKeys = append(operatorKeys, sk)
+		}
+
+		verified, err := threshold.VerifyKeyShares(&keyShares, operatorKeys)
+		if err != nil {
+			logger.Fatal("failed to verify threshold keys", zap.Error(err))
+		}
+		logger.Info("verified threshold keys",
+			zap.String("validator", keyShares.ValidatorPublicKey),
+			zap.Uint64("threshold", keyShares.Threshold),
+			zap.Int("verifiedQuorums", verified))
+	},
+}
+
+// getOperatorIDs returns the operator ids flag value, or 1..count if not set
+func getOperatorIDs(cmd *cobra.Command, count uint64) ([]uint64, error) {
+	values, err := flags.GetOperatorIDsFlagValue(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		ids := make([]uint64, count)
+		for i := range ids {
+			ids[i] = uint64(i + 1)
+		}
+		return ids, nil
+	}
+	if uint64(len(values)) != count {
+		return nil, errors.Errorf("got %d operator ids, expected %d", len(values), count)
+	}
+	ids := make([]uint64, len(values))
+	for i, v := range values {
+		id, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid operator id %s", v)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// readOperatorPrivateKey reads a base64 operator private key, as generated by generate-operator-keys
+func readOperatorPrivateKey(file string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	skPem, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode base64")
+	}
+	return rsaencryption.ConvertPemToPrivateKey(string(skPem))
+}
+
 func init() {
 	flags.AddPrivKeyFlag(createThresholdCmd)
 	flags.AddKeysCountFlag(createThresholdCmd)
+	flags.AddThresholdFlag(createThresholdCmd)
+	flags.AddOperatorIDsFlag(createThresholdCmd)
+	flags.AddOperatorPublicKeysFlag(createThresholdCmd)
+	flags.AddKeySharesOutputFlag(createThresholdCmd)
+
+	flags.AddKeySharesFileFlag(verifyThresholdCmd)
+	flags.AddOperatorPrivateKeyFilesFlag(verifyThresholdCmd)
 
 	RootCmd.AddCommand(createThresholdCmd)
+	RootCmd.AddCommand(verifyThresholdCmd)
 }
@@ -0,0 +1,95 @@
+package ekm
+
+import (
+	"encoding/binary"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// attestationHistoryRetentionEpochs is the amount of epochs to keep in the attestation history,
+// older records are pruned as new attestations are signed
+var attestationHistoryRetentionEpochs = phase0.Epoch(4096)
+
+const attestationRecordSize = 8 + 8 + 32
+
+// AttestationRecord is a signed attestation entry in the attestation history of a share
+type AttestationRecord struct {
+	Source phase0.Epoch
+	Target phase0.Epoch
+	// DataRoot is the hash tree root of the signed attestation data
+	DataRoot phase0.Root
+}
+
+func (r *AttestationRecord) encode() []byte {
+	ret := make([]byte, attestationRecordSize)
+	binary.BigEndian.PutUint64(ret[0:8], uint64(r.Source))
+	binary.BigEndian.PutUint64(ret[8:16], uint64(r.Target))
+	copy(ret[16:], r.DataRoot[:])
+	return ret
+}
+
+func decodeAttestationRecord(data []byte) (*AttestationRecord, error) {
+	if len(data) != attestationRecordSize {
+		return nil, errors.Errorf("invalid attestation record size %d", len(data))
+	}
+	ret := &AttestationRecord{
+		Source: phase0.Epoch(binary.BigEndian.Uint64(data[0:8])),
+		Target: phase0.Epoch(binary.BigEndian.Uint64(data[8:16])),
+	}
+	copy(ret.DataRoot[:], data[16:])
+	return ret, nil
+}
+
+// attestationRecordKey encodes the target in big endian so records are ordered by target
+func attestationRecordKey(target phase0.Epoch) []byte {
+	ret := make([]byte, 8)
+	binary.BigEndian.PutUint64(ret, uint64(target))
+	return ret
+}
+
+// checkAttestationHistory checks the given attestation against the signed history of a share.
+// an attestation is slashable if it is a double vote (same target, different data) or if it
+// surrounds / is surrounded by a previous attestation.
+// since pruned records are unknown, attestations older than the oldest record are refused as well.
+func checkAttestationHistory(history []*AttestationRecord, data *phase0.AttestationData, dataRoot phase0.Root) error {
+	source, target := data.Source.Epoch, data.Target.Epoch
+	if source > target {
+		return errors.Errorf("invalid attestation, source %d is higher than target %d", source, target)
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	var lowestSource, lowestTarget = history[0].Source, history[0].Target
+	for _, r := range history {
+		if r.Source < lowestSource {
+			lowestSource = r.Source
+		}
+		if r.Target < lowestTarget {
+			lowestTarget = r.Target
+		}
+
+		if r.Target == target {
+			if r.DataRoot != dataRoot {
+				return slashableAttestationError("DoubleVote", r)
+			}
+			continue
+		}
+		if r.Source < source && target < r.Target {
+			return slashableAttestationError("SurroundedVote", r)
+		}
+		if source < r.Source && r.Target < target {
+			return slashableAttestationError("SurroundingVote", r)
+		}
+	}
+
+	if source < lowestSource || target < lowestTarget {
+		return errors.New("slashable attestation (PrunedHistory), not signing")
+	}
+	return nil
+}
+
+func slashableAttestationError(status string, r *AttestationRecord) error {
+	return errors.Errorf("slashable attestation (%s with source %d target %d), not signing", status, r.Source, r.Target)
+}
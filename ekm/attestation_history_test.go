@@ -0,0 +1,159 @@
+package ekm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func testAttestationData(source, target phase0.Epoch, root byte) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:            phase0.Slot(target * 32),
+		BeaconBlockRoot: [32]byte{root},
+		Source:          &phase0.Checkpoint{Epoch: source},
+		Target:          &phase0.Checkpoint{Epoch: target},
+	}
+}
+
+func TestCheckAttestationHistory(t *testing.T) {
+	history := []*AttestationRecord{
+		{Source: 10, Target: 11, DataRoot: phase0.Root{1}},
+		{Source: 11, Target: 15, DataRoot: phase0.Root{2}},
+	}
+
+	tests := []struct {
+		name     string
+		data     *phase0.AttestationData
+		dataRoot phase0.Root
+		err      string
+	}{
+		{"valid", testAttestationData(15, 16, 0), phase0.Root{3}, ""},
+		{"same attestation", testAttestationData(11, 15, 0), phase0.Root{2}, ""},
+		{"double vote", testAttestationData(11, 15, 0), phase0.Root{3}, "slashable attestation (DoubleVote with source 11 target 15), not signing"},
+		{"surrounded vote", testAttestationData(12, 14, 0), phase0.Root{3}, "slashable attestation (SurroundedVote with source 11 target 15), not signing"},
+		{"surrounding vote", testAttestationData(10, 16, 0), phase0.Root{3}, "slashable attestation (SurroundingVote with source 11 target 15), not signing"},
+		{"pruned history", testAttestationData(5, 9, 0), phase0.Root{3}, "slashable attestation (PrunedHistory), not signing"},
+		{"invalid", testAttestationData(20, 19, 0), phase0.Root{3}, "invalid attestation, source 20 is higher than target 19"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkAttestationHistory(history, test.data, test.dataRoot)
+			if len(test.err) > 0 {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAttestationRecordsStorage(t *testing.T) {
+	signerStorage, done := newStorageForTest()
+	defer done()
+
+	pk := _byteArray(pk1Str)
+	for _, target := range []phase0.Epoch{300, 2, 256, 10} {
+		require.NoError(t, signerStorage.SaveAttestationRecord(pk, &AttestationRecord{
+			Source:   target - 1,
+			Target:   target,
+			DataRoot: phase0.Root{byte(target)},
+		}))
+	}
+
+	records, err := signerStorage.ListAttestationRecords(pk)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	for i, target := range []phase0.Epoch{2, 10, 256, 300} {
+		require.Equal(t, target, records[i].Target)
+		require.Equal(t, target-1, records[i].Source)
+		require.Equal(t, phase0.Root{byte(target)}, records[i].DataRoot)
+	}
+
+	records, err = signerStorage.ListAttestationRecordsFrom(pk, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, phase0.Epoch(10), records[0].Target)
+	lowest, found, err := signerStorage.GetLowestAttestationRecord(pk)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, phase0.Epoch(2), lowest.Target)
+
+	pruned, err := signerStorage.PruneAttestationRecords(pk, 256)
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+
+	// the most recent record is never pruned
+	pruned, err = signerStorage.PruneAttestationRecords(pk, 1000)
+	require.NoError(t, err)
+	require.Equal(t, 1, pruned)
+	records, err = signerStorage.ListAttestationRecords(pk)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, phase0.Epoch(300), records[0].Target)
+
+	require.NoError(t, signerStorage.RemoveAttestationRecords(pk))
+	records, err = signerStorage.ListAttestationRecords(pk)
+	require.NoError(t, err)
+	require.Len(t, records, 0)
+	_, found, err = signerStorage.GetLowestAttestationRecord(pk)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestCheckAttestationHistoryRange(t *testing.T) {
+	km := testKeyManager(t).(*ethKeyManagerSigner)
+
+	pk := _byteArray(pk1Str)
+	for target, source := range map[phase0.Epoch]phase0.Epoch{10: 9, 11: 10, 12: 11, 20: 14} {
+		require.NoError(t, km.storage.SaveAttestationRecord(pk, &AttestationRecord{
+			Source:   source,
+			Target:   target,
+			DataRoot: phase0.Root{byte(target)},
+		}))
+	}
+	// the data root of the records is their target, another root is another vote
+	check := func(source, target phase0.Epoch, root byte) error {
+		data := testAttestationData(source, target, root)
+		return km.checkAttestationHistory(pk, data, phase0.Root{root})
+	}
+
+	require.NoError(t, check(20, 21, 21))
+	require.NoError(t, check(11, 12, 12))
+	require.NoError(t, check(11, 13, 13))
+	require.EqualError(t, check(19, 20, 1), "slashable attestation (DoubleVote with source 14 target 20), not signing")
+	require.EqualError(t, check(15, 18, 18), "slashable attestation (SurroundedVote with source 14 target 20), not signing")
+	require.EqualError(t, check(10, 14, 14), "slashable attestation (SurroundingVote with source 11 target 12), not signing")
+	require.EqualError(t, check(5, 6, 6), "slashable attestation (PrunedHistory), not signing")
+}
+
+func TestSurroundVoteProtection(t *testing.T) {
+	km := testKeyManager(t)
+
+	sk1 := &bls.SecretKey{}
+	require.NoError(t, sk1.SetHexString(sk1Str))
+	pk := sk1.GetPublicKey().Serialize()
+
+	currentEpoch := km.(*ethKeyManagerSigner).storage.Network().EstimatedCurrentEpoch()
+
+	_, sig, err := km.(*ethKeyManagerSigner).SignBeaconObject(testAttestationData(currentEpoch+2, currentEpoch+3, 1), phase0.Domain{}, pk, spectypes.DomainAttester)
+	require.NoError(t, err)
+	require.NotNil(t, sig)
+
+	// a surrounding vote is refused by the highest attestation check
+	surrounding := testAttestationData(currentEpoch+1, currentEpoch+4, 1)
+	require.EqualError(t, km.IsAttestationSlashable(pk, surrounding), "slashable attestation (HighestAttestationVote), not signing")
+
+	// the history is checked on its own as well
+	dataRoot, err := surrounding.HashTreeRoot()
+	require.NoError(t, err)
+	require.EqualError(t, km.(*ethKeyManagerSigner).checkAttestationHistory(pk, surrounding, dataRoot),
+		fmt.Sprintf("slashable attestation (SurroundingVote with source %d target %d), not signing", currentEpoch+2, currentEpoch+3))
+
+	records, err := km.(*ethKeyManagerSigner).storage.ListAttestationRecords(pk)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+}
@@ -38,11 +38,19 @@ type ethKeyManagerSigner struct {
 	domain            spectypes.DomainType
 	slashingProtector core.SlashingProtector
 	isBlinded         bool
+
+	// attHistoryLocks serializes the attestation signing of each share, by its public key
+	attHistoryLocks map[string]*sync.Mutex
+	attHistoryMutex sync.Mutex
 }
 
-// NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner
-func NewETHKeyManagerSigner(db basedb.IDb, network beaconprotocol.Network, domain spectypes.DomainType, logger *zap.Logger) (spectypes.KeyManager, error) {
+// NewETHKeyManagerSigner returns a new instance of ethKeyManagerSigner.
+// share secrets are encrypted at rest with a key derived from encryptionSecret, unless it is empty
+func NewETHKeyManagerSigner(db basedb.IDb, network beaconprotocol.Network, domain spectypes.DomainType, logger *zap.Logger, encryptionSecret []byte) (spectypes.KeyManager, error) {
 	signerStore := NewSignerStorage(db, network, logger)
+	if err := signerStore.SetupEncryption(encryptionSecret); err != nil {
+		return nil, errors.Wrap(err, "could not setup share encryption")
+	}
 	options := &eth2keymanager.KeyVaultOptions{}
 	options.SetStorage(signerStore)
 	options.SetWalletType(core.NDWallet)
@@ -68,6 +76,7 @@ func NewETHKeyManagerSigner(db basedb.IDb, network beaconprotocol.Network, domai
 	return &ethKeyManagerSigner{
 		wallet:            wallet,
 		walletLock:        &sync.RWMutex{},
+		attHistoryLocks:   make(map[string]*sync.Mutex),
 		signer:            beaconSigner,
 		storage:           signerStore,
 		domain:            domain,
@@ -85,7 +94,7 @@ func (km *ethKeyManagerSigner) SignBeaconObject(obj ssz.HashRoot, domain phase0.
 		if !ok {
 			return nil, nil, errors.New("could not cast obj to AttestationData")
 		}
-		return km.signer.SignBeaconAttestation(data, domain, pk)
+		return km.signAttestation(data, domain, pk)
 	case spectypes.DomainProposer:
 		if km.isBlinded {
 			block, ok := obj.(*apiv1bbellatrix.BlindedBeaconBlock)
@@ -152,11 +161,111 @@ func (km *ethKeyManagerSigner) SignBeaconObject(obj ssz.HashRoot, domain phase0.
 			return nil, nil, errors.New("could not cast obj to ValidatorRegistration")
 		}
 		return km.signer.SignRegistration(data, domain, pk)
+	case spectypes.DomainVoluntaryExit:
+		data, ok := obj.(*phase0.VoluntaryExit)
+		if !ok {
+			return nil, nil, errors.New("could not cast obj to VoluntaryExit")
+		}
+		return km.signVoluntaryExit(data, domain, pk)
 	default:
 		return nil, nil, errors.New("domain unknown")
 	}
 }
 
+// signVoluntaryExit signs the given voluntary exit with the share, the key manager lib doesn't support exits
+func (km *ethKeyManagerSigner) signVoluntaryExit(data *phase0.VoluntaryExit, domain phase0.Domain, pk []byte) (spectypes.Signature, []byte, error) {
+	account, err := km.wallet.AccountByPublicKey(hex.EncodeToString(pk))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get signing account")
+	}
+
+	root, err := spectypes.ComputeETHSigningRoot(data, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not compute signing root")
+	}
+
+	sig, err := account.ValidationKeySign(root[:])
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not sign voluntary exit")
+	}
+	return sig, root[:], nil
+}
+
+// signAttestation signs the given attestation data after checking it against the attestation history of the share,
+// once signed the attestation is added to the history
+func (km *ethKeyManagerSigner) signAttestation(data *phase0.AttestationData, domain phase0.Domain, pk []byte) (spectypes.Signature, []byte, error) {
+	lock := km.attHistoryLock(pk)
+	lock.Lock()
+	defer lock.Unlock()
+
+	dataRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+	if err := km.checkAttestationHistory(pk, data, dataRoot); err != nil {
+		return nil, nil, err
+	}
+
+	sig, root, err := km.signer.SignBeaconAttestation(data, domain, pk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := km.saveAttestationRecord(pk, data, dataRoot); err != nil {
+		return nil, nil, errors.Wrap(err, "could not save attestation record")
+	}
+	return sig, root, nil
+}
+
+// attHistoryLock returns the attestation history lock of the given share
+func (km *ethKeyManagerSigner) attHistoryLock(pk []byte) *sync.Mutex {
+	km.attHistoryMutex.Lock()
+	defer km.attHistoryMutex.Unlock()
+
+	key := hex.EncodeToString(pk)
+	lock, ok := km.attHistoryLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		km.attHistoryLocks[key] = lock
+	}
+	return lock
+}
+
+// checkAttestationHistory checks the attestation against the records it could conflict with, instead of the whole history:
+// records with a target above the source (double, surrounded and surrounding votes) and the lowest record (pruned history).
+// the lowest record has the lowest source as well, as a record with a lower source and a higher target would surround it
+func (km *ethKeyManagerSigner) checkAttestationHistory(pk []byte, data *phase0.AttestationData, dataRoot phase0.Root) error {
+	history, err := km.storage.ListAttestationRecordsFrom(pk, data.Source.Epoch+1)
+	if err != nil {
+		return errors.Wrap(err, "could not list attestation history")
+	}
+	lowest, found, err := km.storage.GetLowestAttestationRecord(pk)
+	if err != nil {
+		return errors.Wrap(err, "could not get lowest attestation record")
+	}
+	if found {
+		history = append(history, lowest)
+	}
+	return checkAttestationHistory(history, data, dataRoot)
+}
+
+func (km *ethKeyManagerSigner) saveAttestationRecord(pk []byte, data *phase0.AttestationData, dataRoot phase0.Root) error {
+	record := &AttestationRecord{
+		Source:   data.Source.Epoch,
+		Target:   data.Target.Epoch,
+		DataRoot: dataRoot,
+	}
+	if err := km.storage.SaveAttestationRecord(pk, record); err != nil {
+		return err
+	}
+	if record.Target > attestationHistoryRetentionEpochs {
+		if _, err := km.storage.PruneAttestationRecords(pk, record.Target-attestationHistoryRetentionEpochs); err != nil {
+			return errors.Wrap(err, "could not prune attestation history")
+		}
+	}
+	return nil
+}
+
 func (km *ethKeyManagerSigner) IsAttestationSlashable(pk []byte, data *phase0.AttestationData) error {
 	if val, err := km.slashingProtector.IsSlashableAttestation(pk, data); err != nil || val != nil {
 		if err != nil {
@@ -164,7 +273,12 @@ func (km *ethKeyManagerSigner) IsAttestationSlashable(pk []byte, data *phase0.At
 		}
 		return errors.Errorf("slashable attestation (%s), not signing", val.Status)
 	}
-	return nil
+
+	dataRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute attestation data root")
+	}
+	return km.checkAttestationHistory(pk, data, dataRoot)
 }
 
 func (km *ethKeyManagerSigner) IsBeaconBlockSlashable(pk []byte, block *bellatrix.BeaconBlock) error {
@@ -258,6 +372,9 @@ func (km *ethKeyManagerSigner) RemoveShare(pubKey string) error {
 		if err := km.storage.RemoveHighestProposal(pkDecoded); err != nil {
 			return errors.Wrap(err, "could not remove highest proposal")
 		}
+		if err := km.storage.RemoveAttestationRecords(pkDecoded); err != nil {
+			return errors.Wrap(err, "could not remove attestation history")
+		}
 		if err := km.wallet.DeleteAccountByPublicKey(pubKey); err != nil {
 			return errors.Wrap(err, "could not delete share")
 		}
@@ -0,0 +1,246 @@
+package ekm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/bloxapp/eth2-key-manager/encryptor"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const (
+	encryptionPrefix   = prefix + "encryption-"
+	encryptionSaltKey  = "salt"
+	encryptionCheckKey = "check"
+	// encryptionCheckValue is encrypted with the share encryption key in order to verify the key on startup
+	encryptionCheckValue = "ssv-share-encryption-check"
+
+	encryptionSaltLen = 32
+	encryptionKeyLen  = 32
+	encryptionScryptN = 1 << 18
+	encryptionScryptR = 8
+	encryptionScryptP = 1
+)
+
+// gcmEncryptor is an encryptor.Encryptor that uses AES-256-GCM,
+// the key passed to Encrypt/Decrypt is the hex encoded derived encryption key
+type gcmEncryptor struct{}
+
+var _ encryptor.Encryptor = &gcmEncryptor{}
+
+// Name returns the name of the encryptor
+func (e *gcmEncryptor) Name() string {
+	return "aes-256-gcm"
+}
+
+// Version returns the version of the encryptor
+func (e *gcmEncryptor) Version() uint {
+	return 1
+}
+
+// Encrypt encrypts the given data with the given hex encoded key
+func (e *gcmEncryptor) Encrypt(data []byte, key string) (map[string]interface{}, error) {
+	gcm, err := e.gcm(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "could not generate nonce")
+	}
+	return map[string]interface{}{
+		"nonce":   hex.EncodeToString(nonce),
+		"message": hex.EncodeToString(gcm.Seal(nil, nonce, data, nil)),
+	}, nil
+}
+
+// Decrypt decrypts the given data with the given hex encoded key
+func (e *gcmEncryptor) Decrypt(data map[string]interface{}, key string) ([]byte, error) {
+	gcm, err := e.gcm(key)
+	if err != nil {
+		return nil, err
+	}
+	nonceHex, _ := data["nonce"].(string)
+	msgHex, _ := data["message"].(string)
+	nonce, err := hex.DecodeString(nonceHex)
+	if err != nil || len(nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid nonce")
+	}
+	msg, err := hex.DecodeString(msgHex)
+	if err != nil {
+		return nil, errors.New("invalid message")
+	}
+	plain, err := gcm.Open(nil, nonce, msg, nil)
+	if err != nil {
+		return nil, errors.New("invalid share encryption key")
+	}
+	return plain, nil
+}
+
+func (e *gcmEncryptor) gcm(key string) (cipher.AEAD, error) {
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode encryption key")
+	}
+	block, err := aes.NewCipher(keyBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create cipher")
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptedAccount is the stored form of an account when encryption at rest is enabled
+type encryptedAccount struct {
+	Encryptor string                 `json:"encryptor"`
+	Version   uint                   `json:"version"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// encryptedAccountEnvelope is used to tell encrypted accounts from plaintext ones
+type encryptedAccountEnvelope struct {
+	Encrypted *encryptedAccount `json:"encrypted"`
+}
+
+// SetupEncryption enables encryption at rest of share secrets with a key derived from the given secret.
+// on first setup, existing plaintext accounts are re-encrypted.
+// an error is returned if the secret doesn't match the one that was used to encrypt the accounts,
+// or if accounts were encrypted but no secret is given.
+func (s *storage) SetupEncryption(secret []byte) error {
+	check, checkFound, err := s.db.Get(s.objPrefix(encryptionPrefix), []byte(encryptionCheckKey))
+	if err != nil {
+		return errors.Wrap(err, "could not get encryption check")
+	}
+	if len(secret) == 0 {
+		if checkFound {
+			return errors.New("share secrets are encrypted at rest, but no share encryption key was configured")
+		}
+		return nil
+	}
+
+	salt, err := s.getOrCreateEncryptionSalt()
+	if err != nil {
+		return err
+	}
+	key, err := scrypt.Key(secret, salt, encryptionScryptN, encryptionScryptR, encryptionScryptP, encryptionKeyLen)
+	if err != nil {
+		return errors.Wrap(err, "could not derive share encryption key")
+	}
+	s.SetEncryptor(&gcmEncryptor{}, []byte(hex.EncodeToString(key)))
+
+	if checkFound {
+		plain, err := s.decrypt(check.Value)
+		if err != nil {
+			return err
+		}
+		if string(plain) != encryptionCheckValue {
+			return errors.New("invalid share encryption key")
+		}
+		return nil
+	}
+	return s.migrateAccountsEncryption()
+}
+
+// migrateAccountsEncryption re-encrypts all plaintext accounts and saves the encryption check, in a single transaction
+func (s *storage) migrateAccountsEncryption() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var accounts []basedb.Obj
+	err := s.db.GetAll(s.objPrefix(accountsPrefix), func(i int, obj basedb.Obj) error {
+		accounts = append(accounts, obj)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not list accounts")
+	}
+	check, err := s.encrypt([]byte(encryptionCheckValue))
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Update(func(txn basedb.Txn) error {
+		for _, obj := range accounts {
+			if isEncryptedAccount(obj.Value) {
+				continue
+			}
+			data, err := s.encrypt(obj.Value)
+			if err != nil {
+				return err
+			}
+			if err := txn.Set(s.objPrefix(accountsPrefix), obj.Key, data); err != nil {
+				return err
+			}
+		}
+		return txn.Set(s.objPrefix(encryptionPrefix), []byte(encryptionCheckKey), check)
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not encrypt accounts")
+	}
+	s.logger.Info("share secrets are encrypted at rest")
+	return nil
+}
+
+func (s *storage) getOrCreateEncryptionSalt() ([]byte, error) {
+	obj, found, err := s.db.Get(s.objPrefix(encryptionPrefix), []byte(encryptionSaltKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get encryption salt")
+	}
+	if found {
+		return obj.Value, nil
+	}
+	salt := make([]byte, encryptionSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "could not generate encryption salt")
+	}
+	if err := s.db.Set(s.objPrefix(encryptionPrefix), []byte(encryptionSaltKey), salt); err != nil {
+		return nil, errors.Wrap(err, "could not save encryption salt")
+	}
+	return salt, nil
+}
+
+// encrypt wraps the given data in an encrypted envelope, or returns it as is if no encryptor was set
+func (s *storage) encrypt(data []byte) ([]byte, error) {
+	if s.encryptor == nil {
+		return data, nil
+	}
+	encrypted, err := s.encryptor.Encrypt(data, string(s.encryptionKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt")
+	}
+	return json.Marshal(&encryptedAccountEnvelope{
+		Encrypted: &encryptedAccount{
+			Encryptor: s.encryptor.Name(),
+			Version:   s.encryptor.Version(),
+			Data:      encrypted,
+		},
+	})
+}
+
+// decrypt opens an encrypted envelope, plaintext data is returned as is only if no encryptor was set
+func (s *storage) decrypt(data []byte) ([]byte, error) {
+	var envelope encryptedAccountEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Encrypted == nil {
+		if s.encryptor != nil {
+			return nil, errors.New("data is not encrypted, but share encryption is enabled")
+		}
+		return data, nil
+	}
+	if s.encryptor == nil {
+		return nil, errors.New("data is encrypted, but no share encryption key was configured")
+	}
+	if envelope.Encrypted.Encryptor != s.encryptor.Name() || envelope.Encrypted.Version != s.encryptor.Version() {
+		return nil, errors.Errorf("unsupported encryptor %s v%d", envelope.Encrypted.Encryptor, envelope.Encrypted.Version)
+	}
+	return s.encryptor.Decrypt(envelope.Encrypted.Data, string(s.encryptionKey))
+}
+
+func isEncryptedAccount(data []byte) bool {
+	var envelope encryptedAccountEnvelope
+	return json.Unmarshal(data, &envelope) == nil && envelope.Encrypted != nil
+}
@@ -34,7 +34,7 @@ func testKeyManager(t *testing.T) spectypes.KeyManager {
 	db, err := getBaseStorage()
 	require.NoError(t, err)
 
-	km, err := NewETHKeyManagerSigner(db, beacon2.NewNetwork(core.PraterNetwork, 0), types.GetDefaultDomain(), zap.L())
+	km, err := NewETHKeyManagerSigner(db, beacon2.NewNetwork(core.PraterNetwork, 0), types.GetDefaultDomain(), zap.L(), nil)
 	require.NoError(t, err)
 
 	sk1 := &bls.SecretKey{}
@@ -264,3 +264,28 @@ func TestSignRoot(t *testing.T) {
 		// require.True(t, res)
 	})
 }
+
+func TestSignVoluntaryExit(t *testing.T) {
+	require.NoError(t, bls.Init(bls.BLS12_381))
+
+	km := testKeyManager(t)
+
+	pk := &bls.PublicKey{}
+	require.NoError(t, pk.Deserialize(_byteArray(pk1Str)))
+
+	exit := &phase0.VoluntaryExit{Epoch: 10, ValidatorIndex: 1}
+	domain := phase0.Domain{1, 2, 3}
+	sig, root, err := km.SignBeaconObject(exit, domain, pk.Serialize(), spectypes.DomainVoluntaryExit)
+	require.NoError(t, err)
+
+	expectedRoot, err := spectypes.ComputeETHSigningRoot(exit, domain)
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot[:], root)
+
+	signature := &bls.Sign{}
+	require.NoError(t, signature.Deserialize(sig))
+	require.True(t, signature.VerifyByte(pk, root))
+
+	_, _, err = km.SignBeaconObject(spectypes.SSZUint64(10), domain, pk.Serialize(), spectypes.DomainVoluntaryExit)
+	require.EqualError(t, err, "could not cast obj to VoluntaryExit")
+}
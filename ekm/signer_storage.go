@@ -1,8 +1,10 @@
 package ekm
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -28,6 +30,7 @@ const (
 	accountsPath          = "accounts_%s"
 	highestAttPrefix      = prefix + "highest_att-"
 	highestProposalPrefix = prefix + "highest_prop-"
+	attHistoryPrefix      = prefix + "att_history-"
 )
 
 // Storage represents the interface for ssv node storage
@@ -38,13 +41,29 @@ type Storage interface {
 
 	RemoveHighestAttestation(pubKey []byte) error
 	RemoveHighestProposal(pubKey []byte) error
+
+	SaveAttestationRecord(pubKey []byte, record *AttestationRecord) error
+	ListAttestationRecords(pubKey []byte) ([]*AttestationRecord, error)
+	ListAttestationRecordsFrom(pubKey []byte, minTarget phase0.Epoch) ([]*AttestationRecord, error)
+	GetLowestAttestationRecord(pubKey []byte) (*AttestationRecord, bool, error)
+	PruneAttestationRecords(pubKey []byte, minTarget phase0.Epoch) (int, error)
+	RemoveAttestationRecords(pubKey []byte) error
+
+	ListSlashingProtectedPubKeys() ([][]byte, error)
+	SetupEncryption(secret []byte) error
 }
 
+// errStopIteration stops an iteration once the needed items were read
+var errStopIteration = errors.New("stop iteration")
+
 type storage struct {
 	db      basedb.IDb
 	network beacon.Network
 	logger  *zap.Logger
 	lock    sync.RWMutex
+
+	encryptor     encryptor.Encryptor
+	encryptionKey []byte
 }
 
 func NewSignerStorage(db basedb.IDb, network beacon.Network, logger *zap.Logger) Storage {
@@ -141,6 +160,10 @@ func (s *storage) SaveAccount(account core.ValidatorAccount) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal account")
 	}
+	data, err = s.encrypt(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt account")
+	}
 
 	key := fmt.Sprintf(accountsPath, account.ID().String())
 
@@ -178,6 +201,10 @@ func (s *storage) decodeAccount(byts []byte) (core.ValidatorAccount, error) {
 	if len(byts) == 0 {
 		return nil, errors.New("bytes are empty")
 	}
+	byts, err := s.decrypt(byts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt account")
+	}
 
 	// decode
 	var ret *wallets.HDAccount
@@ -189,9 +216,13 @@ func (s *storage) decodeAccount(byts []byte) (core.ValidatorAccount, error) {
 	return ret, nil
 }
 
-// SetEncryptor sets the given encryptor to the wallet.
+// SetEncryptor sets the given encryptor to the wallet, accounts are encrypted at rest once it is set.
 func (s *storage) SetEncryptor(encryptor encryptor.Encryptor, password []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 
+	s.encryptor = encryptor
+	s.encryptionKey = password
 }
 
 func (s *storage) SaveHighestAttestation(pubKey []byte, attestation *phase0.AttestationData) error {
@@ -230,6 +261,32 @@ func (s *storage) RetrieveHighestAttestation(pubKey []byte) (*phase0.Attestation
 	return ret, nil
 }
 
+// ListSlashingProtectedPubKeys returns the public keys that have a highest attestation or a highest proposal,
+// ordered by public key. it doesn't require the accounts to be decrypted
+func (s *storage) ListSlashingProtectedPubKeys() ([][]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	seen := make(map[string]bool)
+	var ret [][]byte
+	for _, p := range []string{highestAttPrefix, highestProposalPrefix} {
+		err := s.db.GetAll(s.objPrefix(p), func(i int, obj basedb.Obj) error {
+			if !seen[string(obj.Key)] {
+				seen[string(obj.Key)] = true
+				ret = append(ret, obj.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return bytes.Compare(ret[i], ret[j]) < 0
+	})
+	return ret, nil
+}
+
 func (s *storage) RemoveHighestAttestation(pubKey []byte) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -274,3 +331,122 @@ func (s *storage) RemoveHighestProposal(pubKey []byte) error {
 
 	return s.db.Delete(s.objPrefix(highestProposalPrefix), pubKey)
 }
+
+// SaveAttestationRecord saves the given record into the attestation history of the given public key
+func (s *storage) SaveAttestationRecord(pubKey []byte, record *AttestationRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Set(s.attHistoryPrefix(pubKey), attestationRecordKey(record.Target), record.encode())
+}
+
+// ListAttestationRecords returns the attestation history of the given public key, ordered by target epoch
+func (s *storage) ListAttestationRecords(pubKey []byte) ([]*AttestationRecord, error) {
+	return s.ListAttestationRecordsFrom(pubKey, 0)
+}
+
+// ListAttestationRecordsFrom returns the records of the given public key with target higher than or equal to minTarget,
+// ordered by target epoch
+func (s *storage) ListAttestationRecordsFrom(pubKey []byte, minTarget phase0.Epoch) ([]*AttestationRecord, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	ret := make([]*AttestationRecord, 0)
+	err := s.db.View(func(txn basedb.ReadTxn) error {
+		return txn.GetRange(s.attHistoryPrefix(pubKey), attestationRecordKey(minTarget), nil, false, func(i int, obj basedb.Obj) error {
+			record, err := decodeAttestationRecord(obj.Value)
+			if err != nil {
+				return errors.Wrap(err, "could not decode attestation record")
+			}
+			ret = append(ret, record)
+			return nil
+		})
+	})
+	return ret, err
+}
+
+// GetLowestAttestationRecord returns the record of the given public key with the lowest target
+func (s *storage) GetLowestAttestationRecord(pubKey []byte) (*AttestationRecord, bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var ret *AttestationRecord
+	err := s.db.View(func(txn basedb.ReadTxn) error {
+		return txn.GetRange(s.attHistoryPrefix(pubKey), nil, nil, false, func(i int, obj basedb.Obj) error {
+			record, err := decodeAttestationRecord(obj.Value)
+			if err != nil {
+				return errors.Wrap(err, "could not decode attestation record")
+			}
+			ret = record
+			return errStopIteration
+		})
+	})
+	if err != nil && err != errStopIteration {
+		return nil, false, err
+	}
+	return ret, ret != nil, nil
+}
+
+// PruneAttestationRecords removes all the records of the given public key with target lower than minTarget,
+// the most recent record is always kept. only the keys in the pruned range are read
+func (s *storage) PruneAttestationRecords(pubKey []byte, minTarget phase0.Epoch) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	prefix := s.attHistoryPrefix(pubKey)
+	pruned := 0
+	err := s.db.Update(func(txn basedb.Txn) error {
+		// the most recent record is kept if all the records are in the pruned range
+		var last []byte
+		err := txn.GetRange(prefix, nil, nil, true, func(i int, obj basedb.Obj) error {
+			last = obj.Key
+			return errStopIteration
+		})
+		if err != nil && err != errStopIteration {
+			return err
+		}
+		if last == nil {
+			return nil
+		}
+		to := attestationRecordKey(minTarget)
+		if bytes.Compare(last, to) < 0 {
+			to = last
+		}
+
+		var keys [][]byte
+		if err := txn.GetRange(prefix, nil, to, false, func(i int, obj basedb.Obj) error {
+			keys = append(keys, obj.Key)
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := txn.Delete(prefix, k); err != nil {
+				return err
+			}
+		}
+		pruned = len(keys)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
+// RemoveAttestationRecords removes the whole attestation history of the given public key
+func (s *storage) RemoveAttestationRecords(pubKey []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, err := s.db.DeleteByPrefix(s.attHistoryPrefix(pubKey))
+	return err
+}
+
+func (s *storage) attHistoryPrefix(pubKey []byte) []byte {
+	p := s.objPrefix(attHistoryPrefix)
+	ret := make([]byte, 0, len(p)+len(pubKey)+1)
+	ret = append(ret, p...)
+	ret = append(ret, pubKey...)
+	return append(ret, '-')
+}
@@ -2,6 +2,7 @@ package ekm
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"testing"
@@ -513,3 +514,63 @@ func TestRemovingHighestProposal(t *testing.T) {
 		})
 	}
 }
+
+func TestShareEncryption(t *testing.T) {
+	threshold.Init()
+	db, err := getBaseStorage()
+	require.NoError(t, err)
+	defer db.Close()
+	network := beaconprotocol.NewNetwork(core.PraterNetwork, 0)
+
+	// plaintext account created before encryption is enabled
+	signerStorage := NewSignerStorage(db, network, zap.L())
+	require.NoError(t, signerStorage.SetupEncryption(nil))
+	wallet := hd.NewWallet(&core.WalletContext{Storage: signerStorage})
+	require.NoError(t, signerStorage.SaveWallet(wallet))
+	sk := bls.SecretKey{}
+	sk.SetByCSPRNG()
+	acc, err := wallet.CreateValidatorAccountFromPrivateKey(sk.Serialize(), nil)
+	require.NoError(t, err)
+
+	// enabling encryption migrates the existing account
+	signerStorage = NewSignerStorage(db, network, zap.L())
+	require.NoError(t, signerStorage.SetupEncryption([]byte("secret")))
+	err = db.GetAll(signerStorage.(*storage).objPrefix(accountsPrefix), func(i int, obj basedb.Obj) error {
+		require.True(t, isEncryptedAccount(obj.Value))
+		return nil
+	})
+	require.NoError(t, err)
+	opened, err := signerStorage.OpenAccount(acc.ID())
+	require.NoError(t, err)
+	require.Equal(t, acc.ValidatorPublicKey(), opened.ValidatorPublicKey())
+
+	// new accounts are saved encrypted
+	wallet2, err := signerStorage.OpenWallet()
+	require.NoError(t, err)
+	sk2 := bls.SecretKey{}
+	sk2.SetByCSPRNG()
+	acc2, err := wallet2.CreateValidatorAccountFromPrivateKey(sk2.Serialize(), nil)
+	require.NoError(t, err)
+	obj, found, err := db.Get(signerStorage.(*storage).objPrefix(accountsPrefix), []byte(fmt.Sprintf(accountsPath, acc2.ID().String())))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, isEncryptedAccount(obj.Value))
+
+	// wrong or missing key is refused
+	require.EqualError(t, NewSignerStorage(db, network, zap.L()).SetupEncryption([]byte("wrong secret")), "invalid share encryption key")
+	require.EqualError(t, NewSignerStorage(db, network, zap.L()).SetupEncryption(nil), "share secrets are encrypted at rest, but no share encryption key was configured")
+
+	// same key opens the accounts after restart
+	signerStorage = NewSignerStorage(db, network, zap.L())
+	require.NoError(t, signerStorage.SetupEncryption([]byte("secret")))
+	accounts, err := signerStorage.ListAccounts()
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+
+	// plaintext accounts are refused once encryption is enabled
+	plain, err := json.Marshal(acc)
+	require.NoError(t, err)
+	require.NoError(t, db.Set(signerStorage.(*storage).objPrefix(accountsPrefix), []byte(fmt.Sprintf(accountsPath, acc.ID().String())), plain))
+	_, err = signerStorage.OpenAccount(acc.ID())
+	require.EqualError(t, err, "failed to decrypt account: data is not encrypted, but share encryption is enabled")
+}
@@ -0,0 +1,266 @@
+package ekm
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+// InterchangeFormatVersion is the supported EIP-3076 interchange format version
+const InterchangeFormatVersion = "5"
+
+// Interchange represents an EIP-3076 slashing protection interchange document
+// https://eips.ethereum.org/EIPS/eip-3076
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []*InterchangeData  `json:"data"`
+}
+
+// InterchangeMetadata holds the metadata of an interchange document
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// InterchangeData holds the protection data of a single public key
+type InterchangeData struct {
+	Pubkey             string                    `json:"pubkey"`
+	SignedBlocks       []*InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []*InterchangeAttestation `json:"signed_attestations"`
+}
+
+// InterchangeBlock is a signed block entry, uint64 values are encoded as decimal strings
+type InterchangeBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// InterchangeAttestation is a signed attestation entry, uint64 values are encoded as decimal strings
+type InterchangeAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// ExportSlashingProtection exports the highest attestation and proposal of every share in the given storage.
+// the result is a minimal interchange document, i.e. a single block and attestation per public key.
+func ExportSlashingProtection(s Storage, network beacon.Network) (*Interchange, error) {
+	gvr, err := network.GenesisValidatorsRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeys, err := s.ListSlashingProtectedPubKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list public keys")
+	}
+
+	ret := &Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    encodeHex(gvr[:]),
+		},
+		Data: make([]*InterchangeData, 0, len(pubKeys)),
+	}
+	for _, pk := range pubKeys {
+		data := &InterchangeData{
+			Pubkey:             encodeHex(pk),
+			SignedBlocks:       make([]*InterchangeBlock, 0),
+			SignedAttestations: make([]*InterchangeAttestation, 0),
+		}
+
+		highestAtt, err := s.RetrieveHighestAttestation(pk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not retrieve highest attestation for %s", data.Pubkey)
+		}
+		if highestAtt != nil {
+			data.SignedAttestations = append(data.SignedAttestations, &InterchangeAttestation{
+				SourceEpoch: strconv.FormatUint(uint64(highestAtt.Source.Epoch), 10),
+				TargetEpoch: strconv.FormatUint(uint64(highestAtt.Target.Epoch), 10),
+			})
+		}
+
+		highestProposal, err := s.RetrieveHighestProposal(pk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not retrieve highest proposal for %s", data.Pubkey)
+		}
+		if highestProposal > 0 {
+			data.SignedBlocks = append(data.SignedBlocks, &InterchangeBlock{
+				Slot: strconv.FormatUint(uint64(highestProposal), 10),
+			})
+		}
+
+		ret.Data = append(ret.Data, data)
+	}
+	return ret, nil
+}
+
+// importedWatermarks holds the parsed highest values of a single public key
+type importedWatermarks struct {
+	pubKey          []byte
+	highestSource   *phase0.Epoch
+	highestTarget   *phase0.Epoch
+	highestProposal *phase0.Slot
+}
+
+// ImportSlashingProtection merges the given interchange document into the storage by taking the higher values,
+// therefore existing watermarks are never lowered.
+// the whole document is validated before anything is written.
+func ImportSlashingProtection(s Storage, network beacon.Network, interchange *Interchange) error {
+	if interchange == nil {
+		return errors.New("interchange is nil")
+	}
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return errors.Errorf("unsupported interchange format version %s", interchange.Metadata.InterchangeFormatVersion)
+	}
+	gvr, err := network.GenesisValidatorsRoot()
+	if err != nil {
+		return err
+	}
+	importedRoot, err := decodeHex(interchange.Metadata.GenesisValidatorsRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not decode genesis validators root")
+	}
+	if !strings.EqualFold(encodeHex(importedRoot), encodeHex(gvr[:])) {
+		return errors.Errorf("genesis validators root mismatch: expected %s, got %s",
+			encodeHex(gvr[:]), interchange.Metadata.GenesisValidatorsRoot)
+	}
+
+	watermarks := make([]*importedWatermarks, 0, len(interchange.Data))
+	for _, data := range interchange.Data {
+		w, err := parseInterchangeData(data)
+		if err != nil {
+			return err
+		}
+		watermarks = append(watermarks, w)
+	}
+
+	attestations := make(map[string]*phase0.AttestationData)
+	proposals := make(map[string]phase0.Slot)
+	for _, w := range watermarks {
+		pkHex := encodeHex(w.pubKey)
+		if w.highestTarget != nil {
+			existing, err := s.RetrieveHighestAttestation(w.pubKey)
+			if err != nil {
+				return errors.Wrapf(err, "could not retrieve highest attestation for %s", pkHex)
+			}
+			merged := mergeHighestAttestation(attestations[pkHex], *w.highestSource, *w.highestTarget)
+			merged = mergeHighestAttestation(merged, sourceOf(existing), targetOf(existing))
+			attestations[pkHex] = merged
+		}
+		if w.highestProposal != nil {
+			existing, err := s.RetrieveHighestProposal(w.pubKey)
+			if err != nil {
+				return errors.Wrapf(err, "could not retrieve highest proposal for %s", pkHex)
+			}
+			merged := *w.highestProposal
+			if proposals[pkHex] > merged {
+				merged = proposals[pkHex]
+			}
+			if existing > merged {
+				merged = existing
+			}
+			proposals[pkHex] = merged
+		}
+	}
+
+	for pkHex, att := range attestations {
+		pk, _ := decodeHex(pkHex)
+		if err := s.SaveHighestAttestation(pk, att); err != nil {
+			return errors.Wrapf(err, "could not save highest attestation for %s", pkHex)
+		}
+	}
+	for pkHex, slot := range proposals {
+		pk, _ := decodeHex(pkHex)
+		if err := s.SaveHighestProposal(pk, slot); err != nil {
+			return errors.Wrapf(err, "could not save highest proposal for %s", pkHex)
+		}
+	}
+	return nil
+}
+
+// parseInterchangeData extracts the highest values of the given data entry
+func parseInterchangeData(data *InterchangeData) (*importedWatermarks, error) {
+	if data == nil {
+		return nil, errors.New("interchange data is nil")
+	}
+	pk, err := decodeHex(data.Pubkey)
+	if err != nil || len(pk) != phase0.PublicKeyLength {
+		return nil, errors.Errorf("invalid pubkey %s", data.Pubkey)
+	}
+	ret := &importedWatermarks{pubKey: pk}
+
+	for _, b := range data.SignedBlocks {
+		slot, err := strconv.ParseUint(b.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid block slot for %s", data.Pubkey)
+		}
+		if ret.highestProposal == nil || phase0.Slot(slot) > *ret.highestProposal {
+			s := phase0.Slot(slot)
+			ret.highestProposal = &s
+		}
+	}
+
+	for _, a := range data.SignedAttestations {
+		source, err := strconv.ParseUint(a.SourceEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid attestation source epoch for %s", data.Pubkey)
+		}
+		target, err := strconv.ParseUint(a.TargetEpoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid attestation target epoch for %s", data.Pubkey)
+		}
+		if source > target {
+			return nil, errors.Errorf("attestation source %d is higher than target %d for %s", source, target, data.Pubkey)
+		}
+		if ret.highestSource == nil || phase0.Epoch(source) > *ret.highestSource {
+			e := phase0.Epoch(source)
+			ret.highestSource = &e
+		}
+		if ret.highestTarget == nil || phase0.Epoch(target) > *ret.highestTarget {
+			e := phase0.Epoch(target)
+			ret.highestTarget = &e
+		}
+	}
+	return ret, nil
+}
+
+// mergeHighestAttestation returns an attestation with the higher source and target of the given values
+func mergeHighestAttestation(current *phase0.AttestationData, source, target phase0.Epoch) *phase0.AttestationData {
+	if current != nil {
+		if current.Source.Epoch > source {
+			source = current.Source.Epoch
+		}
+		if current.Target.Epoch > target {
+			target = current.Target.Epoch
+		}
+	}
+	return minimalAttProtectionData(source, target)
+}
+
+func sourceOf(att *phase0.AttestationData) phase0.Epoch {
+	if att == nil || att.Source == nil {
+		return 0
+	}
+	return att.Source.Epoch
+}
+
+func targetOf(att *phase0.AttestationData) phase0.Epoch {
+	if att == nil || att.Target == nil {
+		return 0
+	}
+	return att.Target.Epoch
+}
+
+func encodeHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
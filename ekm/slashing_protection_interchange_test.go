@@ -0,0 +1,135 @@
+package ekm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/stretchr/testify/require"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+)
+
+func TestSlashingProtectionInterchange(t *testing.T) {
+	network := beaconprotocol.NewNetwork(core.PraterNetwork, 0)
+
+	t.Run("export and import", func(t *testing.T) {
+		wallet, signerStorage, done := testWallet(t)
+		defer done()
+
+		accounts := wallet.Accounts()
+		require.Len(t, accounts, 1)
+		pk := accounts[0].ValidatorPublicKey()
+		require.NoError(t, signerStorage.SaveHighestAttestation(pk, minimalAttProtectionData(10, 11)))
+		require.NoError(t, signerStorage.SaveHighestProposal(pk, 100))
+
+		interchange, err := ExportSlashingProtection(signerStorage, network)
+		require.NoError(t, err)
+		require.Equal(t, InterchangeFormatVersion, interchange.Metadata.InterchangeFormatVersion)
+		require.Equal(t, "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb", interchange.Metadata.GenesisValidatorsRoot)
+		require.Len(t, interchange.Data, 1)
+		require.Equal(t, encodeHex(pk), interchange.Data[0].Pubkey)
+		require.Equal(t, "10", interchange.Data[0].SignedAttestations[0].SourceEpoch)
+		require.Equal(t, "11", interchange.Data[0].SignedAttestations[0].TargetEpoch)
+		require.Equal(t, "100", interchange.Data[0].SignedBlocks[0].Slot)
+
+		// json round trip into a fresh storage
+		raw, err := json.Marshal(interchange)
+		require.NoError(t, err)
+		var imported Interchange
+		require.NoError(t, json.Unmarshal(raw, &imported))
+
+		newStorage, done2 := newStorageForTest()
+		defer done2()
+		require.NoError(t, ImportSlashingProtection(newStorage, network, &imported))
+
+		att, err := newStorage.RetrieveHighestAttestation(pk)
+		require.NoError(t, err)
+		require.EqualValues(t, 10, att.Source.Epoch)
+		require.EqualValues(t, 11, att.Target.Epoch)
+		slot, err := newStorage.RetrieveHighestProposal(pk)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, slot)
+	})
+
+	t.Run("export proposal only share", func(t *testing.T) {
+		signerStorage, done := newStorageForTest()
+		defer done()
+
+		attPk := _byteArray(pk1Str)
+		proposalPk := _byteArray(pk2Str)
+		require.NoError(t, signerStorage.SaveHighestAttestation(attPk, minimalAttProtectionData(10, 11)))
+		require.NoError(t, signerStorage.SaveHighestProposal(attPk, 100))
+		require.NoError(t, signerStorage.SaveHighestProposal(proposalPk, 200))
+
+		interchange, err := ExportSlashingProtection(signerStorage, network)
+		require.NoError(t, err)
+		require.Len(t, interchange.Data, 2)
+		byPubKey := make(map[string]*InterchangeData)
+		for _, data := range interchange.Data {
+			byPubKey[data.Pubkey] = data
+		}
+		require.Len(t, byPubKey[encodeHex(attPk)].SignedAttestations, 1)
+		require.Equal(t, "100", byPubKey[encodeHex(attPk)].SignedBlocks[0].Slot)
+		require.Empty(t, byPubKey[encodeHex(proposalPk)].SignedAttestations)
+		require.Equal(t, "200", byPubKey[encodeHex(proposalPk)].SignedBlocks[0].Slot)
+	})
+
+	t.Run("import keeps higher values", func(t *testing.T) {
+		wallet, signerStorage, done := testWallet(t)
+		defer done()
+
+		pk := wallet.Accounts()[0].ValidatorPublicKey()
+		require.NoError(t, signerStorage.SaveHighestAttestation(pk, minimalAttProtectionData(20, 21)))
+		require.NoError(t, signerStorage.SaveHighestProposal(pk, 500))
+
+		interchange := &Interchange{
+			Metadata: InterchangeMetadata{
+				InterchangeFormatVersion: InterchangeFormatVersion,
+				GenesisValidatorsRoot:    "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb",
+			},
+			Data: []*InterchangeData{
+				{
+					Pubkey:       encodeHex(pk),
+					SignedBlocks: []*InterchangeBlock{{Slot: "400"}},
+					SignedAttestations: []*InterchangeAttestation{
+						{SourceEpoch: "5", TargetEpoch: "30"},
+						{SourceEpoch: "15", TargetEpoch: "16"},
+					},
+				},
+			},
+		}
+		require.NoError(t, ImportSlashingProtection(signerStorage, network, interchange))
+
+		att, err := signerStorage.RetrieveHighestAttestation(pk)
+		require.NoError(t, err)
+		require.EqualValues(t, 20, att.Source.Epoch)
+		require.EqualValues(t, 30, att.Target.Epoch)
+		slot, err := signerStorage.RetrieveHighestProposal(pk)
+		require.NoError(t, err)
+		require.EqualValues(t, 500, slot)
+	})
+
+	t.Run("invalid interchange", func(t *testing.T) {
+		signerStorage, done := newStorageForTest()
+		defer done()
+
+		interchange := &Interchange{
+			Metadata: InterchangeMetadata{
+				InterchangeFormatVersion: InterchangeFormatVersion,
+				GenesisValidatorsRoot:    "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
+			},
+		}
+		require.EqualError(t, ImportSlashingProtection(signerStorage, network, interchange),
+			"genesis validators root mismatch: expected 0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb, got 0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95")
+
+		interchange.Metadata.InterchangeFormatVersion = "4"
+		require.EqualError(t, ImportSlashingProtection(signerStorage, network, interchange),
+			"unsupported interchange format version 4")
+
+		interchange.Metadata.InterchangeFormatVersion = InterchangeFormatVersion
+		interchange.Metadata.GenesisValidatorsRoot = "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"
+		interchange.Data = []*InterchangeData{{Pubkey: "0x01"}}
+		require.EqualError(t, ImportSlashingProtection(signerStorage, network, interchange), "invalid pubkey 0x01")
+	})
+}
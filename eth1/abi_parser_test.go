@@ -10,6 +10,7 @@ import (
 	"github.com/bloxapp/ssv/utils/logex"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -81,6 +82,53 @@ func TestParseValidatorRegistrationEvent(t *testing.T) {
 	})
 }
 
+func TestParseFeeRecipientAddressUpdatedEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI(V2)))
+	require.NoError(t, err)
+	ev := contractAbi.Events[abiparser.FeeRecipientAddressUpdated]
+	owner := common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F")
+	recipient := common.HexToAddress("0xceefd323dd28a8d9514eddfec45a6c81800a7d49")
+	data, err := ev.Inputs.NonIndexed().Pack(recipient)
+	require.NoError(t, err)
+
+	abiParser := NewParser(logex.Build("test", zap.DebugLevel, nil), V2)
+	parsed, err := abiParser.ParseFeeRecipientAddressUpdatedEvent(types.Log{
+		Topics: []common.Hash{ev.ID, common.BytesToHash(owner.Bytes())},
+		Data:   data,
+	}, contractAbi)
+	require.NoError(t, err)
+	require.Equal(t, owner, parsed.OwnerAddress)
+	require.Equal(t, recipient, parsed.RecipientAddress)
+
+	_, err = abiParser.ParseFeeRecipientAddressUpdatedEvent(types.Log{Topics: []common.Hash{ev.ID}, Data: data}, contractAbi)
+	var malformedEventErr *abiparser.MalformedEventError
+	require.True(t, errors.As(err, &malformedEventErr))
+}
+
+func TestParseValidatorExitedEvent(t *testing.T) {
+	contractAbi, err := abi.JSON(strings.NewReader(ContractABI(V2)))
+	require.NoError(t, err)
+	ev := contractAbi.Events[abiparser.ValidatorExited]
+	owner := common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F")
+	pubKey, err := hex.DecodeString("a8cb269bd7741740cfe90de2f8db6ea35a9da443385155da0fa2f621ba80e5ac14b5c8f65d23fd9ccc170cc85f29e27d")
+	require.NoError(t, err)
+	data, err := ev.Inputs.NonIndexed().Pack(pubKey)
+	require.NoError(t, err)
+
+	abiParser := NewParser(logex.Build("test", zap.DebugLevel, nil), V2)
+	parsed, err := abiParser.ParseValidatorExitedEvent(types.Log{
+		Topics: []common.Hash{ev.ID, common.BytesToHash(owner.Bytes())},
+		Data:   data,
+	}, contractAbi)
+	require.NoError(t, err)
+	require.Equal(t, owner, parsed.OwnerAddress)
+	require.Equal(t, pubKey, parsed.PublicKey)
+
+	_, err = abiParser.ParseValidatorExitedEvent(types.Log{Topics: []common.Hash{ev.ID}, Data: data}, contractAbi)
+	var malformedEventErr *abiparser.MalformedEventError
+	require.True(t, errors.As(err, &malformedEventErr))
+}
+
 func unmarshalLog(t *testing.T, rawOperatorRegistration string, abiVersion Version) (*types.Log, abi.ABI) {
 	var vLogOperatorRegistration types.Log
 	err := json.Unmarshal([]byte(rawOperatorRegistration), &vLogOperatorRegistration)
@@ -21,12 +21,14 @@ func (e *MalformedEventError) Error() string {
 
 // Event names
 const (
-	OperatorRegistration  = "OperatorRegistration"
-	OperatorRemoval       = "OperatorRemoval"
-	ValidatorRegistration = "ValidatorRegistration"
-	ValidatorRemoval      = "ValidatorRemoval"
-	AccountLiquidation    = "AccountLiquidation"
-	AccountEnable         = "AccountEnable"
+	OperatorRegistration       = "OperatorRegistration"
+	OperatorRemoval            = "OperatorRemoval"
+	ValidatorRegistration      = "ValidatorRegistration"
+	ValidatorRemoval           = "ValidatorRemoval"
+	AccountLiquidation         = "AccountLiquidation"
+	AccountEnable              = "AccountEnable"
+	FeeRecipientAddressUpdated = "FeeRecipientAddressUpdated"
+	ValidatorExited            = "ValidatorExited"
 )
 
 // OperatorRegistrationEvent struct represents event received by the smart contract
@@ -70,6 +72,20 @@ type AccountEnableEvent struct {
 	OwnerAddress common.Address // indexed
 }
 
+// FeeRecipientAddressUpdatedEvent struct represents event received by the smart contract
+type FeeRecipientAddressUpdatedEvent struct {
+	OwnerAddress     common.Address // indexed
+	RecipientAddress common.Address
+}
+
+// ValidatorExitedEvent struct represents event received by the smart contract
+type ValidatorExitedEvent struct {
+	OwnerAddress common.Address // indexed
+	PublicKey    []byte
+	// BlockTime is the timestamp of the block of the event, the exit epoch is derived from it
+	BlockTime uint64
+}
+
 // AbiV2 parsing events from v2 abi contract
 type AbiV2 struct {
 }
@@ -215,6 +231,47 @@ func (v2 *AbiV2) ParseAccountEnableEvent(log types.Log) (*AccountEnableEvent, er
 	return &accountEnableEvent, nil
 }
 
+// ParseFeeRecipientAddressUpdatedEvent parses FeeRecipientAddressUpdatedEvent
+func (v2 *AbiV2) ParseFeeRecipientAddressUpdatedEvent(
+	log types.Log,
+	contractAbi abi.ABI,
+) (*FeeRecipientAddressUpdatedEvent, error) {
+	var feeRecipientEvent FeeRecipientAddressUpdatedEvent
+	err := contractAbi.UnpackIntoInterface(&feeRecipientEvent, FeeRecipientAddressUpdated, log.Data)
+	if err != nil {
+		return nil, &MalformedEventError{
+			Err: errors.Wrap(err, "could not unpack event"),
+		}
+	}
+
+	if len(log.Topics) < 2 {
+		return nil, &MalformedEventError{
+			Err: errors.Errorf("%s event missing topics", FeeRecipientAddressUpdated),
+		}
+	}
+	feeRecipientEvent.OwnerAddress = common.HexToAddress(log.Topics[1].Hex())
+	return &feeRecipientEvent, nil
+}
+
+// ParseValidatorExitedEvent parses ValidatorExitedEvent, the block time is not part of the log and is set by the caller
+func (v2 *AbiV2) ParseValidatorExitedEvent(log types.Log, contractAbi abi.ABI) (*ValidatorExitedEvent, error) {
+	var validatorExitedEvent ValidatorExitedEvent
+	err := contractAbi.UnpackIntoInterface(&validatorExitedEvent, ValidatorExited, log.Data)
+	if err != nil {
+		return nil, &MalformedEventError{
+			Err: errors.Wrap(err, "could not unpack event"),
+		}
+	}
+
+	if len(log.Topics) < 2 {
+		return nil, &MalformedEventError{
+			Err: errors.Errorf("%s event missing topics", ValidatorExited),
+		}
+	}
+	validatorExitedEvent.OwnerAddress = common.HexToAddress(log.Topics[1].Hex())
+	return &validatorExitedEvent, nil
+}
+
 func readOperatorPubKey(operatorPublicKey []byte, outAbi abi.ABI) (string, error) {
 	outOperatorPublicKey, err := outAbi.Unpack("method", operatorPublicKey)
 	if err != nil {
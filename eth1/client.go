@@ -23,8 +23,17 @@ type Event struct {
 type SyncEndedEvent struct {
 	// Success returns true if the sync went well (all events were parsed)
 	Success bool
-	// Logs is the actual logs that we got from eth1
-	Logs []types.Log
+	// Events is the number of events that were synced
+	Events int
+}
+
+// SyncBatchEvent meant to notify an observer that the events of a batch of blocks were sent,
+// the sync offset can be upgraded to ToBlock once they were handled
+type SyncBatchEvent struct {
+	FromBlock uint64
+	ToBlock   uint64
+	// Success returns true if all the events of the batch were parsed
+	Success bool
 }
 
 // Client represents the required interface for eth1 client
@@ -33,3 +42,8 @@ type Client interface {
 	Start() error
 	Sync(fromBlock *big.Int) error
 }
+
+// EventsFetcher fetches the contract events of a blocks range
+type EventsFetcher interface {
+	FetchEvents(fromBlock, toBlock uint64) ([]*Event, error)
+}
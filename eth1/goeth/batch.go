@@ -0,0 +1,98 @@
+package goeth
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// minBlocksInBatch is the smallest batch, a single block
+	minBlocksInBatch uint64 = 1
+	// maxResponseBytes is the size of a response above which the batch shrinks
+	maxResponseBytes = 4 * 1024 * 1024
+)
+
+// limitErrors are parts of the errors that providers return when the response of a query is too large
+// or it covers too many blocks. rate limit errors (e.g. "too many requests", "rate limit exceeded")
+// must not match, as a smaller query doesn't help
+var limitErrors = []string{
+	"query returned more than", // geth, infura
+	"response size exceeded",   // alchemy
+	"read limit exceeded",      // websocket message size limit
+	"request entity too large", // http 413
+	// block range limits of providers
+	"block range is too wide",
+	"block range too large",
+	"exceed maximum block range",
+	"range limit exceeded",
+	"too many blocks",
+}
+
+// isLimitError returns true if the given error indicates that the query should cover fewer blocks
+func isLimitError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, limitErr := range limitErrors {
+		if strings.Contains(msg, limitErr) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTimeoutError returns true if the given error is a timeout of the request. unlike limit errors,
+// a timeout may be the endpoint's fault, so the request fails over to the other endpoints first
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// batchSizer adapts the number of blocks in an eth_getLogs query to the provider, it shrinks on limit errors
+// and large responses, and grows back on small responses
+type batchSizer struct {
+	size uint64
+	max  uint64
+}
+
+func newBatchSizer(max uint64) *batchSizer {
+	return &batchSizer{size: max, max: max}
+}
+
+// onError shrinks the batch, it returns false if the error is neither a limit error nor a timeout of all
+// the endpoints, or the batch can't shrink
+func (b *batchSizer) onError(err error) bool {
+	if !(isLimitError(err) || isTimeoutError(err)) || b.size <= minBlocksInBatch {
+		return false
+	}
+	b.size /= 2
+	return true
+}
+
+// onSuccess adapts the batch to the size of the response
+func (b *batchSizer) onSuccess(logs []types.Log) {
+	size := responseSize(logs)
+	switch {
+	case size > maxResponseBytes && b.size > minBlocksInBatch:
+		b.size /= 2
+	case size < maxResponseBytes/8 && b.size < b.max:
+		b.size *= 2
+		if b.size > b.max {
+			b.size = b.max
+		}
+	}
+}
+
+// responseSize estimates the size of the given logs
+func responseSize(logs []types.Log) int {
+	size := 0
+	for _, log := range logs {
+		size += len(log.Data) + 32*len(log.Topics)
+	}
+	return size
+}
@@ -0,0 +1,61 @@
+package goeth
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const defaultTestTimeout = 10 * time.Millisecond
+
+func TestIsLimitError(t *testing.T) {
+	require.True(t, isLimitError(errors.New("websocket: read limit exceeded")))
+	require.True(t, isLimitError(errors.New("query returned more than 10000 results")))
+	require.True(t, isLimitError(errors.Wrap(errors.New("Log response size exceeded."), "failed to get event logs")))
+	require.False(t, isLimitError(errors.Wrap(context.DeadlineExceeded, "failed to get event logs")))
+	require.True(t, isLimitError(errors.New("requested too many blocks from 1 to 20000, maximum is set to 10000")))
+	require.False(t, isLimitError(errors.New("connection refused")))
+	require.False(t, isLimitError(errors.New("429 Too Many Requests")))
+	require.False(t, isLimitError(errors.New("rate limit exceeded")))
+	require.False(t, isLimitError(errors.New("daily request limit exceeded")))
+}
+
+func TestIsTimeoutError(t *testing.T) {
+	require.True(t, isTimeoutError(errors.Wrap(context.DeadlineExceeded, "failed to get event logs")))
+	require.True(t, isTimeoutError(&net.DNSError{Err: "i/o timeout", IsTimeout: true}))
+	require.False(t, isTimeoutError(errors.New("connection refused")))
+	require.False(t, isTimeoutError(errors.New("block range is too wide")))
+}
+
+func TestBatchSizer(t *testing.T) {
+	b := newBatchSizer(8)
+
+	require.False(t, b.onError(errors.New("connection refused")))
+	require.Equal(t, uint64(8), b.size)
+
+	require.True(t, b.onError(errors.New("block range is too wide")))
+	require.True(t, b.onError(errors.New("block range is too wide")))
+	require.True(t, b.onError(errors.New("block range is too wide")))
+	require.Equal(t, uint64(1), b.size)
+	require.False(t, b.onError(errors.New("block range is too wide")))
+
+	// a timeout of all the endpoints shrinks the batch as well
+	b.size = 8
+	require.True(t, b.onError(errors.Wrap(context.DeadlineExceeded, "failed to get event logs")))
+	require.Equal(t, uint64(4), b.size)
+
+	// small responses grow the batch up to the max
+	for i := 0; i < 5; i++ {
+		b.onSuccess(nil)
+	}
+	require.Equal(t, uint64(8), b.size)
+
+	// large responses shrink it
+	b.onSuccess([]types.Log{{Data: make([]byte, maxResponseBytes+1)}})
+	require.Equal(t, uint64(4), b.size)
+}
@@ -0,0 +1,378 @@
+package goeth
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// endpointsCheckInterval is the interval of scoring the endpoints
+	endpointsCheckInterval = 30 * time.Second
+	// maxHeadLag is the number of blocks that the active endpoint can lag behind the others before it is replaced
+	maxHeadLag uint64 = 4
+	// headLagPenalty and errorPenalty weigh a block of lag and an error against latency, when scoring endpoints
+	headLagPenalty = float64(time.Second)
+	errorPenalty   = float64(5 * time.Second)
+	// latencyWeight is the weight of a new sample in the moving average of the latency
+	latencyWeight = 0.2
+	// maxCrossCheckRetries is the number of times that a history sync batch is fetched again on a mismatch
+	maxCrossCheckRetries = 3
+)
+
+var (
+	// errEndpointSwitched stops the subscriptions of the active endpoint once it is replaced
+	errEndpointSwitched = errors.New("active endpoint was switched")
+	// errCrossCheckMismatch is returned when the logs of two endpoints don't match
+	errCrossCheckMismatch = errors.New("the logs of the execution clients don't match")
+)
+
+// endpoint is an execution client, the eth1 client uses the active endpoint and fails over by scores
+type endpoint struct {
+	index int
+	addr  string
+	conn  *ethclient.Client
+	// latency is a moving average of the latency of requests
+	latency time.Duration
+	head    uint64
+	// errors is the number of recent errors, it decays on every check
+	errors float64
+}
+
+// score returns the score of the endpoint, lower is better
+func (e *endpoint) score(bestHead uint64) float64 {
+	if e.conn == nil {
+		return math.MaxFloat64
+	}
+	var lag uint64
+	if bestHead > e.head {
+		lag = bestHead - e.head
+	}
+	return float64(e.latency) + float64(lag)*headLagPenalty + e.errors*errorPenalty
+}
+
+// observe records the result of a request
+func (e *endpoint) observe(latency time.Duration, err error) {
+	if err != nil && !isLimitError(err) {
+		e.errors++
+		return
+	}
+	if e.latency == 0 {
+		e.latency = latency
+		return
+	}
+	e.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(e.latency))
+}
+
+func (e *endpoint) isHTTP() bool {
+	addr := strings.ToLower(e.addr)
+	return strings.HasPrefix(addr, "http://") || strings.HasPrefix(addr, "https://")
+}
+
+func newEndpoints(addrs []string) []*endpoint {
+	res := make([]*endpoint, 0, len(addrs))
+	for i, addr := range addrs {
+		res = append(res, &endpoint{index: i, addr: addr})
+	}
+	return res
+}
+
+// connectEndpoint connects to the given endpoint
+func (ec *eth1Client) connectEndpoint(e *endpoint) error {
+	ec.logger.Info("connecting to execution client", zap.String("address", e.addr))
+	ctx, cancel := context.WithTimeout(context.Background(), ec.connectionTimeout)
+	defer cancel()
+	conn, err := ethclient.DialContext(ctx, e.addr)
+	if err != nil {
+		ec.logger.Error("could not connect to the execution client", zap.String("address", e.addr), zap.Error(err))
+		return err
+	}
+	ec.logger.Info("successfully connected to execution client", zap.String("address", e.addr))
+	ec.endpointsLock.Lock()
+	e.conn = conn
+	ec.endpointsLock.Unlock()
+	return nil
+}
+
+// activeConn returns the connection of the active endpoint
+func (ec *eth1Client) activeConn() *ethclient.Client {
+	ec.endpointsLock.Lock()
+	defer ec.endpointsLock.Unlock()
+	return ec.active.conn
+}
+
+// candidates returns the active endpoint followed by the other endpoints by their scores
+func (ec *eth1Client) candidates() []*endpoint {
+	ec.endpointsLock.Lock()
+	defer ec.endpointsLock.Unlock()
+	res := ec.byScore()
+	for i, e := range res {
+		if e == ec.active {
+			copy(res[1:i+1], res[:i])
+			res[0] = e
+			break
+		}
+	}
+	return res
+}
+
+// byScore returns the endpoints by their scores, it must be called with the lock held
+func (ec *eth1Client) byScore() []*endpoint {
+	var bestHead uint64
+	for _, e := range ec.endpoints {
+		if e.head > bestHead {
+			bestHead = e.head
+		}
+	}
+	res := make([]*endpoint, len(ec.endpoints))
+	copy(res, ec.endpoints)
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].score(bestHead) < res[j].score(bestHead)
+	})
+	return res
+}
+
+// setActive replaces the active endpoint, it must be called with the lock held
+func (ec *eth1Client) setActive(e *endpoint) {
+	if ec.active == e {
+		return
+	}
+	ec.logger.Warn("switching the active execution client", zap.String("from", ec.active.addr), zap.String("to", e.addr))
+	ec.active = e
+	reportActiveEndpoint(e.index)
+}
+
+// call runs the given request on the active endpoint, and fails over to the other endpoints by their scores.
+// limit errors are returned as is, as they are handled by the caller. timeouts fail over like other errors
+func (ec *eth1Client) call(fn func(conn *ethclient.Client) error) error {
+	err := errors.New("no execution client is connected")
+	for _, e := range ec.candidates() {
+		ec.endpointsLock.Lock()
+		conn := e.conn
+		ec.endpointsLock.Unlock()
+		if conn == nil {
+			continue
+		}
+		start := time.Now()
+		err = fn(conn)
+		ec.endpointsLock.Lock()
+		e.observe(time.Since(start), err)
+		if err == nil || isLimitError(err) {
+			if e != ec.active && !ec.isStreaming() {
+				ec.setActive(e)
+			}
+			ec.endpointsLock.Unlock()
+			return err
+		}
+		ec.endpointsLock.Unlock()
+		ec.logger.Warn("execution client request failed", zap.String("address", e.addr), zap.Error(err))
+	}
+	return err
+}
+
+// isStreaming returns true if the events are streamed by subscriptions, which are bound to the active endpoint.
+// it must be called with the lock held
+func (ec *eth1Client) isStreaming() bool {
+	return ec.streaming
+}
+
+// failover replaces the active endpoint after it failed, it connects the endpoints by their scores
+// and returns an error if none of them is connected
+func (ec *eth1Client) failover() error {
+	ec.endpointsLock.Lock()
+	failed := ec.active
+	failed.errors++
+	candidates := ec.byScore()
+	ec.endpointsLock.Unlock()
+
+	err := errors.New("no execution client is connected")
+	for _, e := range candidates {
+		ec.endpointsLock.Lock()
+		connected := e.conn != nil && e != failed
+		ec.endpointsLock.Unlock()
+		if !connected {
+			if err = ec.connectEndpoint(e); err != nil {
+				continue
+			}
+		}
+		ec.endpointsLock.Lock()
+		ec.setActive(e)
+		ec.endpointsLock.Unlock()
+		return nil
+	}
+	return err
+}
+
+// monitorEndpoints scores the endpoints every endpointsCheckInterval, and replaces the active endpoint
+// if it lags behind or fails
+func (ec *eth1Client) monitorEndpoints() {
+	ticker := time.NewTicker(endpointsCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ec.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		ec.checkEndpoints()
+	}
+}
+
+// checkEndpoints updates the heads and latencies of the endpoints, and picks the best one
+func (ec *eth1Client) checkEndpoints() {
+	for _, e := range ec.endpoints {
+		ec.endpointsLock.Lock()
+		conn := e.conn
+		ec.endpointsLock.Unlock()
+		if conn == nil {
+			if err := ec.connectEndpoint(e); err != nil {
+				continue
+			}
+			ec.endpointsLock.Lock()
+			conn = e.conn
+			ec.endpointsLock.Unlock()
+		}
+		ctx, cancel := context.WithTimeout(ec.ctx, healthCheckTimeout)
+		start := time.Now()
+		head, err := conn.BlockNumber(ctx)
+		cancel()
+		ec.endpointsLock.Lock()
+		e.errors /= 2
+		e.observe(time.Since(start), err)
+		if err == nil {
+			e.head = head
+		}
+		reportEndpointHead(e.index, e.head)
+		ec.endpointsLock.Unlock()
+	}
+
+	ec.endpointsLock.Lock()
+	defer ec.endpointsLock.Unlock()
+	best := ec.byScore()[0]
+	if best == ec.active || best.conn == nil {
+		return
+	}
+	lagging := best.head > ec.active.head+maxHeadLag
+	failing := ec.active.errors >= 1 || ec.active.conn == nil
+	if !lagging && !failing {
+		return
+	}
+	ec.setActive(best)
+	if ec.isStreaming() {
+		select {
+		case ec.endpointSwitched <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (ec *eth1Client) blockNumber() (uint64, error) {
+	var head uint64
+	err := ec.call(func(conn *ethclient.Client) error {
+		var err error
+		head, err = conn.BlockNumber(ec.ctx)
+		return err
+	})
+	return head, err
+}
+
+func (ec *eth1Client) headerByNumber(number uint64) (*types.Header, error) {
+	var header *types.Header
+	err := ec.call(func(conn *ethclient.Client) error {
+		var err error
+		header, err = conn.HeaderByNumber(ec.ctx, new(big.Int).SetUint64(number))
+		return err
+	})
+	return header, err
+}
+
+// getLogs fetches the logs of the registry contract in the given blocks range
+func (ec *eth1Client) getLogs(from, to uint64) ([]types.Log, error) {
+	var logs []types.Log
+	err := ec.call(func(conn *ethclient.Client) error {
+		var err error
+		logs, err = conn.FilterLogs(ec.ctx, ec.logsQuery(from, to))
+		return err
+	})
+	return logs, err
+}
+
+func (ec *eth1Client) logsQuery(from, to uint64) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{common.HexToAddress(ec.registryContractAddr)},
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+	}
+}
+
+// crossCheckLogs compares the logs of the given blocks range with the logs of another endpoint, if enabled.
+// the check is skipped if there is no other endpoint or it fails
+func (ec *eth1Client) crossCheckLogs(from, to uint64, logs []types.Log) error {
+	if !ec.crossCheck || from > to {
+		return nil
+	}
+	ec.endpointsLock.Lock()
+	var other *endpoint
+	for _, e := range ec.byScore() {
+		if e != ec.active && e.conn != nil {
+			other = e
+			break
+		}
+	}
+	ec.endpointsLock.Unlock()
+	if other == nil {
+		ec.logger.Debug("no execution client to cross check logs with")
+		return nil
+	}
+	otherLogs, err := other.conn.FilterLogs(ec.ctx, ec.logsQuery(from, to))
+	if err != nil {
+		ec.logger.Debug("could not cross check logs", zap.String("address", other.addr), zap.Error(err))
+		return nil
+	}
+	missing, extra := diffLogs(logsInRange(logs, from, to), otherLogs)
+	if missing == 0 && extra == 0 {
+		return nil
+	}
+	metricCrossCheckMismatches.Inc()
+	ec.logger.Error("the logs of the execution clients don't match",
+		zap.Uint64("fromBlock", from), zap.Uint64("toBlock", to),
+		zap.String("address", other.addr), zap.Int("missing", missing), zap.Int("extra", extra))
+	return errCrossCheckMismatch
+}
+
+// logsInRange returns the logs of the given blocks range
+func logsInRange(logs []types.Log, from, to uint64) []types.Log {
+	var res []types.Log
+	for _, log := range logs {
+		if log.BlockNumber >= from && log.BlockNumber <= to {
+			res = append(res, log)
+		}
+	}
+	return res
+}
+
+// diffLogs returns the number of logs that are missing from the other logs, and the number of extra logs
+func diffLogs(logs, other []types.Log) (missing int, extra int) {
+	keys := make(map[logKey]bool, len(logs))
+	for _, log := range logs {
+		keys[newLogKey(log)] = true
+	}
+	for _, log := range other {
+		if keys[newLogKey(log)] {
+			delete(keys, newLogKey(log))
+		} else {
+			extra++
+		}
+	}
+	return len(keys), extra
+}
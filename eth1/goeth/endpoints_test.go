@@ -0,0 +1,153 @@
+package goeth
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointScore(t *testing.T) {
+	e := &endpoint{}
+	require.Equal(t, math.MaxFloat64, e.score(0), "disconnected endpoints are the worst")
+
+	conn := ethclient.NewClient(nil)
+	fast := &endpoint{conn: conn, latency: 10 * time.Millisecond, head: 100}
+	slow := &endpoint{conn: conn, latency: 500 * time.Millisecond, head: 100}
+	lagging := &endpoint{conn: conn, latency: 10 * time.Millisecond, head: 98}
+	failing := &endpoint{conn: conn, latency: 10 * time.Millisecond, head: 100, errors: 1}
+	require.Less(t, fast.score(100), slow.score(100))
+	require.Less(t, slow.score(100), lagging.score(100))
+	require.Less(t, lagging.score(100), failing.score(100))
+
+	// limit errors are not the endpoint's fault
+	fast.observe(time.Millisecond, errCrossCheckMismatch)
+	require.Equal(t, float64(1), fast.errors)
+	slow.observe(time.Millisecond, errors.New("query returned more than 10000 results"))
+	require.Equal(t, float64(0), slow.errors)
+}
+
+func TestEth1Client_Failover(t *testing.T) {
+	primary := &fakeChain{head: 20, logs: []types.Log{registrationLog(t, 5, 1)}}
+	secondary := &fakeChain{head: 20, logs: []types.Log{registrationLog(t, 5, 1)}}
+	ec, events := newPollingClient(t, 0, false, primary, secondary)
+	require.Equal(t, 0, ec.active.index)
+
+	// the requests fail over to the secondary once the primary is down
+	primary.srv.Close()
+	require.NoError(t, ec.Sync(big.NewInt(0)))
+	require.Equal(t, []byte{1}, receivedTxs(events))
+	require.Equal(t, 1, ec.active.index)
+	require.Equal(t, float64(1), ec.endpoints[0].errors)
+}
+
+func TestEth1Client_FailoverOnTimeout(t *testing.T) {
+	ec, _ := newPollingClient(t, 0, false, &fakeChain{head: 20}, &fakeChain{head: 20})
+	require.Equal(t, 0, ec.active.index)
+
+	// a timeout is counted against the endpoint and the request fails over, unlike a limit error
+	primary := ec.endpoints[0].conn
+	err := ec.call(func(conn *ethclient.Client) error {
+		if conn == primary {
+			return errors.Wrap(context.DeadlineExceeded, "failed to get event logs")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, ec.active.index)
+	require.Equal(t, float64(1), ec.endpoints[0].errors)
+}
+
+func TestEth1Client_CheckEndpoints(t *testing.T) {
+	primary := &fakeChain{head: 20}
+	secondary := &fakeChain{head: 20}
+	ec, _ := newPollingClient(t, 0, false, primary, secondary)
+
+	ec.checkEndpoints()
+	require.Equal(t, 0, ec.active.index)
+
+	// a lagging active endpoint is replaced
+	secondary.set(func(c *fakeChain) {
+		c.head = 20 + maxHeadLag + 1
+	})
+	ec.checkEndpoints()
+	require.Equal(t, 1, ec.active.index)
+}
+
+func TestEth1Client_CrossCheck(t *testing.T) {
+	primary := &fakeChain{head: 20, logs: []types.Log{registrationLog(t, 5, 1)}}
+	secondary := &fakeChain{head: 20, logs: []types.Log{registrationLog(t, 5, 1)}}
+	ec, events := newPollingClient(t, 0, true, primary, secondary)
+
+	require.NoError(t, ec.Sync(big.NewInt(0)))
+	require.Equal(t, []byte{1}, receivedTxs(events))
+
+	// the history sync fails while the logs don't match
+	mismatches := testutil.ToFloat64(metricCrossCheckMismatches)
+	secondary.set(func(c *fakeChain) {
+		c.logs = append(c.logs, registrationLog(t, 7, 2))
+	})
+	require.ErrorIs(t, ec.Sync(big.NewInt(0)), errCrossCheckMismatch)
+	require.Equal(t, mismatches+1+maxCrossCheckRetries, testutil.ToFloat64(metricCrossCheckMismatches))
+	require.Empty(t, receivedTxs(events))
+
+	// a missing secondary skips the check
+	secondary.srv.Close()
+	require.NoError(t, ec.Sync(big.NewInt(0)))
+	require.Equal(t, []byte{1}, receivedTxs(events))
+}
+
+func TestEth1Client_CrossCheckStreamedLogs(t *testing.T) {
+	primary := &fakeChain{head: 20, logs: []types.Log{registrationLog(t, 5, 1), registrationLog(t, 12, 2)}}
+	secondary := &fakeChain{head: 20, logs: []types.Log{registrationLog(t, 5, 1), registrationLog(t, 12, 2)}}
+	ec, events := newPollingClient(t, 2, true, primary, secondary)
+	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
+	require.NoError(t, err)
+	ec.synced = true
+	ec.logs.setConfirmed(3)
+
+	ec.logs.add(registrationLog(t, 5, 1))
+	ec.logs.setHead(10)
+	require.NoError(t, ec.dispatchStreamedLogs(contractAbi))
+	require.Equal(t, []byte{1}, receivedTxs(events))
+
+	// a log that was missed by the stream holds back the confirmed logs, until they are fetched again
+	mismatches := testutil.ToFloat64(metricCrossCheckMismatches)
+	ec.logs.setHead(15)
+	require.NoError(t, ec.dispatchStreamedLogs(contractAbi))
+	require.Equal(t, mismatches+1, testutil.ToFloat64(metricCrossCheckMismatches))
+	require.Empty(t, receivedTxs(events))
+	require.NoError(t, ec.dispatchStreamedLogs(contractAbi))
+	require.Equal(t, []byte{2}, receivedTxs(events))
+}
+
+func TestEth1Client_IsPolling(t *testing.T) {
+	ec := &eth1Client{endpoints: newEndpoints([]string{"ws://localhost:8546", "http://localhost:8545"})}
+	ec.active = ec.endpoints[0]
+	require.False(t, ec.isPolling())
+	ec.active = ec.endpoints[1]
+	require.True(t, ec.isPolling())
+}
+
+func TestDiffLogs(t *testing.T) {
+	a, b, c := newTestLog(1, 0, 0), newTestLog(2, 0, 0), newTestLog(2, 0, 1)
+	missing, extra := diffLogs([]types.Log{a, b}, []types.Log{a, b})
+	require.Zero(t, missing)
+	require.Zero(t, extra)
+
+	// the same log in another block is a mismatch
+	missing, extra = diffLogs([]types.Log{a, b}, []types.Log{a, c})
+	require.Equal(t, 1, missing)
+	require.Equal(t, 1, extra)
+
+	require.Equal(t, []types.Log{b, c}, logsInRange([]types.Log{a, b, c}, 2, 3))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bloxapp/ssv/eth1"
@@ -16,7 +17,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"go.uber.org/zap"
@@ -29,12 +29,19 @@ const (
 
 // ClientOptions are the options for the client
 type ClientOptions struct {
-	Ctx                  context.Context
-	Logger               *zap.Logger
-	NodeAddr             string
+	Ctx    context.Context
+	Logger *zap.Logger
+	// NodeAddrs are the addresses of the execution clients, the first one is preferred
+	NodeAddrs            []string
 	RegistryContractAddr string
 	ContractABI          string
 	ConnectionTimeout    time.Duration
+	// FollowDistance is the number of blocks that an event must be deep before it is dispatched
+	FollowDistance uint64
+	// PollingInterval is the interval of polling new blocks, while the active node address is an HTTP address
+	PollingInterval time.Duration
+	// CrossCheck compares the confirmed logs with the logs of another endpoint before they are dispatched
+	CrossCheck bool
 
 	AbiVersion eth1.Version
 }
@@ -42,15 +49,30 @@ type ClientOptions struct {
 // eth1Client is the internal implementation of Client
 type eth1Client struct {
 	ctx    context.Context
-	conn   *ethclient.Client
 	logger *zap.Logger
 
-	nodeAddr             string
+	endpoints     []*endpoint
+	endpointsLock sync.Mutex
+	active        *endpoint
+	// endpointSwitched stops the subscriptions once the active endpoint is replaced
+	endpointSwitched chan struct{}
+	crossCheck       bool
+
 	registryContractAddr string
 	contractABI          string
 	connectionTimeout    time.Duration
+	followDistance       uint64
+	pollingInterval      time.Duration
 
 	eventsFeed *event.Feed
+	logs       *logsBuffer
+	batch      *batchSizer
+	// synced is true once the history was synced, the stream then fetches the blocks that followed it
+	synced bool
+	// checkpoint is a confirmed block and its hash, when polling
+	checkpoint blockCheckpoint
+	// streaming is true while the events are streamed by the subscriptions of the active endpoint
+	streaming bool
 
 	abiVersion eth1.Version
 }
@@ -58,6 +80,15 @@ type eth1Client struct {
 // verifies that the client implements HealthCheckAgent
 var _ metrics.HealthCheckAgent = &eth1Client{}
 
+// verifies that the client implements EventsFetcher
+var _ eth1.EventsFetcher = &eth1Client{}
+
+// blockCheckpoint is a block number and hash
+type blockCheckpoint struct {
+	number uint64
+	hash   common.Hash
+}
+
 // NewEth1Client creates a new instance
 func NewEth1Client(opts ClientOptions) (eth1.Client, error) {
 	logger := opts.Logger
@@ -65,18 +96,34 @@ func NewEth1Client(opts ClientOptions) (eth1.Client, error) {
 	ec := eth1Client{
 		ctx:                  opts.Ctx,
 		logger:               logger,
-		nodeAddr:             opts.NodeAddr,
+		endpoints:            newEndpoints(opts.NodeAddrs),
+		endpointSwitched:     make(chan struct{}, 1),
+		crossCheck:           opts.CrossCheck,
 		registryContractAddr: opts.RegistryContractAddr,
 		contractABI:          opts.ContractABI,
 		connectionTimeout:    opts.ConnectionTimeout,
+		followDistance:       opts.FollowDistance,
+		pollingInterval:      opts.PollingInterval,
 		eventsFeed:           new(event.Feed),
+		logs:                 newLogsBuffer(opts.FollowDistance),
+		batch:                newBatchSizer(blocksInBatch),
 		abiVersion:           opts.AbiVersion,
 	}
 
-	if err := ec.connect(); err != nil {
+	if len(ec.endpoints) == 0 {
+		return nil, errors.New("missing execution client address")
+	}
+	var err error
+	for _, e := range ec.endpoints {
+		if err = ec.connectEndpoint(e); err == nil && ec.active == nil {
+			ec.active = e
+		}
+	}
+	if ec.active == nil {
 		logger.Error("failed to connect to the execution client", zap.Error(err))
 		return nil, err
 	}
+	reportActiveEndpoint(ec.active.index)
 
 	return &ec, nil
 }
@@ -86,15 +133,34 @@ func (ec *eth1Client) EventsFeed() *event.Feed {
 	return ec.eventsFeed
 }
 
-// Start streams events from the contract
+// Start streams events from the contract, while the active endpoint is an HTTP endpoint the new blocks are polled
 func (ec *eth1Client) Start() error {
-	err := ec.streamSmartContractEvents()
+	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse ABI interface")
+	}
+	if len(ec.endpoints) > 1 {
+		go ec.monitorEndpoints()
+	}
+	err = ec.followEvents(contractAbi)
 	if err != nil {
 		ec.logger.Error("Failed to init operator contract address subject", zap.Error(err))
 	}
 	return err
 }
 
+// followEvents streams the events of the active endpoint, or polls them if it's an HTTP endpoint
+func (ec *eth1Client) followEvents(contractAbi abi.ABI) error {
+	ec.endpointsLock.Lock()
+	polling := ec.isPolling()
+	ec.endpointsLock.Unlock()
+	if polling {
+		go ec.pollSmartContractEvents(contractAbi)
+		return nil
+	}
+	return ec.streamSmartContractEvents(contractAbi)
+}
+
 // Sync reads events history
 func (ec *eth1Client) Sync(fromBlock *big.Int) error {
 	err := ec.syncSmartContractsEvents(fromBlock)
@@ -106,12 +172,13 @@ func (ec *eth1Client) Sync(fromBlock *big.Int) error {
 
 // HealthCheck provides health status of eth1 node
 func (ec *eth1Client) HealthCheck() []string {
-	if ec.conn == nil {
+	conn := ec.activeConn()
+	if conn == nil {
 		return []string{"not connected to eth1 node"}
 	}
 	ctx, cancel := context.WithTimeout(ec.ctx, healthCheckTimeout)
 	defer cancel()
-	sp, err := ec.conn.SyncProgress(ctx)
+	sp, err := conn.SyncProgress(ctx)
 	if err != nil {
 		reportNodeStatus(statusUnknown)
 		return []string{"could not get eth1 node sync progress"}
@@ -126,28 +193,25 @@ func (ec *eth1Client) HealthCheck() []string {
 	return []string{}
 }
 
-// connect connects to eth1 client
-func (ec *eth1Client) connect() error {
-	// Create an IPC based RPC connection to a remote node
-	ec.logger.Info("connecting to execution client", zap.String("address", ec.nodeAddr))
-	ctx, cancel := context.WithTimeout(context.Background(), ec.connectionTimeout)
-	defer cancel()
-	conn, err := ethclient.DialContext(ctx, ec.nodeAddr)
-	if err != nil {
-		ec.logger.Error("could not connect to the execution client", zap.Error(err))
-		return err
-	}
-	ec.logger.Info("successfully connected to execution client")
-	ec.conn = conn
-	return nil
+// isPolling returns true if the active endpoint is an HTTP endpoint, which doesn't support subscriptions.
+// it must be called with the lock held
+func (ec *eth1Client) isPolling() bool {
+	return ec.active.isHTTP()
+}
+
+// setStreaming sets whether the events are streamed by the subscriptions of the active endpoint
+func (ec *eth1Client) setStreaming(streaming bool) {
+	ec.endpointsLock.Lock()
+	defer ec.endpointsLock.Unlock()
+	ec.streaming = streaming
 }
 
-// reconnect tries to reconnect multiple times with an exponent interval
-func (ec *eth1Client) reconnect() {
+// reconnect fails over to the best endpoint, it tries multiple times with an exponent interval
+func (ec *eth1Client) reconnect(contractAbi abi.ABI) {
 	limit := 64 * time.Second
 	tasks.ExecWithInterval(func(lastTick time.Duration) (stop bool, cont bool) {
 		ec.logger.Info("reconnecting to eth1 node")
-		if err := ec.connect(); err != nil {
+		if err := ec.failover(); err != nil {
 			// continue until reaching to limit, and then panic as eth1 connection is required
 			if lastTick >= limit {
 				ec.logger.Panic("failed to reconnect to eth1 node", zap.Error(err))
@@ -159,7 +223,7 @@ func (ec *eth1Client) reconnect() {
 		return true, false
 	}, 1*time.Second, limit+(1*time.Second))
 	ec.logger.Debug("managed to reconnect to eth1 node")
-	if err := ec.streamSmartContractEvents(); err != nil {
+	if err := ec.followEvents(contractAbi); err != nil {
 		// TODO: panic?
 		ec.logger.Error("failed to stream events after reconnection", zap.Error(err))
 	}
@@ -173,23 +237,36 @@ func (ec *eth1Client) fireEvent(log types.Log, name string, data interface{}) {
 	// ec.logger.Debug("events was sent to subscribers", zap.Int("num of subscribers", n))
 }
 
-// streamSmartContractEvents sync events history of the given contract
-func (ec *eth1Client) streamSmartContractEvents() error {
+// streamSmartContractEvents streams the events of the given contract from the active endpoint
+func (ec *eth1Client) streamSmartContractEvents(contractAbi abi.ABI) error {
 	ec.logger.Debug("streaming smart contract events")
 
-	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
-	if err != nil {
-		return errors.Wrap(err, "failed to parse ABI interface")
-	}
-
+	ec.setStreaming(true)
 	sub, logs, err := ec.subscribeToLogs()
 	if err != nil {
+		ec.setStreaming(false)
 		return errors.Wrap(err, "Failed to subscribe to logs")
 	}
+	headsSub, heads, err := ec.subscribeToHeads()
+	if err != nil {
+		sub.Unsubscribe()
+		ec.setStreaming(false)
+		return errors.Wrap(err, "Failed to subscribe to new heads")
+	}
 
 	go func() {
-		if err := ec.listenToSubscription(logs, sub, contractAbi); err != nil {
-			ec.reconnect()
+		err := ec.listenToSubscription(logs, heads, sub, headsSub, contractAbi)
+		sub.Unsubscribe()
+		headsSub.Unsubscribe()
+		ec.setStreaming(false)
+		if errors.Is(err, errEndpointSwitched) {
+			// the new active endpoint is followed by its own subscriptions, or polled if it's an HTTP endpoint
+			if err := ec.followEvents(contractAbi); err == nil {
+				return
+			}
+		}
+		if err != nil {
+			ec.reconnect(contractAbi)
 		}
 	}()
 
@@ -202,7 +279,7 @@ func (ec *eth1Client) subscribeToLogs() (ethereum.Subscription, chan types.Log,
 		Addresses: []common.Address{contractAddress},
 	}
 	logs := make(chan types.Log)
-	sub, err := ec.conn.SubscribeFilterLogs(ec.ctx, query, logs)
+	sub, err := ec.activeConn().SubscribeFilterLogs(ec.ctx, query, logs)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "Failed to subscribe to logs")
 	}
@@ -211,30 +288,252 @@ func (ec *eth1Client) subscribeToLogs() (ethereum.Subscription, chan types.Log,
 	return sub, logs, nil
 }
 
-// listenToSubscription listen to new event logs from the contract
-func (ec *eth1Client) listenToSubscription(logs chan types.Log, sub ethereum.Subscription, contractAbi abi.ABI) error {
+func (ec *eth1Client) subscribeToHeads() (ethereum.Subscription, chan *types.Header, error) {
+	heads := make(chan *types.Header)
+	sub, err := ec.activeConn().SubscribeNewHead(ec.ctx, heads)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Failed to subscribe to new heads")
+	}
+	ec.logger.Debug("subscribed to new heads")
+
+	return sub, heads, nil
+}
+
+// fetchPendingLogs fetches the logs of the blocks above the confirmed block, which were not dispatched by the
+// history sync or were missed while the subscription was down
+func (ec *eth1Client) fetchPendingLogs() error {
+	if !ec.synced {
+		return nil
+	}
+	head, err := ec.blockNumber()
+	if err != nil {
+		return errors.Wrap(err, "failed to get current block")
+	}
+	logs, err := ec.filterLogs(ec.logs.confirmed+1, head)
+	if err != nil {
+		return err
+	}
+	ec.logger.Debug("fetched logs of unconfirmed blocks", zap.Uint64("fromBlock", ec.logs.confirmed+1),
+		zap.Uint64("head", head), zap.Int("results", len(logs)))
+	ec.logs.reset(logs)
+	ec.logs.setHead(head)
+	return nil
+}
+
+// dispatchStreamedLogs dispatches the logs that are followDistance blocks deep, once they match the logs
+// of another endpoint if the cross check is enabled. on a mismatch the pending logs are fetched again,
+// as streamed logs might have been missed, and they are checked again on the next head
+func (ec *eth1Client) dispatchStreamedLogs(contractAbi abi.ABI) error {
+	if from, to, ok := ec.logs.confirmedRange(); ok && ec.crossCheckLogs(from, to, ec.logs.pendingLogs()) != nil {
+		if err := ec.fetchPendingLogs(); err != nil {
+			ec.logger.Warn("failed to fetch logs of unconfirmed blocks", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+	ec.dispatchConfirmedLogs(contractAbi)
+	return nil
+}
+
+// listenToSubscription listen to new event logs from the contract,
+// the logs are dispatched once they are followDistance blocks deep
+func (ec *eth1Client) listenToSubscription(logs chan types.Log, heads chan *types.Header, sub, headsSub ethereum.Subscription, contractAbi abi.ABI) error {
+	if err := ec.fetchPendingLogs(); err != nil {
+		ec.logger.Warn("failed to fetch logs of unconfirmed blocks", zap.Error(err))
+		return err
+	}
+	if err := ec.dispatchStreamedLogs(contractAbi); err != nil {
+		return err
+	}
 	for {
 		select {
 		case err := <-sub.Err():
 			ec.logger.Warn("failed to read logs from subscription", zap.Error(err))
 			return err
+		case err := <-headsSub.Err():
+			ec.logger.Warn("failed to read new heads from subscription", zap.Error(err))
+			return err
+		case <-ec.endpointSwitched:
+			return errEndpointSwitched
+		case header := <-heads:
+			ec.logs.setHead(header.Number.Uint64())
 		case vLog := <-logs:
-			if vLog.Removed {
-				continue
+			ec.logger.Debug("received contract event from stream", zap.Uint64("block", vLog.BlockNumber),
+				zap.Bool("removed", vLog.Removed))
+			if ec.logs.add(vLog) {
+				ec.reportReorgedEvent(vLog, contractAbi)
 			}
-			ec.logger.Debug("received contract event from stream")
-			eventName, err := ec.handleEvent(vLog, contractAbi)
-			if err != nil {
-				ec.logger.Warn("could not parse ongoing event, the event is malformed",
-					zap.String("event", eventName),
-					zap.Uint64("block", vLog.BlockNumber),
-					zap.String("txHash", vLog.TxHash.Hex()),
-					zap.Error(err),
-				)
+		}
+		if err := ec.dispatchStreamedLogs(contractAbi); err != nil {
+			return err
+		}
+	}
+}
+
+// pollSmartContractEvents polls the new blocks every pollingInterval, the logs of the blocks above the
+// confirmed block are fetched on every poll so that logs which were removed by a reorg are dropped
+func (ec *eth1Client) pollSmartContractEvents(contractAbi abi.ABI) {
+	ec.logger.Debug("polling smart contract events", zap.Duration("interval", ec.pollingInterval))
+	ticker := time.NewTicker(ec.pollingInterval)
+	defer ticker.Stop()
+	for {
+		if err := ec.poll(contractAbi); err != nil {
+			ec.logger.Warn("failed to poll contract events", zap.Error(err))
+		}
+		ec.endpointsLock.Lock()
+		polling := ec.isPolling()
+		ec.endpointsLock.Unlock()
+		if !polling {
+			// the active endpoint was replaced by an endpoint that supports subscriptions
+			err := ec.streamSmartContractEvents(contractAbi)
+			if err == nil {
+				return
+			}
+			ec.logger.Warn("failed to stream contract events, still polling", zap.Error(err))
+		}
+		select {
+		case <-ec.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (ec *eth1Client) poll(contractAbi abi.ABI) error {
+	head, err := ec.blockNumber()
+	if err != nil {
+		return errors.Wrap(err, "failed to get current block")
+	}
+	if !ec.synced {
+		// without history sync, only the new blocks are polled
+		ec.logs.setConfirmed(head)
+		ec.synced = true
+		return nil
+	}
+	if head <= ec.logs.confirmed {
+		return nil
+	}
+	if err := ec.checkDispatchedLogs(contractAbi); err != nil {
+		ec.logger.Warn("could not check dispatched logs", zap.Error(err))
+	}
+	logs, err := ec.filterLogs(ec.logs.confirmed+1, head)
+	if err != nil {
+		return err
+	}
+	if head > ec.followDistance {
+		if err := ec.crossCheckLogs(ec.logs.confirmed+1, head-ec.followDistance, logs); err != nil {
+			return err
+		}
+	}
+	ec.logs.reset(logs)
+	ec.logs.setHead(head)
+	ec.dispatchConfirmedLogs(contractAbi)
+	return ec.updateCheckpoint()
+}
+
+// updateCheckpoint saves the hash of the confirmed block
+func (ec *eth1Client) updateCheckpoint() error {
+	if ec.logs.confirmed == 0 || ec.logs.confirmed == ec.checkpoint.number {
+		return nil
+	}
+	header, err := ec.headerByNumber(ec.logs.confirmed)
+	if err != nil {
+		return errors.Wrap(err, "failed to get confirmed block")
+	}
+	ec.checkpoint = blockCheckpoint{number: ec.logs.confirmed, hash: header.Hash()}
+	return nil
+}
+
+// checkDispatchedLogs looks for dispatched logs that were removed by a reorg. polled logs have no removal
+// notifications, instead the dispatched logs are fetched again once the hash of the checkpoint block changes
+func (ec *eth1Client) checkDispatchedLogs(contractAbi abi.ABI) error {
+	if ec.checkpoint.number == 0 {
+		return nil
+	}
+	header, err := ec.headerByNumber(ec.checkpoint.number)
+	if err != nil {
+		return errors.Wrap(err, "failed to get checkpoint block")
+	}
+	if header.Hash() == ec.checkpoint.hash {
+		return nil
+	}
+	ec.logger.Warn("a confirmed block was reorged", zap.Uint64("block", ec.checkpoint.number),
+		zap.String("prevHash", ec.checkpoint.hash.Hex()), zap.String("hash", header.Hash().Hex()))
+	ec.checkpoint = blockCheckpoint{number: ec.checkpoint.number, hash: header.Hash()}
+	from, ok := ec.logs.lowestDispatched()
+	if !ok {
+		return nil
+	}
+	logs, err := ec.filterLogs(from, ec.logs.confirmed)
+	if err != nil {
+		return err
+	}
+	for _, removed := range ec.logs.removedDispatched(logs) {
+		ec.reportReorgedEvent(removed, contractAbi)
+	}
+	return nil
+}
+
+// filterLogs fetches the logs of the given blocks range in batches
+func (ec *eth1Client) filterLogs(from, to uint64) ([]types.Log, error) {
+	var res []types.Log
+	for from <= to {
+		end := ec.batchEnd(from, to)
+		logs, err := ec.getLogs(from, end)
+		if err != nil {
+			if ec.batch.onError(err) {
+				ec.logger.Debug("using a lower batch size", zap.Uint64("currentBatchSize", ec.batch.size), zap.Error(err))
 				continue
 			}
+			return nil, errors.Wrap(err, "failed to get event logs")
 		}
+		ec.batch.onSuccess(logs)
+		res = append(res, logs...)
+		from = end + 1
 	}
+	return res, nil
+}
+
+// batchEnd returns the last block of the batch that starts at the given block
+func (ec *eth1Client) batchEnd(from, to uint64) uint64 {
+	if to-from+1 > ec.batch.size {
+		return from + ec.batch.size - 1
+	}
+	return to
+}
+
+// dispatchConfirmedLogs fires the events of the logs that are followDistance blocks deep
+func (ec *eth1Client) dispatchConfirmedLogs(contractAbi abi.ABI) {
+	for _, vLog := range ec.logs.confirm() {
+		eventName, err := ec.handleEvent(vLog, contractAbi)
+		if err != nil {
+			ec.logger.Warn("could not parse ongoing event, the event is malformed",
+				zap.String("event", eventName),
+				zap.Uint64("block", vLog.BlockNumber),
+				zap.String("txHash", vLog.TxHash.Hex()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// reportReorgedEvent alerts about an event that was dispatched and then removed by a reorg,
+// which is deeper than the follow distance. the event was already applied and is not reverted.
+func (ec *eth1Client) reportReorgedEvent(vLog types.Log, contractAbi abi.ABI) {
+	eventName := "unknown"
+	if len(vLog.Topics) > 0 {
+		if ev, err := contractAbi.EventByID(vLog.Topics[0]); err == nil {
+			eventName = ev.Name
+		}
+	}
+	metricReorgedEvents.WithLabelValues(eventName).Inc()
+	ec.logger.Error("an applied contract event was removed by a reorg, the registry data might be inconsistent",
+		zap.String("event", eventName),
+		zap.Uint64("block", vLog.BlockNumber),
+		zap.String("blockHash", vLog.BlockHash.Hex()),
+		zap.String("txHash", vLog.TxHash.Hex()),
+		zap.Uint64("followDistance", ec.followDistance),
+	)
 }
 
 // syncSmartContractsEvents sync events history of the given contract
@@ -245,75 +544,68 @@ func (ec *eth1Client) syncSmartContractsEvents(fromBlock *big.Int) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to parse ABI interface")
 	}
-	currentBlock, err := ec.conn.BlockNumber(ec.ctx)
+	currentBlock, err := ec.blockNumber()
 	if err != nil {
 		return errors.Wrap(err, "failed to get current block")
 	}
-	var logs []types.Log
-	var nSuccess int
-	for {
-		var toBlock *big.Int
-		if currentBlock-fromBlock.Uint64() > blocksInBatch {
-			toBlock = big.NewInt(int64(fromBlock.Uint64() + blocksInBatch))
-		} else { // no more batches are required -> setting toBlock to nil
-			toBlock = nil
-		}
-		_logs, _nSuccess, err := ec.fetchAndProcessEvents(fromBlock, toBlock, contractAbi)
+	// only the blocks that are followDistance deep are synced, the rest are streamed once confirmed
+	var confirmedBlock uint64
+	if currentBlock > ec.followDistance {
+		confirmedBlock = currentBlock - ec.followDistance
+	}
+	// the logs of the last blocks are kept in order to detect their removal by a reorg
+	var windowStart uint64
+	if confirmedBlock > dispatchedLogsWindow {
+		windowStart = confirmedBlock - dispatchedLogsWindow
+	}
+	progress := newSyncProgress(fromBlock.Uint64(), confirmedBlock)
+	var nEvents, nSuccess int
+	mismatches := 0
+	for from := fromBlock.Uint64(); from <= confirmedBlock; {
+		to := ec.batchEnd(from, confirmedBlock)
+		_logs, _nSuccess, err := ec.fetchAndProcessEvents(new(big.Int).SetUint64(from), new(big.Int).SetUint64(to), contractAbi)
 		if err != nil {
-			// in case request exceeded limit, try again with less blocks
-			// will stop after log(blocksInBatch) tries
-			if !strings.Contains(err.Error(), "websocket: read limit exceeded") {
-				return errors.Wrap(err, "failed to get events")
+			// the logs of another endpoint don't match, try again in case one of them was not up-to-date
+			if errors.Is(err, errCrossCheckMismatch) && mismatches < maxCrossCheckRetries {
+				mismatches++
+				continue
 			}
-			currentBatchSize := int64(blocksInBatch)
-		retryLoop:
-			for currentBatchSize > 1 {
-				currentBatchSize /= 2
-				ec.logger.Debug("using a lower batch size", zap.Int64("currentBatchSize", currentBatchSize))
-				toBlock = big.NewInt(int64(fromBlock.Uint64()) + currentBatchSize)
-				_logs, _nSuccess, err = ec.fetchAndProcessEvents(fromBlock, toBlock, contractAbi)
-				if err != nil {
-					if !strings.Contains(err.Error(), "websocket: read limit exceeded") {
-						return errors.Wrap(err, "failed to get events")
-					}
-					// limit exceeded
-					continue retryLoop
-				}
-				// done
-				break retryLoop
+			// in case request exceeded limit, try again with less blocks
+			if ec.batch.onError(err) {
+				ec.logger.Debug("using a lower batch size", zap.Uint64("currentBatchSize", ec.batch.size), zap.Error(err))
+				continue
 			}
+			return errors.Wrap(err, "failed to get events")
 		}
+		ec.batch.onSuccess(_logs)
+		nEvents += len(_logs)
 		nSuccess += _nSuccess
-		logs = append(logs, _logs...)
-		if toBlock == nil { // finished
-			break
-		}
-		fromBlock = toBlock
+		ec.logs.markDispatched(logsInRange(_logs, windowStart, confirmedBlock))
+		// publishing SyncBatchEvent so the sync offset is upgraded once the events of the batch are handled
+		ec.fireEvent(types.Log{}, "SyncBatchEvent", eth1.SyncBatchEvent{FromBlock: from, ToBlock: to, Success: _nSuccess == len(_logs)})
+		progress.update(to, len(_logs))
+		from = to + 1
 	}
-	ec.logger.Debug("finished syncing registry contract",
-		zap.Int("total events", len(logs)), zap.Int("total success", nSuccess))
+	ec.logs.setConfirmed(confirmedBlock)
+	ec.synced = true
+	ec.logger.Debug("finished syncing registry contract", zap.Uint64("confirmedBlock", confirmedBlock),
+		zap.Int("total events", nEvents), zap.Int("total success", nSuccess))
 	// publishing SyncEndedEvent so other components could track the sync
-	ec.fireEvent(types.Log{}, "SyncEndedEvent", eth1.SyncEndedEvent{Logs: logs, Success: nSuccess == len(logs)})
+	ec.fireEvent(types.Log{}, "SyncEndedEvent", eth1.SyncEndedEvent{Events: nEvents, Success: nSuccess == nEvents})
 
 	return nil
 }
 
 func (ec *eth1Client) fetchAndProcessEvents(fromBlock, toBlock *big.Int, contractAbi abi.ABI) ([]types.Log, int, error) {
-	logger := ec.logger.With(zap.Int64("fromBlock", fromBlock.Int64()))
-	contractAddress := common.HexToAddress(ec.registryContractAddr)
-	query := ethereum.FilterQuery{
-		Addresses: []common.Address{contractAddress},
-		FromBlock: fromBlock,
-	}
-	if toBlock != nil {
-		query.ToBlock = toBlock
-		logger = logger.With(zap.Int64("toBlock", toBlock.Int64()))
-	}
+	logger := ec.logger.With(zap.Int64("fromBlock", fromBlock.Int64()), zap.Int64("toBlock", toBlock.Int64()))
 	logger.Debug("fetching event logs")
-	logs, err := ec.conn.FilterLogs(ec.ctx, query)
+	logs, err := ec.getLogs(fromBlock.Uint64(), toBlock.Uint64())
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to get event logs")
 	}
+	if err := ec.crossCheckLogs(fromBlock.Uint64(), toBlock.Uint64(), logs); err != nil {
+		return nil, 0, err
+	}
 	nSuccess := len(logs)
 	logger = logger.With(zap.Int("results", len(logs)))
 	logger.Debug("got event logs")
@@ -344,6 +636,16 @@ func (ec *eth1Client) fetchAndProcessEvents(fromBlock, toBlock *big.Int, contrac
 }
 
 func (ec *eth1Client) handleEvent(vLog types.Log, contractAbi abi.ABI) (string, error) {
+	eventName, data, err := ec.parseEvent(vLog, contractAbi)
+	if err != nil || data == nil {
+		return eventName, err
+	}
+	ec.fireEvent(vLog, eventName, data)
+	return eventName, nil
+}
+
+// parseEvent parses the given log, the data is nil if the event is unknown or unsupported
+func (ec *eth1Client) parseEvent(vLog types.Log, contractAbi abi.ABI) (string, interface{}, error) {
 	ev, err := contractAbi.EventByID(vLog.Topics[0])
 	if err != nil { // unknown event -> ignored
 		ec.logger.Debug("could not read event by ID",
@@ -352,7 +654,7 @@ func (ec *eth1Client) handleEvent(vLog types.Log, contractAbi abi.ABI) (string,
 			zap.String("txHash", vLog.TxHash.Hex()),
 			zap.Error(err),
 		)
-		return "", nil
+		return "", nil, nil
 	}
 
 	abiParser := eth1.NewParser(ec.logger, ec.abiVersion)
@@ -362,44 +664,63 @@ func (ec *eth1Client) handleEvent(vLog types.Log, contractAbi abi.ABI) (string,
 		parsed, err := abiParser.ParseOperatorRegistrationEvent(vLog, contractAbi)
 		reportSyncEvent(ev.Name, err)
 		if err != nil {
-			return ev.Name, err
+			return ev.Name, nil, err
 		}
-		ec.fireEvent(vLog, ev.Name, *parsed)
+		return ev.Name, *parsed, nil
 	case abiparser.OperatorRemoval:
 		parsed, err := abiParser.ParseOperatorRemovalEvent(vLog, contractAbi)
 		reportSyncEvent(ev.Name, err)
 		if err != nil {
-			return ev.Name, err
+			return ev.Name, nil, err
 		}
-		ec.fireEvent(vLog, ev.Name, *parsed)
+		return ev.Name, *parsed, nil
 	case abiparser.ValidatorRegistration:
 		parsed, err := abiParser.ParseValidatorRegistrationEvent(vLog, contractAbi)
 		reportSyncEvent(ev.Name, err)
 		if err != nil {
-			return ev.Name, err
+			return ev.Name, nil, err
 		}
-		ec.fireEvent(vLog, ev.Name, *parsed)
+		return ev.Name, *parsed, nil
 	case abiparser.ValidatorRemoval:
 		parsed, err := abiParser.ParseValidatorRemovalEvent(vLog, contractAbi)
 		reportSyncEvent(ev.Name, err)
 		if err != nil {
-			return ev.Name, err
+			return ev.Name, nil, err
 		}
-		ec.fireEvent(vLog, ev.Name, *parsed)
+		return ev.Name, *parsed, nil
 	case abiparser.AccountLiquidation:
 		parsed, err := abiParser.ParseAccountLiquidationEvent(vLog)
 		reportSyncEvent(ev.Name, err)
 		if err != nil {
-			return ev.Name, err
+			return ev.Name, nil, err
 		}
-		ec.fireEvent(vLog, ev.Name, *parsed)
+		return ev.Name, *parsed, nil
 	case abiparser.AccountEnable:
 		parsed, err := abiParser.ParseAccountEnableEvent(vLog)
 		reportSyncEvent(ev.Name, err)
 		if err != nil {
-			return ev.Name, err
+			return ev.Name, nil, err
+		}
+		return ev.Name, *parsed, nil
+	case abiparser.FeeRecipientAddressUpdated:
+		parsed, err := abiParser.ParseFeeRecipientAddressUpdatedEvent(vLog, contractAbi)
+		reportSyncEvent(ev.Name, err)
+		if err != nil {
+			return ev.Name, nil, err
 		}
-		ec.fireEvent(vLog, ev.Name, *parsed)
+		return ev.Name, *parsed, nil
+	case abiparser.ValidatorExited:
+		parsed, err := abiParser.ParseValidatorExitedEvent(vLog, contractAbi)
+		reportSyncEvent(ev.Name, err)
+		if err != nil {
+			return ev.Name, nil, err
+		}
+		header, err := ec.headerByNumber(vLog.BlockNumber)
+		if err != nil {
+			return ev.Name, nil, errors.Wrapf(err, "could not get block %d of %s event", vLog.BlockNumber, ev.Name)
+		}
+		parsed.BlockTime = header.Time
+		return ev.Name, *parsed, nil
 
 	default:
 		ec.logger.Debug("unsupported contract event was received, skipping",
@@ -408,5 +729,40 @@ func (ec *eth1Client) handleEvent(vLog types.Log, contractAbi abi.ABI) (string,
 			zap.String("txHash", vLog.TxHash.Hex()),
 		)
 	}
-	return ev.Name, nil
+	return ev.Name, nil, nil
+}
+
+// FetchEvents returns the parsed contract events of the given blocks range, without dispatching them.
+// malformed events are skipped, as they are when syncing
+func (ec *eth1Client) FetchEvents(fromBlock, toBlock uint64) ([]*eth1.Event, error) {
+	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse ABI interface")
+	}
+	logs, err := ec.filterLogs(fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	var res []*eth1.Event
+	for _, vLog := range logs {
+		eventName, data, err := ec.parseEvent(vLog, contractAbi)
+		if err != nil {
+			var malformedEventErr *abiparser.MalformedEventError
+			if !errors.As(err, &malformedEventErr) {
+				return nil, errors.Wrapf(err, "could not parse %s event in block %d", eventName, vLog.BlockNumber)
+			}
+			ec.logger.Warn("skipping malformed event",
+				zap.String("event", eventName),
+				zap.Uint64("block", vLog.BlockNumber),
+				zap.String("txHash", vLog.TxHash.Hex()),
+				zap.Error(err),
+			)
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		res = append(res, &eth1.Event{Log: vLog, Name: eventName, Data: data})
+	}
+	return res, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
 
 	"github.com/bloxapp/ssv/eth1"
 	"github.com/bloxapp/ssv/eth1/abiparser"
@@ -84,10 +85,37 @@ func TestEth1Client_handleEvent(t *testing.T) {
 	}
 }
 
+func TestEth1Client_FetchEvents(t *testing.T) {
+	var validatorRegistration types.Log
+	require.NoError(t, json.Unmarshal([]byte(rawValidatorRegistration), &validatorRegistration))
+	validatorRegistration.BlockNumber = 7
+	chain := &fakeChain{head: 20, maxBlocks: 4}
+	chain.logs = []types.Log{registrationLog(t, 5, 1), validatorRegistration, registrationLog(t, 15, 2)}
+	ec, events := newPollingClient(t, 0, false, chain)
+
+	// the events of the range are returned in batches and are not dispatched
+	res, err := ec.FetchEvents(0, 10)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, abiparser.OperatorRegistration, res[0].Name)
+	require.Equal(t, abiparser.ValidatorRegistration, res[1].Name)
+	require.Empty(t, receivedTxs(events))
+
+	// the events are loaded back from the local events format
+	data, err := yaml.Marshal(res)
+	require.NoError(t, err)
+	var parsed []*eth1.Event
+	require.NoError(t, yaml.Unmarshal(data, &parsed))
+	require.Len(t, parsed, len(res))
+	for i := range res {
+		require.Equal(t, res[i].Name, parsed[i].Name)
+		require.Equal(t, res[i].Data, parsed[i].Data)
+	}
+}
+
 func newEth1Client(abiVersion eth1.Version) *eth1Client {
 	ec := eth1Client{
 		ctx:        context.TODO(),
-		conn:       nil,
 		logger:     zap.L(),
 		eventsFeed: new(event.Feed),
 		abiVersion: abiVersion,
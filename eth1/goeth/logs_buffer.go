@@ -0,0 +1,175 @@
+package goeth
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// dispatchedLogsWindow is the number of blocks below the confirmed block whose dispatched logs are kept,
+// in order to detect reorgs that are deeper than the follow distance
+const dispatchedLogsWindow uint64 = 1024
+
+// logKey identifies a log in a specific block, a log that is included again after a reorg has another block hash
+type logKey struct {
+	blockHash common.Hash
+	txHash    common.Hash
+	index     uint
+}
+
+func newLogKey(log types.Log) logKey {
+	return logKey{blockHash: log.BlockHash, txHash: log.TxHash, index: log.Index}
+}
+
+// logsBuffer holds the streamed logs until they are followDistance blocks deep,
+// logs that are removed by a reorg before that are dropped and never dispatched.
+// it is not thread safe, it is used by the goroutine that listens to the subscriptions
+type logsBuffer struct {
+	followDistance uint64
+	// head is the highest known block
+	head uint64
+	// confirmed is the highest block whose logs were dispatched
+	confirmed  uint64
+	pending    map[logKey]types.Log
+	dispatched map[logKey]types.Log
+}
+
+func newLogsBuffer(followDistance uint64) *logsBuffer {
+	return &logsBuffer{
+		followDistance: followDistance,
+		pending:        make(map[logKey]types.Log),
+		dispatched:     make(map[logKey]types.Log),
+	}
+}
+
+// setConfirmed sets the highest block whose logs were dispatched, e.g. by the history sync
+func (b *logsBuffer) setConfirmed(block uint64) {
+	b.confirmed = block
+	b.setHead(block)
+}
+
+// markDispatched records the logs that were dispatched by the history sync, in order to detect their removal
+func (b *logsBuffer) markDispatched(logs []types.Log) {
+	for _, log := range logs {
+		if log.BlockNumber+dispatchedLogsWindow >= b.confirmed {
+			b.dispatched[newLogKey(log)] = log
+		}
+	}
+}
+
+// reset replaces the pending logs with the given logs of the blocks above the confirmed block
+func (b *logsBuffer) reset(logs []types.Log) {
+	b.pending = make(map[logKey]types.Log)
+	for _, log := range logs {
+		b.add(log)
+	}
+}
+
+// add adds a streamed log, it returns true if the log is removed by a reorg after it was dispatched
+func (b *logsBuffer) add(log types.Log) (reorged bool) {
+	key := newLogKey(log)
+	if log.Removed {
+		delete(b.pending, key)
+		if _, ok := b.dispatched[key]; ok {
+			delete(b.dispatched, key)
+			return true
+		}
+		return false
+	}
+	if log.BlockNumber <= b.confirmed {
+		// already dispatched, or arrived too late
+		return false
+	}
+	b.pending[key] = log
+	b.setHead(log.BlockNumber)
+	return false
+}
+
+// setHead updates the highest known block
+func (b *logsBuffer) setHead(head uint64) {
+	if head > b.head {
+		b.head = head
+	}
+}
+
+// confirmedRange returns the range of the blocks that became followDistance blocks deep, ok is false if there are none
+func (b *logsBuffer) confirmedRange() (from, to uint64, ok bool) {
+	if b.head < b.followDistance {
+		return 0, 0, false
+	}
+	confirmed := b.head - b.followDistance
+	if confirmed <= b.confirmed {
+		return 0, 0, false
+	}
+	return b.confirmed + 1, confirmed, true
+}
+
+// pendingLogs returns the logs that were not dispatched yet
+func (b *logsBuffer) pendingLogs() []types.Log {
+	res := make([]types.Log, 0, len(b.pending))
+	for _, log := range b.pending {
+		res = append(res, log)
+	}
+	return res
+}
+
+// confirm returns the pending logs that are followDistance blocks deep, ordered by block and index,
+// and marks them as dispatched
+func (b *logsBuffer) confirm() []types.Log {
+	_, confirmed, ok := b.confirmedRange()
+	if !ok {
+		return nil
+	}
+	var res []types.Log
+	for key, log := range b.pending {
+		if log.BlockNumber <= confirmed {
+			res = append(res, log)
+			b.dispatched[key] = log
+			delete(b.pending, key)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].BlockNumber != res[j].BlockNumber {
+			return res[i].BlockNumber < res[j].BlockNumber
+		}
+		return res[i].Index < res[j].Index
+	})
+	b.confirmed = confirmed
+	for key, log := range b.dispatched {
+		if log.BlockNumber+dispatchedLogsWindow < confirmed {
+			delete(b.dispatched, key)
+		}
+	}
+	return res
+}
+
+// lowestDispatched returns the lowest block of the dispatched logs that are kept
+func (b *logsBuffer) lowestDispatched() (uint64, bool) {
+	var lowest uint64
+	found := false
+	for _, log := range b.dispatched {
+		if !found || log.BlockNumber < lowest {
+			lowest = log.BlockNumber
+			found = true
+		}
+	}
+	return lowest, found
+}
+
+// removedDispatched returns the dispatched logs that are missing from the given logs, which were fetched again
+// for the blocks of the dispatched logs, and forgets them
+func (b *logsBuffer) removedDispatched(logs []types.Log) []types.Log {
+	current := make(map[logKey]bool, len(logs))
+	for _, log := range logs {
+		current[newLogKey(log)] = true
+	}
+	var res []types.Log
+	for key, log := range b.dispatched {
+		if !current[key] {
+			res = append(res, log)
+			delete(b.dispatched, key)
+		}
+	}
+	return res
+}
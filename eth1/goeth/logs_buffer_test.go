@@ -0,0 +1,121 @@
+package goeth
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLog(block uint64, index uint, fork byte) types.Log {
+	return types.Log{
+		BlockNumber: block,
+		BlockHash:   common.BytesToHash([]byte{byte(block), fork}),
+		TxHash:      common.BytesToHash([]byte{byte(block), byte(index)}),
+		Index:       index,
+	}
+}
+
+func blocksOf(logs []types.Log) []uint64 {
+	var res []uint64
+	for _, log := range logs {
+		res = append(res, log.BlockNumber)
+	}
+	return res
+}
+
+func TestLogsBuffer(t *testing.T) {
+	t.Run("dispatch once deep enough", func(t *testing.T) {
+		b := newLogsBuffer(3)
+		b.setConfirmed(10)
+
+		require.False(t, b.add(newTestLog(12, 1, 0)))
+		require.False(t, b.add(newTestLog(11, 0, 0)))
+		require.False(t, b.add(newTestLog(12, 0, 0)))
+		require.Empty(t, b.confirm())
+
+		b.setHead(14)
+		logs := b.confirm()
+		require.Equal(t, []uint64{11}, blocksOf(logs))
+
+		b.setHead(15)
+		logs = b.confirm()
+		require.Equal(t, []uint64{12, 12}, blocksOf(logs))
+		require.Equal(t, uint(0), logs[0].Index)
+		require.Equal(t, uint(1), logs[1].Index)
+		require.Empty(t, b.confirm())
+	})
+
+	t.Run("removed before dispatch", func(t *testing.T) {
+		b := newLogsBuffer(3)
+		b.setConfirmed(10)
+
+		log := newTestLog(12, 0, 0)
+		require.False(t, b.add(log))
+		log.Removed = true
+		require.False(t, b.add(log))
+		// the log is included again in another block
+		require.False(t, b.add(newTestLog(13, 0, 1)))
+
+		b.setHead(20)
+		require.Equal(t, []uint64{13}, blocksOf(b.confirm()))
+	})
+
+	t.Run("removed after dispatch", func(t *testing.T) {
+		b := newLogsBuffer(1)
+		b.setConfirmed(10)
+
+		log := newTestLog(12, 0, 0)
+		require.False(t, b.add(log))
+		b.setHead(13)
+		require.Len(t, b.confirm(), 1)
+
+		log.Removed = true
+		require.True(t, b.add(log))
+		// reported once
+		require.False(t, b.add(log))
+	})
+
+	t.Run("removed after history sync", func(t *testing.T) {
+		b := newLogsBuffer(1)
+		log := newTestLog(9, 0, 0)
+		b.setConfirmed(10)
+		b.markDispatched([]types.Log{log})
+
+		log.Removed = true
+		require.True(t, b.add(log))
+	})
+
+	t.Run("no follow distance", func(t *testing.T) {
+		b := newLogsBuffer(0)
+		b.setConfirmed(10)
+
+		require.False(t, b.add(newTestLog(11, 0, 0)))
+		require.Equal(t, []uint64{11}, blocksOf(b.confirm()))
+		// late logs of dispatched blocks are ignored
+		require.False(t, b.add(newTestLog(11, 1, 0)))
+		require.Empty(t, b.confirm())
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		b := newLogsBuffer(2)
+		b.setConfirmed(10)
+		require.False(t, b.add(newTestLog(11, 0, 0)))
+
+		// the backfilled logs replace the pending logs, which might have been reorged while disconnected
+		b.reset([]types.Log{newTestLog(12, 0, 1)})
+		b.setHead(20)
+		require.Equal(t, []uint64{12}, blocksOf(b.confirm()))
+	})
+
+	t.Run("dispatched window", func(t *testing.T) {
+		b := newLogsBuffer(0)
+		require.False(t, b.add(newTestLog(1, 0, 0)))
+		require.Len(t, b.confirm(), 1)
+
+		b.setHead(dispatchedLogsWindow + 10)
+		b.confirm()
+		require.Empty(t, b.dispatched)
+	})
+}
@@ -1,9 +1,12 @@
 package goeth
 
 import (
+	"log"
+	"strconv"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"log"
 )
 
 type eth1NodeStatus int32
@@ -17,6 +20,34 @@ var (
 		Name: "ssv:eth1:sync:count:failed",
 		Help: "Count failed eth1 sync events",
 	}, []string{"etype"})
+	metricReorgedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:eth1:reorged_events",
+		Help: "Count applied eth1 events that were removed by a reorg",
+	}, []string{"etype"})
+	metricCrossCheckMismatches = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:eth1:cross_check_mismatches",
+		Help: "Count eth1 logs ranges that don't match between two execution clients",
+	})
+	metricActiveEndpoint = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:eth1:active_endpoint",
+		Help: "Index of the active execution client",
+	})
+	metricEndpointHead = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ssv:eth1:endpoint_head",
+		Help: "Head block of the execution clients by their index",
+	}, []string{"endpoint"})
+	metricSyncCurrentBlock = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:eth1:sync:current_block",
+		Help: "The last block of the history sync that was synced",
+	})
+	metricSyncTargetBlock = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:eth1:sync:target_block",
+		Help: "The block that the history sync syncs up to",
+	})
+	metricSyncEventsPerSecond = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:eth1:sync:events_per_second",
+		Help: "The rate of the events of the history sync",
+	})
 	metricsEth1NodeStatus = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "ssv:eth1:node_status",
 		Help: "Status of the connected eth1 node",
@@ -36,6 +67,27 @@ func init() {
 	if err := prometheus.Register(metricSyncEventsCountSuccess); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricReorgedEvents); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricCrossCheckMismatches); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricActiveEndpoint); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricEndpointHead); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricSyncCurrentBlock); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricSyncTargetBlock); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricSyncEventsPerSecond); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 func reportSyncEvent(eventType string, err error) {
@@ -49,3 +101,33 @@ func reportSyncEvent(eventType string, err error) {
 func reportNodeStatus(status eth1NodeStatus) {
 	metricsEth1NodeStatus.Set(float64(status))
 }
+
+func reportActiveEndpoint(index int) {
+	metricActiveEndpoint.Set(float64(index))
+}
+
+func reportEndpointHead(index int, head uint64) {
+	metricEndpointHead.WithLabelValues(strconv.Itoa(index)).Set(float64(head))
+}
+
+// syncProgress reports the progress of the history sync
+type syncProgress struct {
+	start  time.Time
+	events int
+}
+
+func newSyncProgress(fromBlock, targetBlock uint64) *syncProgress {
+	metricSyncCurrentBlock.Set(float64(fromBlock))
+	metricSyncTargetBlock.Set(float64(targetBlock))
+	metricSyncEventsPerSecond.Set(0)
+	return &syncProgress{start: time.Now()}
+}
+
+// update reports a batch of blocks that was synced up to the given block
+func (p *syncProgress) update(block uint64, events int) {
+	p.events += events
+	metricSyncCurrentBlock.Set(float64(block))
+	if elapsed := time.Since(p.start).Seconds(); elapsed > 0 {
+		metricSyncEventsPerSecond.Set(float64(p.events) / elapsed)
+	}
+}
@@ -0,0 +1,239 @@
+package goeth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+)
+
+// fakeChain is a JSON-RPC server that serves eth_blockNumber, eth_getLogs and eth_getBlockByNumber over HTTP
+type fakeChain struct {
+	lock sync.Mutex
+	head uint64
+	// fork changes the hashes of the blocks, as after a reorg
+	fork byte
+	logs []types.Log
+	// maxBlocks fails queries of more blocks, 0 is unlimited
+	maxBlocks uint64
+	srv       *httptest.Server
+}
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+func (c *fakeChain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, err := c.handle(req)
+	res := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if err != nil {
+		res["error"] = map[string]interface{}{"code": -32005, "message": err.Error()}
+	} else {
+		res["result"] = result
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func (c *fakeChain) handle(req rpcRequest) (interface{}, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	switch req.Method {
+	case "eth_blockNumber":
+		return hexutil.Uint64(c.head), nil
+	case "eth_getBlockByNumber":
+		var number hexutil.Uint64
+		if err := json.Unmarshal(req.Params[0], &number); err != nil {
+			return nil, err
+		}
+		return &types.Header{Number: new(big.Int).SetUint64(uint64(number)), Difficulty: big.NewInt(0), Extra: []byte{c.fork}}, nil
+	case "eth_getLogs":
+		var query struct {
+			FromBlock hexutil.Uint64 `json:"fromBlock"`
+			ToBlock   hexutil.Uint64 `json:"toBlock"`
+		}
+		if err := json.Unmarshal(req.Params[0], &query); err != nil {
+			return nil, err
+		}
+		if c.maxBlocks > 0 && uint64(query.ToBlock-query.FromBlock)+1 > c.maxBlocks {
+			return nil, fmt.Errorf("query returned more than 10000 results")
+		}
+		res := []types.Log{}
+		for _, log := range c.logs {
+			if log.BlockNumber >= uint64(query.FromBlock) && log.BlockNumber <= uint64(query.ToBlock) {
+				res = append(res, log)
+			}
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("unsupported method %s", req.Method)
+}
+
+func (c *fakeChain) set(fn func(c *fakeChain)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	fn(c)
+}
+
+// registrationLog returns an operator registration log in the given block
+func registrationLog(t *testing.T, block uint64, tx byte) types.Log {
+	var log types.Log
+	require.NoError(t, json.Unmarshal([]byte(rawOperatorRegistration), &log))
+	log.BlockNumber = block
+	log.BlockHash = common.BytesToHash([]byte{byte(block)})
+	log.TxHash = common.BytesToHash([]byte{tx})
+	return log
+}
+
+func newPollingClient(t *testing.T, followDistance uint64, crossCheck bool, chains ...*fakeChain) (*eth1Client, chan *eth1.Event) {
+	var addrs []string
+	for _, chain := range chains {
+		chain.srv = httptest.NewServer(chain)
+		t.Cleanup(chain.srv.Close)
+		addrs = append(addrs, chain.srv.URL)
+	}
+
+	client, err := NewEth1Client(ClientOptions{
+		Ctx:               context.Background(),
+		Logger:            zap.L(),
+		NodeAddrs:         addrs,
+		CrossCheck:        crossCheck,
+		ContractABI:       eth1.ContractABI(eth1.V2),
+		ConnectionTimeout: defaultTestTimeout,
+		FollowDistance:    followDistance,
+		PollingInterval:   defaultTestTimeout,
+		AbiVersion:        eth1.V2,
+	})
+	require.NoError(t, err)
+	ec := client.(*eth1Client)
+	require.True(t, ec.isPolling())
+
+	events := make(chan *eth1.Event, 32)
+	sub := ec.EventsFeed().Subscribe(events)
+	t.Cleanup(sub.Unsubscribe)
+	return ec, events
+}
+
+// receivedTxs returns the tx hashes of the received registration events
+func receivedTxs(events chan *eth1.Event) []byte {
+	var res []byte
+	for {
+		select {
+		case e := <-events:
+			if _, ok := e.Data.(abiparser.OperatorRegistrationEvent); ok {
+				res = append(res, e.Log.TxHash[31])
+			}
+		default:
+			return res
+		}
+	}
+}
+
+func TestEth1Client_Polling(t *testing.T) {
+	chain := &fakeChain{head: 10}
+	chain.logs = []types.Log{registrationLog(t, 5, 1), registrationLog(t, 9, 2)}
+	ec, events := newPollingClient(t, 2, false, chain)
+	contractAbi, err := abi.JSON(strings.NewReader(ec.contractABI))
+	require.NoError(t, err)
+
+	// the history is synced up to the confirmed block
+	require.NoError(t, ec.Sync(big.NewInt(0)))
+	require.Equal(t, []byte{1}, receivedTxs(events))
+	require.Equal(t, uint64(8), ec.logs.confirmed)
+
+	// block 9 is confirmed once the head is 11
+	require.NoError(t, ec.poll(contractAbi))
+	require.Empty(t, receivedTxs(events))
+	chain.set(func(c *fakeChain) {
+		c.head = 11
+		c.logs = append(c.logs, registrationLog(t, 11, 3))
+	})
+	require.NoError(t, ec.poll(contractAbi))
+	require.Equal(t, []byte{2}, receivedTxs(events))
+
+	// block 11 is reorged before it is confirmed, its log is never dispatched
+	chain.set(func(c *fakeChain) {
+		c.head = 14
+		c.logs = c.logs[:2]
+	})
+	require.NoError(t, ec.poll(contractAbi))
+	require.Empty(t, receivedTxs(events))
+	require.Equal(t, uint64(12), ec.logs.confirmed)
+
+	// a reorg deeper than the follow distance removes the dispatched log of block 9
+	reorged := testutil.ToFloat64(metricReorgedEvents.WithLabelValues(abiparser.OperatorRegistration))
+	chain.set(func(c *fakeChain) {
+		c.head = 15
+		c.fork = 1
+		c.logs = c.logs[:1]
+	})
+	require.NoError(t, ec.poll(contractAbi))
+	require.Equal(t, reorged+1, testutil.ToFloat64(metricReorgedEvents.WithLabelValues(abiparser.OperatorRegistration)))
+	require.Empty(t, receivedTxs(events))
+}
+
+func TestEth1Client_PollingBatchSize(t *testing.T) {
+	chain := &fakeChain{head: 100, maxBlocks: 10}
+	chain.logs = []types.Log{registrationLog(t, 5, 1), registrationLog(t, 50, 2), registrationLog(t, 90, 3)}
+	ec, events := newPollingClient(t, 0, false, chain)
+
+	// the batch shrinks until the provider accepts the queries
+	require.NoError(t, ec.Sync(big.NewInt(0)))
+	require.Equal(t, []byte{1, 2, 3}, receivedTxs(events))
+	require.Less(t, ec.batch.size, blocksInBatch)
+}
+
+func TestEth1Client_SyncBatches(t *testing.T) {
+	chain := &fakeChain{head: 100, maxBlocks: 10}
+	chain.logs = []types.Log{registrationLog(t, 5, 1), registrationLog(t, 50, 2)}
+	ec, events := newPollingClient(t, 4, false, chain)
+
+	require.NoError(t, ec.Sync(big.NewInt(0)))
+
+	// every batch is followed by a SyncBatchEvent, the batches cover the blocks up to the confirmed block
+	next := uint64(0)
+	registrations := 0
+	for done := false; !done; {
+		e := <-events
+		switch data := e.Data.(type) {
+		case abiparser.OperatorRegistrationEvent:
+			require.GreaterOrEqual(t, e.Log.BlockNumber, next)
+			registrations++
+		case eth1.SyncBatchEvent:
+			require.Equal(t, next, data.FromBlock)
+			require.True(t, data.Success)
+			next = data.ToBlock + 1
+		case eth1.SyncEndedEvent:
+			require.Equal(t, 2, data.Events)
+			require.True(t, data.Success)
+			done = true
+		}
+	}
+	require.Equal(t, 2, registrations)
+	require.Equal(t, uint64(97), next)
+	require.Equal(t, float64(96), testutil.ToFloat64(metricSyncCurrentBlock))
+	require.Equal(t, float64(96), testutil.ToFloat64(metricSyncTargetBlock))
+}
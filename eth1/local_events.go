@@ -2,6 +2,7 @@ package eth1
 
 import (
 	"encoding/hex"
+	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -22,6 +23,7 @@ type operatorRegistrationEventYAML struct {
 	Name         string `yaml:"Name"`
 	OwnerAddress string `yaml:"OwnerAddress"`
 	PublicKey    string `yaml:"PublicKey"`
+	Fee          string `yaml:"Fee,omitempty"`
 }
 
 type operatorRemovalEventYAML struct {
@@ -50,12 +52,33 @@ type accountEnableEventYAML struct {
 	OwnerAddress string `yaml:"OwnerAddress"`
 }
 
+type feeRecipientAddressUpdatedEventYAML struct {
+	OwnerAddress     string `yaml:"OwnerAddress"`
+	RecipientAddress string `yaml:"RecipientAddress"`
+}
+
+type validatorExitedEventYAML struct {
+	OwnerAddress string `yaml:"OwnerAddress"`
+	PublicKey    string `yaml:"PublicKey"`
+	// BlockTime is the unix time of the exit, all the operators must use the same time to sign the same exit
+	BlockTime uint64 `yaml:"BlockTime"`
+}
+
 func (e *operatorRegistrationEventYAML) toEventData() (interface{}, error) {
+	var fee *big.Int
+	if len(e.Fee) > 0 {
+		var ok bool
+		fee, ok = new(big.Int).SetString(e.Fee, 10)
+		if !ok {
+			return nil, errors.Errorf("invalid fee: %s", e.Fee)
+		}
+	}
 	return abiparser.OperatorRegistrationEvent{
 		Id:           e.Id,
 		Name:         e.Name,
 		OwnerAddress: common.HexToAddress(e.OwnerAddress),
 		PublicKey:    []byte(e.PublicKey),
+		Fee:          fee,
 	}, nil
 }
 
@@ -89,9 +112,13 @@ func (e *validatorRegistrationEventYAML) toEventData() (interface{}, error) {
 }
 
 func (e *validatorRemovalEventYAML) toEventData() (interface{}, error) {
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(e.PublicKey, "0x"))
+	if err != nil {
+		return nil, err
+	}
 	return abiparser.ValidatorRemovalEvent{
 		OwnerAddress: common.HexToAddress(e.OwnerAddress),
-		PublicKey:    []byte(strings.TrimPrefix(e.PublicKey, "0x")),
+		PublicKey:    pubKey,
 	}, nil
 }
 
@@ -107,6 +134,25 @@ func (e *accountEnableEventYAML) toEventData() (interface{}, error) {
 	}, nil
 }
 
+func (e *feeRecipientAddressUpdatedEventYAML) toEventData() (interface{}, error) {
+	return abiparser.FeeRecipientAddressUpdatedEvent{
+		OwnerAddress:     common.HexToAddress(e.OwnerAddress),
+		RecipientAddress: common.HexToAddress(e.RecipientAddress),
+	}, nil
+}
+
+func (e *validatorExitedEventYAML) toEventData() (interface{}, error) {
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(e.PublicKey, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	return abiparser.ValidatorExitedEvent{
+		OwnerAddress: common.HexToAddress(e.OwnerAddress),
+		PublicKey:    pubKey,
+		BlockTime:    e.BlockTime,
+	}, nil
+}
+
 type eventDataUnmarshaler struct {
 	name string
 	data eventData
@@ -139,6 +185,14 @@ func (u *eventDataUnmarshaler) UnmarshalYAML(value *yaml.Node) error {
 		var v accountEnableEventYAML
 		err = value.Decode(&v)
 		u.data = &v
+	case "FeeRecipientAddressUpdated":
+		var v feeRecipientAddressUpdatedEventYAML
+		err = value.Decode(&v)
+		u.data = &v
+	case "ValidatorExited":
+		var v validatorExitedEventYAML
+		err = value.Decode(&v)
+		u.data = &v
 	default:
 		return errors.New("event unknown")
 	}
@@ -193,3 +247,81 @@ func toByteArr(orig []string, decodeHex bool) ([][]byte, error) {
 	}
 	return res, nil
 }
+
+// MarshalYAML encodes the event in the local events format, so it can be loaded back with UnmarshalYAML
+func (e *Event) MarshalYAML() (interface{}, error) {
+	var data eventData
+	switch ev := e.Data.(type) {
+	case abiparser.OperatorRegistrationEvent:
+		d := &operatorRegistrationEventYAML{
+			Id:           ev.Id,
+			Name:         ev.Name,
+			OwnerAddress: ev.OwnerAddress.Hex(),
+			PublicKey:    string(ev.PublicKey),
+		}
+		if ev.Fee != nil {
+			d.Fee = ev.Fee.String()
+		}
+		data = d
+	case abiparser.OperatorRemovalEvent:
+		data = &operatorRemovalEventYAML{
+			OperatorId:   ev.OperatorId,
+			OwnerAddress: ev.OwnerAddress.Hex(),
+		}
+	case abiparser.ValidatorRegistrationEvent:
+		data = &validatorRegistrationEventYAML{
+			PublicKey:        "0x" + hex.EncodeToString(ev.PublicKey),
+			OwnerAddress:     ev.OwnerAddress.Hex(),
+			OperatorIds:      ev.OperatorIds,
+			SharesPublicKeys: fromByteArr(ev.SharesPublicKeys, true),
+			EncryptedKeys:    fromByteArr(ev.EncryptedKeys, false),
+		}
+	case abiparser.ValidatorRemovalEvent:
+		data = &validatorRemovalEventYAML{
+			OwnerAddress: ev.OwnerAddress.Hex(),
+			PublicKey:    "0x" + hex.EncodeToString(ev.PublicKey),
+		}
+	case abiparser.AccountLiquidationEvent:
+		data = &accountLiquidationEventYAML{
+			OwnerAddress: ev.OwnerAddress.Hex(),
+		}
+	case abiparser.AccountEnableEvent:
+		data = &accountEnableEventYAML{
+			OwnerAddress: ev.OwnerAddress.Hex(),
+		}
+	case abiparser.FeeRecipientAddressUpdatedEvent:
+		data = &feeRecipientAddressUpdatedEventYAML{
+			OwnerAddress:     ev.OwnerAddress.Hex(),
+			RecipientAddress: ev.RecipientAddress.Hex(),
+		}
+	case abiparser.ValidatorExitedEvent:
+		data = &validatorExitedEventYAML{
+			OwnerAddress: ev.OwnerAddress.Hex(),
+			PublicKey:    "0x" + hex.EncodeToString(ev.PublicKey),
+			BlockTime:    ev.BlockTime,
+		}
+	default:
+		return nil, errors.Errorf("event unknown: %s", e.Name)
+	}
+
+	return struct {
+		Log  string    `yaml:"Log"`
+		Name string    `yaml:"Name"`
+		Data eventData `yaml:"Data"`
+	}{
+		Name: e.Name,
+		Data: data,
+	}, nil
+}
+
+func fromByteArr(orig [][]byte, encodeHex bool) []string {
+	res := make([]string, len(orig))
+	for i, v := range orig {
+		if encodeHex {
+			res[i] = "0x" + hex.EncodeToString(v)
+		} else {
+			res[i] = string(v)
+		}
+	}
+	return res
+}
@@ -1,7 +1,10 @@
 package eth1
 
 import (
+	"math/big"
+
 	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 	"testing"
@@ -155,3 +158,88 @@ func TestUnmarshalYAMLValidatorRegistrationEvent(t *testing.T) {
 		require.EqualError(t, err, "encoding/hex: invalid byte: U+0073 's'")
 	})
 }
+
+func TestLocalEventsMarshalYAML(t *testing.T) {
+	events := []*Event{
+		{
+			Name: "OperatorRegistration",
+			Data: abiparser.OperatorRegistrationEvent{
+				Id:           1,
+				Name:         "operator-1",
+				OwnerAddress: common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+				PublicKey:    []byte("LS0tLS1CRUdJTiBSU0EgUFVCTElDIEtFWS0tLS0tCg=="),
+				Fee:          big.NewInt(1000000000),
+			},
+		},
+		{
+			Name: "OperatorRemoval",
+			Data: abiparser.OperatorRemovalEvent{
+				OperatorId:   1,
+				OwnerAddress: common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+			},
+		},
+		{
+			Name: "ValidatorRegistration",
+			Data: abiparser.ValidatorRegistrationEvent{
+				PublicKey:        []byte{0x1, 0x2, 0x3},
+				OwnerAddress:     common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+				OperatorIds:      []uint32{1, 2, 3, 4},
+				SharesPublicKeys: [][]byte{{0x1}, {0x2}, {0x3}, {0x4}},
+				EncryptedKeys:    [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d")},
+			},
+		},
+		{
+			Name: "ValidatorRemoval",
+			Data: abiparser.ValidatorRemovalEvent{
+				OwnerAddress: common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+				PublicKey:    []byte{0x1, 0x2, 0x3},
+			},
+		},
+		{
+			Name: "AccountLiquidation",
+			Data: abiparser.AccountLiquidationEvent{
+				OwnerAddress: common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+			},
+		},
+		{
+			Name: "AccountEnable",
+			Data: abiparser.AccountEnableEvent{
+				OwnerAddress: common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+			},
+		},
+		{
+			Name: "FeeRecipientAddressUpdated",
+			Data: abiparser.FeeRecipientAddressUpdatedEvent{
+				OwnerAddress:     common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+				RecipientAddress: common.HexToAddress("0xceefd323dd28a8d9514eddfec45a6c81800a7d49"),
+			},
+		},
+		{
+			Name: "ValidatorExited",
+			Data: abiparser.ValidatorExitedEvent{
+				OwnerAddress: common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+				PublicKey:    []byte{0x1, 0x2, 0x3},
+				BlockTime:    1616025600,
+			},
+		},
+	}
+
+	data, err := yaml.Marshal(events)
+	require.NoError(t, err)
+
+	var parsedData []*Event
+	require.NoError(t, yaml.Unmarshal(data, &parsedData))
+	require.Equal(t, events, parsedData)
+
+	_, err = yaml.Marshal([]*Event{{Name: "Unknown", Data: struct{}{}}})
+	require.EqualError(t, err, "event unknown: Unknown")
+
+	err = yaml.Unmarshal([]byte(`
+- Log:
+  Name: OperatorRegistration
+  Data:
+    Id: 1
+    Fee: fee
+`), &parsedData)
+	require.EqualError(t, err, "invalid fee: fee")
+}
@@ -1,14 +1,31 @@
 package eth1
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Options configurations related to eth1
 type Options struct {
-	ETH1Addr              string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-required:"true" env-description:"ETH1 node WebSocket address"`
+	ETH1Addr              string        `yaml:"ETH1Addr" env:"ETH_1_ADDR" env-required:"true" env-description:"ETH1 node WebSocket or HTTP addresses separated by commas, the first one is preferred and the others are used for failover, new blocks are polled while the active address is an HTTP address"`
 	ETH1SyncOffset        string        `yaml:"ETH1SyncOffset" env:"ETH_1_SYNC_OFFSET" env-default:"6F31E9" env-description:"block number to start the sync from"`
 	ETH1ConnectionTimeout time.Duration `yaml:"ETH1ConnectionTimeout" env:"ETH_1_CONNECTION_TIMEOUT" env-default:"10s" env-description:"eth1 node connection timeout"`
+	ETH1FollowDistance    uint64        `yaml:"ETH1FollowDistance" env:"ETH_1_FOLLOW_DISTANCE" env-default:"8" env-description:"number of blocks an event must be deep before it is applied, protects from reorgs"`
+	ETH1PollingInterval   time.Duration `yaml:"ETH1PollingInterval" env:"ETH_1_POLLING_INTERVAL" env-default:"12s" env-description:"interval of polling new blocks, while the active ETH1Addr is an HTTP address"`
+	ETH1CrossCheck        bool          `yaml:"ETH1CrossCheck" env:"ETH_1_CROSS_CHECK" env-default:"false" env-description:"compare the logs of two ETH1 nodes before applying them, requires multiple addresses"`
 	RegistryContractAddr  string        `yaml:"RegistryContractAddr" env:"REGISTRY_CONTRACT_ADDR_KEY" env-default:"0xb9e155e65B5c4D66df28Da8E9a0957f06F11Bc04" env-description:"registry contract address"`
 	RegistryContractABI   string        `yaml:"RegistryContractABI" env:"REGISTRY_CONTRACT_ABI" env-description:"registry contract abi json file"`
 	CleanRegistryData     bool          `yaml:"CleanRegistryData" env:"CLEAN_REGISTRY_DATA" env-default:"false" env-description:"cleans registry contract data (validator shares) and forces re-sync"`
 	AbiVersion            Version       `yaml:"AbiVersion" env:"ABI_VERSION" env-default:"0" env-description:"smart contract abi version (format)"`
 }
+
+// Addrs returns the ETH1 node addresses
+func (o Options) Addrs() []string {
+	var res []string
+	for _, addr := range strings.Split(o.ETH1Addr, ",") {
+		if addr = strings.TrimSpace(addr); len(addr) > 0 {
+			res = append(res, addr)
+		}
+	}
+	return res
+}
@@ -0,0 +1,13 @@
+package eth1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_Addrs(t *testing.T) {
+	require.Equal(t, []string{"ws://a:8546"}, Options{ETH1Addr: "ws://a:8546"}.Addrs())
+	require.Equal(t, []string{"ws://a:8546", "https://b"}, Options{ETH1Addr: " ws://a:8546, https://b ,"}.Addrs())
+	require.Empty(t, Options{}.Addrs())
+}
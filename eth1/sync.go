@@ -48,7 +48,8 @@ func HexStringToSyncOffset(shex string) *SyncOffset {
 	return offset
 }
 
-// SyncEth1Events sync past events
+// SyncEth1Events sync past events, the sync offset is upgraded after every batch of blocks whose events were handled,
+// so an interrupted sync is resumed from the last batch
 func SyncEth1Events(logger *zap.Logger, client Client, storage SyncOffsetStorage, syncOffset *SyncOffset, handler SyncEventHandler) error {
 	logger.Info("syncing eth1 contract events")
 
@@ -56,26 +57,42 @@ func SyncEth1Events(logger *zap.Logger, client Client, storage SyncOffsetStorage
 	feed := client.EventsFeed()
 	sub := feed.Subscribe(cn)
 
+	syncOffset = determineSyncOffset(logger, storage, syncOffset)
+	offset := new(SyncOffset).Set(syncOffset)
+
 	// Stop once SyncEndedEvent arrives
 	var errs []error
+	var upgradeErr error
 	var syncEndedEvent SyncEndedEvent
 	var syncWg sync.WaitGroup
 	syncWg.Add(1)
 	go func() {
 		var ok bool
+		// once a batch fails, the following batches don't upgrade the offset
+		failed := false
 		defer syncWg.Done()
 		defer sub.Unsubscribe()
 		for event := range cn {
 			if syncEndedEvent, ok = event.Data.(SyncEndedEvent); ok {
 				return
 			}
+			if batch, ok := event.Data.(SyncBatchEvent); ok {
+				if !batch.Success {
+					logger.Warn("could not parse all events from eth1",
+						zap.Uint64("fromBlock", batch.FromBlock), zap.Uint64("toBlock", batch.ToBlock))
+				}
+				failed = failed || !batch.Success || len(errs) > 0
+				if !failed && upgradeErr == nil {
+					upgradeErr = upgradeSyncOffset(logger, storage, offset, batch.ToBlock)
+				}
+				continue
+			}
 			if handler != nil {
 				logFields, err := handler(*event)
-				errs = HandleEventResult(logger, *event, logFields, err, false)
+				errs = append(errs, HandleEventResult(logger, *event, logFields, err, false)...)
 			}
 		}
 	}()
-	syncOffset = determineSyncOffset(logger, storage, syncOffset)
 	if err := client.Sync(syncOffset); err != nil {
 		return errors.Wrap(err, "failed to sync contract events")
 	}
@@ -86,23 +103,23 @@ func SyncEth1Events(logger *zap.Logger, client Client, storage SyncOffsetStorage
 		logger.Warn("could not handle some of the events during history sync", zap.Any("errs", errs))
 		return errors.New("could not handle some of the events during history sync")
 	}
-
-	return upgradeSyncOffset(logger, storage, syncOffset, syncEndedEvent)
+	if upgradeErr != nil {
+		return upgradeErr
+	}
+	logger.Debug("finished syncing eth1 contract events", zap.Int("events", syncEndedEvent.Events),
+		zap.Uint64("syncOffset", offset.Uint64()))
+	return nil
 }
 
-// upgradeSyncOffset updates the sync offset after a sync
-func upgradeSyncOffset(logger *zap.Logger, storage SyncOffsetStorage, syncOffset *SyncOffset, syncEndedEvent SyncEndedEvent) error {
-	nResults := len(syncEndedEvent.Logs)
-	if nResults > 0 {
-		if !syncEndedEvent.Success {
-			logger.Warn("could not parse all events from eth1")
-		} else if rawOffset := syncEndedEvent.Logs[nResults-1].BlockNumber; rawOffset > syncOffset.Uint64() {
-			logger.Info("upgrading sync offset", zap.Uint64("syncOffset", rawOffset))
-			syncOffset.SetUint64(rawOffset)
-			if err := storage.SaveSyncOffset(syncOffset); err != nil {
-				return errors.Wrap(err, "could not upgrade sync offset")
-			}
-		}
+// upgradeSyncOffset saves the given block as the sync offset, if it is higher than the current offset
+func upgradeSyncOffset(logger *zap.Logger, storage SyncOffsetStorage, syncOffset *SyncOffset, block uint64) error {
+	if block <= syncOffset.Uint64() {
+		return nil
+	}
+	logger.Debug("upgrading sync offset", zap.Uint64("syncOffset", block))
+	syncOffset.SetUint64(block)
+	if err := storage.SaveSyncOffset(syncOffset); err != nil {
+		return errors.Wrap(err, "could not upgrade sync offset")
 	}
 	return nil
 }
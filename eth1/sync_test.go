@@ -29,7 +29,8 @@ func TestSyncEth1(t *testing.T) {
 		logs := []types.Log{{BlockNumber: rawOffset - 1}, {BlockNumber: rawOffset}}
 		eventsFeed.Send(&Event{Data: struct{}{}, Log: logs[0]})
 		eventsFeed.Send(&Event{Data: struct{}{}, Log: logs[1]})
-		eventsFeed.Send(&Event{Data: SyncEndedEvent{Logs: logs, Success: true}})
+		eventsFeed.Send(&Event{Data: SyncBatchEvent{FromBlock: rawOffset - 10, ToBlock: rawOffset, Success: true}})
+		eventsFeed.Send(&Event{Data: SyncEndedEvent{Events: len(logs), Success: true}})
 	}()
 	err := SyncEth1Events(logger, eth1Client, storage, nil, nil)
 	require.NoError(t, err)
@@ -52,7 +53,7 @@ func TestSyncEth1Error(t *testing.T) {
 		logs := []types.Log{{}, {BlockNumber: DefaultSyncOffset().Uint64()}}
 		eventsFeed.Send(&Event{Data: struct{}{}, Log: logs[0]})
 		eventsFeed.Send(&Event{Data: struct{}{}, Log: logs[1]})
-		eventsFeed.Send(&Event{Data: SyncEndedEvent{Logs: logs, Success: false}})
+		eventsFeed.Send(&Event{Data: SyncEndedEvent{Events: len(logs), Success: false}})
 	}()
 	err := SyncEth1Events(logger, eth1Client, storage, nil, nil)
 	require.EqualError(t, err, "failed to sync contract events: eth1-sync-test")
@@ -76,7 +77,7 @@ func TestSyncEth1HandlerError(t *testing.T) {
 		logs := []types.Log{{BlockNumber: DefaultSyncOffset().Uint64() - 1}, {BlockNumber: DefaultSyncOffset().Uint64()}}
 		eventsFeed.Send(&Event{Data: struct{}{}, Log: logs[0]})
 		eventsFeed.Send(&Event{Data: struct{}{}, Log: logs[1]})
-		eventsFeed.Send(&Event{Data: SyncEndedEvent{Logs: logs, Success: false}})
+		eventsFeed.Send(&Event{Data: SyncEndedEvent{Events: len(logs), Success: false}})
 	}()
 	err := SyncEth1Events(logger, eth1Client, storage, nil, func(event Event) ([]zap.Field, error) {
 		return nil, errors.New("test")
@@ -84,6 +85,76 @@ func TestSyncEth1HandlerError(t *testing.T) {
 	require.EqualError(t, err, "could not handle some of the events during history sync")
 }
 
+func TestSyncEth1Checkpoints(t *testing.T) {
+	logger := zap.L()
+	offset := DefaultSyncOffset().Uint64()
+
+	tests := []struct {
+		name           string
+		secondBatch    bool
+		handlerErr     bool
+		expectedOffset uint64
+		expectedErr    string
+	}{
+		{
+			name:           "all batches",
+			secondBatch:    true,
+			expectedOffset: offset + 30,
+		},
+		{
+			name:           "malformed events stop the upgrade",
+			secondBatch:    false,
+			expectedOffset: offset + 10,
+		},
+		{
+			name:           "handler errors stop the upgrade",
+			secondBatch:    true,
+			handlerErr:     true,
+			expectedOffset: offset + 10,
+			expectedErr:    "could not handle some of the events during history sync",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			eth1Client, eventsFeed := eth1ClientMock(ctrl, nil)
+			storage := syncStorageMock(ctrl)
+
+			go func() {
+				time.Sleep(5 * time.Millisecond)
+				eventsFeed.Send(&Event{Data: "first", Log: types.Log{BlockNumber: offset + 5}})
+				eventsFeed.Send(&Event{Data: SyncBatchEvent{FromBlock: offset, ToBlock: offset + 10, Success: true}})
+				eventsFeed.Send(&Event{Data: "second", Log: types.Log{BlockNumber: offset + 15}})
+				eventsFeed.Send(&Event{Data: SyncBatchEvent{FromBlock: offset + 11, ToBlock: offset + 20, Success: test.secondBatch}})
+				eventsFeed.Send(&Event{Data: "third", Log: types.Log{BlockNumber: offset + 25}})
+				eventsFeed.Send(&Event{Data: SyncBatchEvent{FromBlock: offset + 21, ToBlock: offset + 30, Success: true}})
+				eventsFeed.Send(&Event{Data: SyncEndedEvent{Events: 3, Success: test.secondBatch}})
+			}()
+			err := SyncEth1Events(logger, eth1Client, storage, nil, func(event Event) ([]zap.Field, error) {
+				if test.handlerErr && event.Data == "second" {
+					return nil, errors.New("test")
+				}
+				return nil, nil
+			})
+			if len(test.expectedErr) > 0 {
+				require.EqualError(t, err, test.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// the offset is upgraded by the batches up to the first failure
+			syncOffset, found, err := storage.GetSyncOffset()
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, test.expectedOffset, syncOffset.Uint64())
+		})
+	}
+}
+
 func TestDetermineSyncOffset(t *testing.T) {
 	logger := zap.L()
 
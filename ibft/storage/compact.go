@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// compactBatchSize is the number of historical instances that are converted in a single transaction
+const compactBatchSize = 1000
+
+// errBatchFull stops the iteration once a batch is full
+var errBatchFull = errors.New("batch is full")
+
+// CompactHistory converts the historical instances of the given role that are saved whole into compact
+// decided records, in batches of compactBatchSize. instances that can't be decoded are left as is.
+// it returns the number of converted instances.
+func CompactHistory(db basedb.IDb, logger *zap.Logger, role spectypes.BeaconRole) (int, error) {
+	prefix := []byte(role.String())
+	converted := 0
+	var from []byte
+	for {
+		var batch []basedb.Obj
+		var next []byte
+		err := db.View(func(txn basedb.ReadTxn) error {
+			return txn.GetRange(prefix, from, nil, false, func(_ int, obj basedb.Obj) error {
+				if len(batch) == compactBatchSize {
+					next = obj.Key
+					return errBatchFull
+				}
+				if !isHistoricalInstanceKey(obj.Key) || qbftstorage.IsDecidedRecord(obj.Value) {
+					return nil
+				}
+				instance := &qbftstorage.StoredInstance{}
+				if err := instance.Decode(obj.Value); err != nil {
+					logger.Warn("could not decode instance, skipping", zap.String("key", hex.EncodeToString(obj.Key)), zap.Error(err))
+					return nil
+				}
+				value, err := encodeDecidedRecord(instance)
+				if err != nil {
+					logger.Warn("could not convert instance, skipping", zap.String("key", hex.EncodeToString(obj.Key)), zap.Error(err))
+					return nil
+				}
+				batch = append(batch, basedb.Obj{Key: obj.Key, Value: value})
+				return nil
+			})
+		})
+		if err != nil && err != errBatchFull {
+			return converted, errors.Wrap(err, "could not iterate instances")
+		}
+		if len(batch) > 0 {
+			err := db.Update(func(txn basedb.Txn) error {
+				for _, obj := range batch {
+					if err := txn.Set(prefix, obj.Key, obj.Value); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return converted, errors.Wrap(err, "could not save decided records")
+			}
+			converted += len(batch)
+		}
+		if next == nil {
+			return converted, nil
+		}
+		from = next
+	}
+}
+
+// isHistoricalInstanceKey returns true if the given key, without the role prefix, is of a historical instance.
+// the sync committee prefix also matches sync committee contribution keys, which are longer
+func isHistoricalInstanceKey(key []byte) bool {
+	return len(key) == identifierSize+len(instanceKey)+8 && bytes.HasPrefix(key[identifierSize:], []byte(instanceKey))
+}
+
+func encodeDecidedRecord(instance *qbftstorage.StoredInstance) ([]byte, error) {
+	record, err := qbftstorage.NewDecidedRecord(instance.DecidedMessage)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create decided record")
+	}
+	value, err := record.Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode decided record")
+	}
+	return value, nil
+}
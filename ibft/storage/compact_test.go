@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	ssvstorage "github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+// newCompactTestInstance creates a decided instance with full message containers
+func newCompactTestInstance(t *testing.T, id spectypes.MessageID, h specqbft.Height) *qbftstorage.StoredInstance {
+	instance := newRetentionTestInstance(t, id, h, 100)
+	commitData, err := (&specqbft.CommitData{Data: instance.State.DecidedValue}).Encode()
+	require.NoError(t, err)
+	instance.DecidedMessage.Signature = make([]byte, 96)
+	instance.DecidedMessage.Signers = []spectypes.OperatorID{1, 2, 3}
+	instance.DecidedMessage.Message.Data = commitData
+
+	instance.State.ProposeContainer = specqbft.NewMsgContainer()
+	instance.State.PrepareContainer = specqbft.NewMsgContainer()
+	instance.State.CommitContainer = specqbft.NewMsgContainer()
+	instance.State.RoundChangeContainer = specqbft.NewMsgContainer()
+	for signer := spectypes.OperatorID(1); signer <= 4; signer++ {
+		msg := &specqbft.SignedMessage{
+			Signature: make([]byte, 96),
+			Signers:   []spectypes.OperatorID{signer},
+			Message:   instance.DecidedMessage.Message,
+		}
+		instance.State.PrepareContainer.AddMsg(msg)
+		instance.State.CommitContainer.AddMsg(msg)
+	}
+	return instance
+}
+
+func newCompactTestDB(t *testing.T) basedb.IDb {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logex.GetLogger(),
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestCompactHistory(t *testing.T) {
+	db := newCompactTestDB(t)
+	defer db.Close()
+	role := spectypes.BNRoleAttester
+	id := spectypes.NewMsgID([]byte("pk"), role)
+	full := New(db, logex.GetLogger(), role.String(), forksprotocol.GenesisForkVersion)
+	compact := NewCompact(db, logex.GetLogger(), role.String(), forksprotocol.GenesisForkVersion)
+
+	// heights 0-4 are saved whole, heights 5-9 are compact
+	var instances []*qbftstorage.StoredInstance
+	for h := specqbft.Height(0); h < 10; h++ {
+		instance := newCompactTestInstance(t, id, h)
+		instances = append(instances, instance)
+		store := full
+		if h >= 5 {
+			store = compact
+		}
+		require.NoError(t, store.SaveHighestAndHistoricalInstance(instance))
+	}
+
+	fullSize, compactSize := historySize(t, db, role, 0), historySize(t, db, role, 5)
+	require.Less(t, compactSize*2, fullSize)
+
+	// both formats are readable
+	stored, err := full.GetInstancesInRange(id[:], 0, 9)
+	require.NoError(t, err)
+	require.Len(t, stored, 10)
+	for h, instance := range stored {
+		require.Equal(t, instances[h].DecidedMessage, instance.DecidedMessage)
+		require.Equal(t, instances[h].State.DecidedValue, instance.State.DecidedValue)
+	}
+	// the highest instance is saved whole
+	highest, err := compact.GetHighestInstance(id[:])
+	require.NoError(t, err)
+	require.Equal(t, instances[9], highest)
+
+	converted, err := CompactHistory(db, logex.GetLogger(), role)
+	require.NoError(t, err)
+	require.Equal(t, 5, converted)
+	require.Equal(t, compactSize, historySize(t, db, role, 0))
+	converted, err = CompactHistory(db, logex.GetLogger(), role)
+	require.NoError(t, err)
+	require.Equal(t, 0, converted)
+
+	instance, err := full.GetInstance(id[:], 2)
+	require.NoError(t, err)
+	require.Equal(t, instances[2].DecidedMessage, instance.DecidedMessage)
+}
+
+func TestCompactHistoryBatches(t *testing.T) {
+	db := newCompactTestDB(t)
+	defer db.Close()
+	syncCommittee := New(db, logex.GetLogger(), spectypes.BNRoleSyncCommittee.String(), forksprotocol.GenesisForkVersion)
+	contribution := New(db, logex.GetLogger(), spectypes.BNRoleSyncCommitteeContribution.String(), forksprotocol.GenesisForkVersion)
+	n := compactBatchSize + 10
+	for h := 0; h < n; h++ {
+		id := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleSyncCommittee)
+		require.NoError(t, syncCommittee.SaveInstance(newCompactTestInstance(t, id, specqbft.Height(h))))
+	}
+	contributionID := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleSyncCommitteeContribution)
+	require.NoError(t, contribution.SaveInstance(newCompactTestInstance(t, contributionID, 1)))
+
+	// the sync committee prefix also matches sync committee contribution keys, which are not converted
+	converted, err := CompactHistory(db, logex.GetLogger(), spectypes.BNRoleSyncCommittee)
+	require.NoError(t, err)
+	require.Equal(t, n, converted)
+	converted, err = CompactHistory(db, logex.GetLogger(), spectypes.BNRoleSyncCommitteeContribution)
+	require.NoError(t, err)
+	require.Equal(t, 1, converted)
+}
+
+// historySize returns the size of the historical instances of the 5 heights from the given height
+func historySize(t *testing.T, db basedb.IDb, role spectypes.BeaconRole, from specqbft.Height) int {
+	size := 0
+	require.NoError(t, db.GetAll([]byte(role.String()), func(_ int, obj basedb.Obj) error {
+		if !isHistoricalInstanceKey(obj.Key) {
+			return nil
+		}
+		instance, err := qbftstorage.DecodeHistoricalInstance(obj.Value)
+		require.NoError(t, err)
+		if instance.State.Height >= from && instance.State.Height < from+5 {
+			size += len(obj.Value)
+		}
+		return nil
+	}))
+	return size
+}
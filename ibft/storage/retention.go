@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const (
+	// identifierSize is the size of the identifier that precedes the instance keys
+	identifierSize = len(spectypes.MessageID{})
+	// pruneBatchSize is the number of instances that are removed in a single transaction
+	pruneBatchSize = 1000
+	// maxPrunedPerPass limits the instances that are removed in a single pass, the rest are removed in the next passes
+	maxPrunedPerPass = 100 * pruneBatchSize
+)
+
+var (
+	metricsPrunedInstances = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:storage:qbft_pruned_instances",
+		Help: "The number of pruned historical instances",
+	}, []string{"role"})
+	metricsPrunedBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ssv:storage:qbft_pruned_bytes",
+		Help: "The size of the keys and values of the pruned historical instances",
+	}, []string{"role"})
+)
+
+func init() {
+	if err := prometheus.Register(metricsPrunedInstances); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsPrunedBytes); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+}
+
+// errPassLimit stops the iteration once enough instances were collected for a single pass
+var errPassLimit = errors.New("prune pass limit reached")
+
+// RetentionOptions configures the pruning of the decided history, which is saved only by full nodes
+type RetentionOptions struct {
+	Interval time.Duration `yaml:"Interval" env:"HISTORY_PRUNING_INTERVAL" env-default:"10m" env-description:"Interval of pruning the decided history"`
+	Heights  uint64        `yaml:"Heights" env:"HISTORY_RETENTION_HEIGHTS" env-default:"0" env-description:"Number of latest heights of decided history to keep per identifier, 0 keeps all"`
+	Slots    uint64        `yaml:"Slots" env:"HISTORY_RETENTION_SLOTS" env-default:"0" env-description:"Number of latest slots of decided history to keep, 0 keeps all"`
+	// Roles overrides the policy of specific roles, e.g. ATTESTER
+	Roles map[string]qbftstorage.RetentionPolicy `yaml:"Roles"`
+}
+
+// Policy returns the retention policy of the given role
+func (o RetentionOptions) Policy(role spectypes.BeaconRole) qbftstorage.RetentionPolicy {
+	if policy, ok := o.Roles[role.String()]; ok {
+		return policy
+	}
+	return qbftstorage.RetentionPolicy{Heights: o.Heights, Slots: o.Slots}
+}
+
+// Enabled returns true if any of the roles is pruned
+func (o RetentionOptions) Enabled() bool {
+	if (qbftstorage.RetentionPolicy{Heights: o.Heights, Slots: o.Slots}).Enabled() {
+		return true
+	}
+	for _, policy := range o.Roles {
+		if policy.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error if the history is pruned without a positive interval
+func (o RetentionOptions) Validate() error {
+	if o.Enabled() && o.Interval <= 0 {
+		return errors.Errorf("invalid history pruning interval %s, must be positive", o.Interval)
+	}
+	return nil
+}
+
+// identifierHistory collects the historical instances of an identifier while iterating over the store
+type identifierHistory struct {
+	identifier []byte
+	highest    specqbft.Height
+	hasHighest bool
+	instances  []historicalInstance
+}
+
+type historicalInstance struct {
+	key    []byte
+	height specqbft.Height
+	slot   phase0.Slot
+	size   int64
+}
+
+// PruneInstances removes the historical instances that are out of the given retention policy,
+// in batches of pruneBatchSize. the highest instances are never removed.
+func (i *ibftStorage) PruneInstances(policy qbftstorage.RetentionPolicy, currentSlot phase0.Slot) (*qbftstorage.PruneResult, error) {
+	i.forkLock.RLock()
+	defer i.forkLock.RUnlock()
+
+	res := &qbftstorage.PruneResult{}
+	if !policy.Enabled() {
+		return res, nil
+	}
+
+	var pruned []historicalInstance
+	var current *identifierHistory
+	collect := func() {
+		if current != nil {
+			pruned = append(pruned, current.prune(policy, currentSlot)...)
+		}
+	}
+	err := i.db.View(func(txn basedb.ReadTxn) error {
+		// the keys of an identifier are adjacent, the highest instance key precedes the historical instances keys
+		return txn.GetAll(i.prefix, func(_ int, obj basedb.Obj) error {
+			// both the highest and the historical instance keys have the same length, other keys belong to
+			// other stores that share the prefix, e.g. SYNC_COMMITTEE_CONTRIBUTION of SYNC_COMMITTEE
+			if len(obj.Key) != identifierSize+len(highestInstanceKey) {
+				return nil
+			}
+			identifier, rest := obj.Key[:identifierSize], obj.Key[identifierSize:]
+			if current == nil || !bytes.Equal(current.identifier, identifier) {
+				collect()
+				if len(pruned) >= maxPrunedPerPass {
+					return errPassLimit
+				}
+				current = &identifierHistory{identifier: identifier}
+			}
+			size := int64(len(i.prefix) + len(obj.Key) + len(obj.Value))
+			switch {
+			case bytes.Equal(rest, []byte(highestInstanceKey)):
+				instance := &qbftstorage.StoredInstance{}
+				if err := instance.Decode(obj.Value); err != nil || instance.State == nil {
+					// an unknown highest height doesn't allow pruning by heights
+					return nil
+				}
+				current.highest = instance.State.Height
+				current.hasHighest = true
+			case bytes.HasPrefix(rest, []byte(instanceKey)):
+				h := historicalInstance{
+					key:    obj.Key,
+					height: specqbft.Height(binary.LittleEndian.Uint64(rest[len(instanceKey):])),
+					size:   size,
+				}
+				if policy.Slots > 0 {
+					h.slot = decidedSlot(obj.Value)
+				}
+				current.instances = append(current.instances, h)
+			}
+			return nil
+		})
+	})
+	if err != nil && err != errPassLimit {
+		return nil, errors.Wrap(err, "could not iterate instances")
+	}
+	if err == nil {
+		collect()
+	}
+
+	role := string(i.prefix)
+	for start := 0; start < len(pruned); start += pruneBatchSize {
+		end := start + pruneBatchSize
+		if end > len(pruned) {
+			end = len(pruned)
+		}
+		batch := pruned[start:end]
+		err := i.db.Update(func(txn basedb.Txn) error {
+			for _, p := range batch {
+				if err := txn.Delete(i.prefix, p.key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return res, errors.Wrap(err, "could not remove instances")
+		}
+		var size int64
+		for _, p := range batch {
+			size += p.size
+		}
+		res.Pruned += len(batch)
+		res.ReclaimedBytes += size
+		metricsPrunedInstances.WithLabelValues(role).Add(float64(len(batch)))
+		metricsPrunedBytes.WithLabelValues(role).Add(float64(size))
+	}
+	if res.Pruned > 0 {
+		i.logger.Debug("pruned instances", zap.String("role", role), zap.Int("count", res.Pruned),
+			zap.Int64("bytes", res.ReclaimedBytes))
+	}
+	return res, nil
+}
+
+// prune returns the instances that are out of the given policy
+func (h *identifierHistory) prune(policy qbftstorage.RetentionPolicy, currentSlot phase0.Slot) []historicalInstance {
+	highest := h.highest
+	if !h.hasHighest {
+		// without the highest instance, the latest historical instance is kept
+		for _, instance := range h.instances {
+			if instance.height > highest {
+				highest = instance.height
+			}
+		}
+	}
+
+	var res []historicalInstance
+	for _, instance := range h.instances {
+		if instance.height >= highest {
+			continue
+		}
+		byHeight := policy.Heights > 0 && uint64(highest-instance.height) >= policy.Heights
+		bySlot := policy.Slots > 0 && instance.slot > 0 && uint64(currentSlot) > uint64(instance.slot)+policy.Slots
+		if byHeight || bySlot {
+			res = append(res, instance)
+		}
+	}
+	return res
+}
+
+// decidedSlot returns the duty slot of the decided value of a stored instance, or 0 if it is unknown
+func decidedSlot(value []byte) phase0.Slot {
+	instance, err := qbftstorage.DecodeHistoricalInstance(value)
+	if err != nil || instance.State == nil {
+		return 0
+	}
+	data := &spectypes.ConsensusData{}
+	if err := data.Decode(instance.State.DecidedValue); err != nil || data.Duty == nil {
+		return 0
+	}
+	return data.Duty.Slot
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	ssvstorage "github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/logex"
+)
+
+func newRetentionTestInstance(t *testing.T, id spectypes.MessageID, h specqbft.Height, slot phase0.Slot) *qbftstorage.StoredInstance {
+	data := &spectypes.ConsensusData{
+		Duty: &spectypes.Duty{Type: id.GetRoleType(), Slot: slot},
+	}
+	value, err := data.Encode()
+	require.NoError(t, err)
+	return &qbftstorage.StoredInstance{
+		State: &specqbft.State{
+			ID:           id[:],
+			Round:        1,
+			Height:       h,
+			Decided:      true,
+			DecidedValue: value,
+		},
+		DecidedMessage: &specqbft.SignedMessage{
+			Signature: []byte("sig"),
+			Signers:   []spectypes.OperatorID{1},
+			Message: &specqbft.Message{
+				MsgType:    specqbft.CommitMsgType,
+				Height:     h,
+				Round:      1,
+				Identifier: id[:],
+			},
+		},
+	}
+}
+
+// saveHistory saves the instances of heights [0, count), each in its own slot, the last one is the highest
+func saveHistory(t *testing.T, store qbftstorage.QBFTStore, id spectypes.MessageID, count int) {
+	for h := 0; h < count; h++ {
+		instance := newRetentionTestInstance(t, id, specqbft.Height(h), phase0.Slot(100+h))
+		if h == count-1 {
+			require.NoError(t, store.SaveHighestAndHistoricalInstance(instance))
+		} else {
+			require.NoError(t, store.SaveInstance(instance))
+		}
+	}
+}
+
+func historyHeights(t *testing.T, store qbftstorage.QBFTStore, id spectypes.MessageID) []specqbft.Height {
+	instances, err := store.GetInstancesInRange(id[:], 0, 1000)
+	require.NoError(t, err)
+	var res []specqbft.Height
+	for _, instance := range instances {
+		res = append(res, instance.State.Height)
+	}
+	return res
+}
+
+func TestPruneInstances(t *testing.T) {
+	id := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleAttester)
+	other := spectypes.NewMsgID([]byte("other_pk"), spectypes.BNRoleAttester)
+
+	t.Run("disabled", func(t *testing.T) {
+		store, err := newTestIbftStorage(logex.GetLogger(), "test", forksprotocol.GenesisForkVersion)
+		require.NoError(t, err)
+		saveHistory(t, store, id, 10)
+
+		res, err := store.PruneInstances(qbftstorage.RetentionPolicy{}, 1000)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Pruned)
+		require.Len(t, historyHeights(t, store, id), 10)
+	})
+
+	t.Run("by heights", func(t *testing.T) {
+		store, err := newTestIbftStorage(logex.GetLogger(), "test", forksprotocol.GenesisForkVersion)
+		require.NoError(t, err)
+		saveHistory(t, store, id, 10)
+		saveHistory(t, store, other, 2)
+
+		res, err := store.PruneInstances(qbftstorage.RetentionPolicy{Heights: 3}, 0)
+		require.NoError(t, err)
+		require.Equal(t, 7, res.Pruned)
+		require.Greater(t, res.ReclaimedBytes, int64(0))
+		require.Equal(t, []specqbft.Height{7, 8, 9}, historyHeights(t, store, id))
+		require.Equal(t, []specqbft.Height{0, 1}, historyHeights(t, store, other))
+
+		highest, err := store.GetHighestInstance(id[:])
+		require.NoError(t, err)
+		require.NotNil(t, highest)
+		require.Equal(t, specqbft.Height(9), highest.State.Height)
+
+		// a second pass has nothing to prune
+		res, err = store.PruneInstances(qbftstorage.RetentionPolicy{Heights: 3}, 0)
+		require.NoError(t, err)
+		require.Equal(t, 0, res.Pruned)
+	})
+
+	t.Run("by slots", func(t *testing.T) {
+		store, err := newTestIbftStorage(logex.GetLogger(), "test", forksprotocol.GenesisForkVersion)
+		require.NoError(t, err)
+		saveHistory(t, store, id, 10)
+
+		// slots 100-109, instances older than slot 105 are pruned
+		res, err := store.PruneInstances(qbftstorage.RetentionPolicy{Slots: 5}, 110)
+		require.NoError(t, err)
+		require.Equal(t, 5, res.Pruned)
+		require.Equal(t, []specqbft.Height{5, 6, 7, 8, 9}, historyHeights(t, store, id))
+
+		// the highest instance is kept even if it is out of the policy
+		_, err = store.PruneInstances(qbftstorage.RetentionPolicy{Slots: 5}, 1000)
+		require.NoError(t, err)
+		require.Equal(t, []specqbft.Height{9}, historyHeights(t, store, id))
+	})
+}
+
+func TestPruneInstancesSharedPrefix(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: logex.GetLogger(),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	syncCommittee := New(db, logex.GetLogger(), spectypes.BNRoleSyncCommittee.String(), forksprotocol.GenesisForkVersion)
+	contribution := New(db, logex.GetLogger(), spectypes.BNRoleSyncCommitteeContribution.String(), forksprotocol.GenesisForkVersion)
+	syncCommitteeID := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleSyncCommittee)
+	contributionID := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleSyncCommitteeContribution)
+	saveHistory(t, syncCommittee, syncCommitteeID, 5)
+	saveHistory(t, contribution, contributionID, 5)
+
+	// the prefix of sync committee is a prefix of sync committee contribution, which must not be pruned
+	res, err := syncCommittee.PruneInstances(qbftstorage.RetentionPolicy{Heights: 1}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 4, res.Pruned)
+	require.Equal(t, []specqbft.Height{4}, historyHeights(t, syncCommittee, syncCommitteeID))
+	require.Len(t, historyHeights(t, contribution, contributionID), 5)
+}
+
+func TestRetentionOptions(t *testing.T) {
+	opts := RetentionOptions{}
+	require.False(t, opts.Enabled())
+
+	opts.Roles = map[string]qbftstorage.RetentionPolicy{
+		spectypes.BNRoleProposer.String(): {Slots: 100},
+	}
+	require.True(t, opts.Enabled())
+	require.Equal(t, qbftstorage.RetentionPolicy{Slots: 100}, opts.Policy(spectypes.BNRoleProposer))
+	require.Equal(t, qbftstorage.RetentionPolicy{}, opts.Policy(spectypes.BNRoleAttester))
+
+	opts.Heights = 10
+	require.Equal(t, qbftstorage.RetentionPolicy{Heights: 10}, opts.Policy(spectypes.BNRoleAttester))
+	require.Equal(t, qbftstorage.RetentionPolicy{Slots: 100}, opts.Policy(spectypes.BNRoleProposer))
+
+	// the interval matters only when the history is pruned
+	require.EqualError(t, opts.Validate(), "invalid history pruning interval 0s, must be positive")
+	opts.Interval = -time.Minute
+	require.Error(t, opts.Validate())
+	opts.Interval = time.Minute
+	require.NoError(t, opts.Validate())
+	require.NoError(t, RetentionOptions{}.Validate())
+}
@@ -45,16 +45,29 @@ type ibftStorage struct {
 	logger   *zap.Logger
 	fork     forks.Fork
 	forkLock *sync.RWMutex
+	// compactHistory saves the historical instances as decided records rather than whole instances
+	compactHistory bool
 }
 
 // New create new ibft storage
 func New(db basedb.IDb, logger *zap.Logger, prefix string, forkVersion forksprotocol.ForkVersion) qbftstorage.QBFTStore {
+	return newStorage(db, logger, prefix, forkVersion, false)
+}
+
+// NewCompact create new ibft storage that saves the historical instances as compact decided records,
+// the highest instances are saved whole. historical instances of both formats are readable.
+func NewCompact(db basedb.IDb, logger *zap.Logger, prefix string, forkVersion forksprotocol.ForkVersion) qbftstorage.QBFTStore {
+	return newStorage(db, logger, prefix, forkVersion, true)
+}
+
+func newStorage(db basedb.IDb, logger *zap.Logger, prefix string, forkVersion forksprotocol.ForkVersion, compactHistory bool) *ibftStorage {
 	return &ibftStorage{
-		prefix:   []byte(prefix),
-		db:       db,
-		logger:   logger,
-		fork:     forksfactory.NewFork(forkVersion),
-		forkLock: &sync.RWMutex{},
+		prefix:         []byte(prefix),
+		db:             db,
+		logger:         logger,
+		fork:           forksfactory.NewFork(forkVersion),
+		forkLock:       &sync.RWMutex{},
+		compactHistory: compactHistory,
 	}
 }
 
@@ -70,7 +83,13 @@ func (i *ibftStorage) OnFork(forkVersion forksprotocol.ForkVersion) error {
 
 // GetHighestInstance returns the StoredInstance for the highest instance.
 func (i *ibftStorage) GetHighestInstance(identifier []byte) (*qbftstorage.StoredInstance, error) {
-	val, found, err := i.get(highestInstanceKey, identifier[:])
+	var val []byte
+	var found bool
+	err := i.db.View(func(txn basedb.ReadTxn) error {
+		var err error
+		val, found, err = i.get(txn, highestInstanceKey, identifier[:])
+		return err
+	})
 	if !found {
 		return nil, nil
 	}
@@ -101,25 +120,30 @@ func (i *ibftStorage) saveInstance(instance *qbftstorage.StoredInstance, toHisto
 	if err != nil {
 		return errors.Wrap(err, "could not encode instance")
 	}
-
-	if asHighest {
-		i.forkLock.RLock()
-		defer i.forkLock.RUnlock()
-
-		err = i.save(value, highestInstanceKey, instance.State.ID)
-		if err != nil {
-			return errors.Wrap(err, "could not save highest instance")
+	historyValue := value
+	if toHistory && i.compactHistory {
+		if historyValue, err = encodeDecidedRecord(instance); err != nil {
+			return err
 		}
 	}
 
-	if toHistory {
-		err = i.save(value, instanceKey, instance.State.ID, uInt64ToByteSlice(uint64(instance.State.Height)))
-		if err != nil {
-			return errors.Wrap(err, "could not save historical instance")
-		}
-	}
+	i.forkLock.RLock()
+	defer i.forkLock.RUnlock()
 
-	return nil
+	// the highest and historical instances are saved in a single transaction, so readers see both or none
+	return i.db.Update(func(txn basedb.Txn) error {
+		if asHighest {
+			if err := i.save(txn, value, highestInstanceKey, instance.State.ID); err != nil {
+				return errors.Wrap(err, "could not save highest instance")
+			}
+		}
+		if toHistory {
+			if err := i.save(txn, historyValue, instanceKey, instance.State.ID, uInt64ToByteSlice(uint64(instance.State.Height))); err != nil {
+				return errors.Wrap(err, "could not save historical instance")
+			}
+		}
+		return nil
+	})
 }
 
 // GetInstance returns historical StoredInstance for the given identifier and height.
@@ -127,18 +151,13 @@ func (i *ibftStorage) GetInstance(identifier []byte, height specqbft.Height) (*q
 	i.forkLock.RLock()
 	defer i.forkLock.RUnlock()
 
-	val, found, err := i.get(instanceKey, identifier[:], uInt64ToByteSlice(uint64(height)))
-	if !found {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
-	}
-	ret := &qbftstorage.StoredInstance{}
-	if err := ret.Decode(val); err != nil {
-		return nil, errors.Wrap(err, "could not decode instance")
-	}
-	return ret, nil
+	var ret *qbftstorage.StoredInstance
+	err := i.db.View(func(txn basedb.ReadTxn) error {
+		var err error
+		ret, err = i.getInstance(txn, identifier, height)
+		return err
+	})
+	return ret, err
 }
 
 // GetInstancesInRange returns historical StoredInstance's in the given range.
@@ -148,26 +167,47 @@ func (i *ibftStorage) GetInstancesInRange(identifier []byte, from specqbft.Heigh
 
 	instances := make([]*qbftstorage.StoredInstance, 0)
 
-	for seq := from; seq <= to; seq++ {
-		instance, err := i.GetInstance(identifier, seq)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get instance")
-		}
-		if instance != nil {
-			instances = append(instances, instance)
+	// heights are encoded in little endian, therefore the range is read key by key, over a single snapshot
+	err := i.db.View(func(txn basedb.ReadTxn) error {
+		for seq := from; seq <= to; seq++ {
+			instance, err := i.getInstance(txn, identifier, seq)
+			if err != nil {
+				return errors.Wrap(err, "failed to get instance")
+			}
+			if instance != nil {
+				instances = append(instances, instance)
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return instances, nil
 }
 
+func (i *ibftStorage) getInstance(txn basedb.ReadTxn, identifier []byte, height specqbft.Height) (*qbftstorage.StoredInstance, error) {
+	val, found, err := i.get(txn, instanceKey, identifier[:], uInt64ToByteSlice(uint64(height)))
+	if !found {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret, err := qbftstorage.DecodeHistoricalInstance(val)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode instance")
+	}
+	return ret, nil
+}
+
 // CleanAllInstances removes all StoredInstance's & highest StoredInstance's for msgID.
 func (i *ibftStorage) CleanAllInstances(msgID []byte) error {
 	i.forkLock.RLock()
 	defer i.forkLock.RUnlock()
 
-	prefix := i.prefix
-	prefix = append(prefix, msgID[:]...)
+	prefix := append(append([]byte{}, i.prefix...), msgID[:]...)
 	prefix = append(prefix, []byte(instanceKey)...)
 	n, err := i.db.DeleteByPrefix(prefix)
 	if err != nil {
@@ -175,22 +215,24 @@ func (i *ibftStorage) CleanAllInstances(msgID []byte) error {
 	}
 	i.logger.Debug("removed decided", zap.Int("count", n),
 		zap.String("identifier", hex.EncodeToString(msgID)))
-	if err := i.delete(highestInstanceKey, msgID[:]); err != nil {
+	if err := i.db.Update(func(txn basedb.Txn) error {
+		return i.delete(txn, highestInstanceKey, msgID[:])
+	}); err != nil {
 		return errors.Wrap(err, "failed to remove last decided")
 	}
 	return nil
 }
 
-func (i *ibftStorage) save(value []byte, id string, pk []byte, keyParams ...[]byte) error {
+func (i *ibftStorage) save(txn basedb.Txn, value []byte, id string, pk []byte, keyParams ...[]byte) error {
 	prefix := append(i.prefix, pk...)
 	key := i.key(id, keyParams...)
-	return i.db.Set(prefix, key, value)
+	return txn.Set(prefix, key, value)
 }
 
-func (i *ibftStorage) get(id string, pk []byte, keyParams ...[]byte) ([]byte, bool, error) {
+func (i *ibftStorage) get(txn basedb.ReadTxn, id string, pk []byte, keyParams ...[]byte) ([]byte, bool, error) {
 	prefix := append(i.prefix, pk...)
 	key := i.key(id, keyParams...)
-	obj, found, err := i.db.Get(prefix, key)
+	obj, found, err := txn.Get(prefix, key)
 	if !found {
 		return nil, found, nil
 	}
@@ -200,10 +242,10 @@ func (i *ibftStorage) get(id string, pk []byte, keyParams ...[]byte) ([]byte, bo
 	return obj.Value, found, nil
 }
 
-func (i *ibftStorage) delete(id string, pk []byte, keyParams ...[]byte) error {
+func (i *ibftStorage) delete(txn basedb.Txn, id string, pk []byte, keyParams ...[]byte) error {
 	prefix := append(i.prefix, pk...)
 	key := i.key(id, keyParams...)
-	return i.db.Delete(prefix, key)
+	return txn.Delete(prefix, key)
 }
 
 func (i *ibftStorage) key(id string, params ...[]byte) []byte {
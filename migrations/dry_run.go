@@ -0,0 +1,220 @@
+package migrations
+
+import (
+	"context"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/inspect"
+)
+
+// DryRunReport is the outcome of a pending migration that was executed on a copy of the db
+type DryRunReport struct {
+	Name   string `json:"name"`
+	Backup bool   `json:"backup"`
+	// Completed is false if the migration is postponed, e.g. until it is enabled by the config
+	Completed bool                 `json:"completed"`
+	Changes   []*CollectionChanges `json:"changes"`
+	Error     string               `json:"error,omitempty"`
+	changes   map[string]*CollectionChanges
+}
+
+// CollectionChanges holds the number of keys of a collection that a migration deletes or writes
+type CollectionChanges struct {
+	Collection string `json:"collection"`
+	Deleted    int    `json:"deleted"`
+	Rewritten  int    `json:"rewritten"`
+	Created    int    `json:"created"`
+}
+
+// DryRun executes the pending default migrations on a copy of the db.
+func DryRun(ctx context.Context, opt Options) ([]*DryRunReport, error) {
+	return defaultMigrations.DryRun(ctx, opt)
+}
+
+// DryRun executes the pending migrations on a temporary copy of the db of the same type in opt.DryRunDir, and reports the keys
+// that each of them deletes or writes. the db itself is not changed.
+// the execution stops at the first failed migration, as the following migrations depend on it.
+func (m Migrations) DryRun(ctx context.Context, opt Options) ([]*DryRunReport, error) {
+	dir, err := os.MkdirTemp(opt.DryRunDir, "ssv-migrations-dry-run")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create temp dir")
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	dbCopy, err := storage.GetStorageFactory(basedb.Options{
+		Type:   opt.DbType,
+		Path:   dir,
+		Logger: zap.NewNop(),
+		Ctx:    ctx,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create db copy")
+	}
+	defer dbCopy.Close()
+	if _, err := storage.Copy(opt.Db, dbCopy); err != nil {
+		return nil, errors.Wrap(err, "could not copy db")
+	}
+
+	var reports []*DryRunReport
+	for _, migration := range m {
+		applied, err := isApplied(dbCopy, migration.Name)
+		if err != nil {
+			return nil, err
+		}
+		if applied {
+			continue
+		}
+
+		report := &DryRunReport{
+			Name:    migration.Name,
+			Backup:  migration.Backup,
+			changes: make(map[string]*CollectionChanges),
+		}
+		reports = append(reports, report)
+		if migration.Postponed != nil && migration.Postponed(opt) {
+			report.sortChanges()
+			continue
+		}
+		copyOpt := opt
+		copyOpt.Db = &recordingDB{IDb: dbCopy, report: report}
+		copyOpt.BackupDir = ""
+		err = migration.Run(ctx, copyOpt, []byte(migration.Name))
+		report.sortChanges()
+		if err != nil {
+			report.Error = err.Error()
+			break
+		}
+		if report.Completed, err = isApplied(dbCopy, migration.Name); err != nil {
+			return nil, err
+		}
+	}
+	return reports, nil
+}
+
+func (r *DryRunReport) collection(prefix, key []byte) *CollectionChanges {
+	name := inspect.CollectionName(append(append([]byte{}, prefix...), key...))
+	c, ok := r.changes[name]
+	if !ok {
+		c = &CollectionChanges{Collection: name}
+		r.changes[name] = c
+	}
+	return c
+}
+
+func (r *DryRunReport) sortChanges() {
+	r.Changes = make([]*CollectionChanges, 0, len(r.changes))
+	for _, c := range r.changes {
+		r.Changes = append(r.Changes, c)
+	}
+	sort.Slice(r.Changes, func(i, j int) bool {
+		return r.Changes[i].Collection < r.Changes[j].Collection
+	})
+}
+
+// recordingDB records the keys that are deleted or written into a DryRunReport
+type recordingDB struct {
+	basedb.IDb
+	report *DryRunReport
+}
+
+func (db *recordingDB) Set(prefix []byte, key []byte, value []byte) error {
+	return db.Update(func(txn basedb.Txn) error {
+		return txn.Set(prefix, key, value)
+	})
+}
+
+func (db *recordingDB) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	return db.Update(func(txn basedb.Txn) error {
+		for i := 0; i < n; i++ {
+			obj, err := next(i)
+			if err != nil {
+				return err
+			}
+			if err := txn.Set(prefix, obj.Key, obj.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (db *recordingDB) Delete(prefix []byte, key []byte) error {
+	return db.Update(func(txn basedb.Txn) error {
+		return txn.Delete(prefix, key)
+	})
+}
+
+func (db *recordingDB) DeleteByPrefix(prefix []byte) (int, error) {
+	err := db.IDb.GetAll(prefix, func(_ int, obj basedb.Obj) error {
+		db.report.collection(prefix, obj.Key).Deleted++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return db.IDb.DeleteByPrefix(prefix)
+}
+
+func (db *recordingDB) RemoveAllByCollection(prefix []byte) error {
+	_, err := db.DeleteByPrefix(prefix)
+	return err
+}
+
+func (db *recordingDB) Update(fn func(basedb.Txn) error) error {
+	// changes are recorded only if the transaction is committed
+	pending := &DryRunReport{changes: make(map[string]*CollectionChanges)}
+	err := db.IDb.Update(func(txn basedb.Txn) error {
+		return fn(&recordingTxn{Txn: txn, report: pending})
+	})
+	if err != nil {
+		return err
+	}
+	for name, c := range pending.changes {
+		total, ok := db.report.changes[name]
+		if !ok {
+			db.report.changes[name] = c
+			continue
+		}
+		total.Deleted += c.Deleted
+		total.Rewritten += c.Rewritten
+		total.Created += c.Created
+	}
+	return nil
+}
+
+// recordingTxn records the keys that are deleted or written in a transaction
+type recordingTxn struct {
+	basedb.Txn
+	report *DryRunReport
+}
+
+func (txn *recordingTxn) Set(prefix []byte, key []byte, value []byte) error {
+	_, found, err := txn.Txn.Get(prefix, key)
+	if err != nil {
+		return err
+	}
+	if found {
+		txn.report.collection(prefix, key).Rewritten++
+	} else {
+		txn.report.collection(prefix, key).Created++
+	}
+	return txn.Txn.Set(prefix, key, value)
+}
+
+func (txn *recordingTxn) Delete(prefix []byte, key []byte) error {
+	_, found, err := txn.Txn.Get(prefix, key)
+	if err != nil {
+		return err
+	}
+	if found {
+		txn.report.collection(prefix, key).Deleted++
+	}
+	return txn.Txn.Delete(prefix, key)
+}
@@ -8,7 +8,8 @@ import (
 
 // This migration is an Example migration
 var migrationExample1 = Migration{
-	Name: "migration_0_example",
+	Name:   "migration_0_example",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		// Example to clean registry data for specific storage
 		if err := opt.nodeStorage().CleanRegistryData(); err != nil {
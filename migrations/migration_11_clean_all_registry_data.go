@@ -5,7 +5,8 @@ import (
 )
 
 var migrationCleanRegistryData = Migration{
-	Name: "migration_11_clean_all_registry_data",
+	Name:   "migration_11_clean_all_registry_data",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		stores := opt.getRegistryStores()
 		for _, store := range stores {
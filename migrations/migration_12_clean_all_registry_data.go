@@ -5,7 +5,8 @@ import (
 )
 
 var migrationCleanRegistryDataIncludingSignerStorage = Migration{
-	Name: "migration_12_clean_registry_data_including_signer_storage",
+	Name:   "migration_12_clean_registry_data_including_signer_storage",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		stores := opt.getRegistryStores()
 		for _, store := range stores {
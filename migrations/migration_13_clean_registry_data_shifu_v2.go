@@ -5,7 +5,8 @@ import (
 )
 
 var migrationCleanRegistryDataShifuV2 = Migration{
-	Name: "migration_13_clean_registry_data_shifu_v2",
+	Name:   "migration_13_clean_registry_data_shifu_v2",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		stores := opt.getRegistryStores()
 		for _, store := range stores {
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"context"
+
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"go.uber.org/zap"
+
+	qbftstorage "github.com/bloxapp/ssv/ibft/storage"
+)
+
+// migrationCompactDecidedHistory converts the decided history into compact decided records,
+// it is postponed until the compact history is enabled, so it runs once the config is changed
+var migrationCompactDecidedHistory = Migration{
+	Name:   "migration_14_compact_decided_history",
+	Backup: true,
+	Postponed: func(opt Options) bool {
+		return !opt.CompactHistory
+	},
+	Run: func(ctx context.Context, opt Options, key []byte) error {
+		roles := []spectypes.BeaconRole{
+			spectypes.BNRoleAttester,
+			spectypes.BNRoleProposer,
+			spectypes.BNRoleAggregator,
+			spectypes.BNRoleSyncCommittee,
+			spectypes.BNRoleSyncCommitteeContribution,
+		}
+		for _, role := range roles {
+			converted, err := qbftstorage.CompactHistory(opt.Db, opt.Logger, role)
+			if err != nil {
+				return err
+			}
+			opt.Logger.Info("compacted decided history", zap.String("role", role.String()), zap.Int("converted", converted))
+		}
+		return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+	},
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"context"
+)
+
+// migrationEncodeSharesSSZ rewrites the shares that are encoded with gob using the versioned ssz encoding
+var migrationEncodeSharesSSZ = Migration{
+	Name:   "migration_15_encode_shares_ssz",
+	Backup: true,
+	Run: func(ctx context.Context, opt Options, key []byte) error {
+		validatorStorage := opt.validatorStorage()
+		shares, err := validatorStorage.GetAllValidatorShares()
+		if err != nil {
+			return err
+		}
+
+		for _, share := range shares {
+			if err := validatorStorage.SaveValidatorShare(share); err != nil {
+				return err
+			}
+		}
+		return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+	},
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// migrationDefaultFeeRecipient sets the fee recipient of the shares that have none,
+// to the recipient of the owner if it was updated, otherwise to the owner address
+var migrationDefaultFeeRecipient = Migration{
+	Name:   "migration_16_default_fee_recipient",
+	Backup: true,
+	Run: func(ctx context.Context, opt Options, key []byte) error {
+		validatorStorage := opt.validatorStorage()
+		nodeStorage := opt.nodeStorage()
+		shares, err := validatorStorage.GetAllValidatorShares()
+		if err != nil {
+			return err
+		}
+
+		for _, share := range shares {
+			if share.FeeRecipientAddress != (bellatrix.ExecutionAddress{}) {
+				continue
+			}
+			owner := common.HexToAddress(share.OwnerAddress)
+			recipient := owner
+			recipientData, found, err := nodeStorage.GetRecipientData(owner)
+			if err != nil {
+				return err
+			}
+			if found {
+				recipient = recipientData.FeeRecipient
+			}
+			share.FeeRecipientAddress = bellatrix.ExecutionAddress(recipient)
+			if err := validatorStorage.SaveValidatorShare(share); err != nil {
+				return err
+			}
+		}
+		return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+	},
+}
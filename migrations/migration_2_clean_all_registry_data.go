@@ -10,7 +10,8 @@ import (
 
 // This migration is responsible to delete all (exporter, operator) registry data
 var migrationCleanAllRegistryData = Migration{
-	Name: "migration_2_clean_all_registry_data",
+	Name:   "migration_2_clean_all_registry_data",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		// check if deprecated migration (ownerAddrAndOperatorsPKsMigration) was applied
 		fullPath := filepath.Clean(fmt.Sprintf("%s/oa_pks/migration.txt", opt.DbPath))
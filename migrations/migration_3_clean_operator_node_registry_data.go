@@ -7,7 +7,8 @@ import (
 
 // This migration is responsible to delete all (exporter, operator) registry data
 var migrationCleanOperatorNodeRegistryData = Migration{
-	Name: "migration_3_clean_operator_node_registry_data",
+	Name:   "migration_3_clean_operator_node_registry_data",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		storage := opt.nodeStorage()
 		err := storage.CleanRegistryData()
@@ -7,7 +7,8 @@ import (
 )
 
 var migrationCleanExporterRegistryData = Migration{
-	Name: "migration_4_clean_exporter_registry_data",
+	Name:   "migration_4_clean_exporter_registry_data",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		storage := opt.nodeStorage()
 		err := storage.CleanRegistryData()
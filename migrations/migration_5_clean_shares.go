@@ -5,7 +5,8 @@ import (
 )
 
 var migrationCleanValidatorRegistryData = Migration{
-	Name: "migration_5_clean_validator_registry_data",
+	Name:   "migration_5_clean_validator_registry_data",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		stores := opt.getRegistryStores()
 		for _, store := range stores {
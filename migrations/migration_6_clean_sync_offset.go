@@ -5,7 +5,8 @@ import (
 )
 
 var migrationCleanSyncOffset = Migration{
-	Name: "migration_6_clean_sync_offset",
+	Name:   "migration_6_clean_sync_offset",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		nodeStorage := opt.nodeStorage()
 		if err := nodeStorage.CleanRegistryData(); err != nil {
@@ -5,7 +5,8 @@ import (
 )
 
 var migrationCleanOperatorRemovalCorruptions = Migration{
-	Name: "migration_7_clean_operator_removal_corruptions",
+	Name:   "migration_7_clean_operator_removal_corruptions",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		nodeStorage := opt.nodeStorage()
 		if err := nodeStorage.CleanRegistryData(); err != nil {
@@ -5,7 +5,8 @@ import (
 )
 
 var migrationCleanShares = Migration{
-	Name: "migration_8_clean_shares",
+	Name:   "migration_8_clean_shares",
+	Backup: true,
 	Run: func(ctx context.Context, opt Options, key []byte) error {
 		stores := opt.getRegistryStores()
 		for _, store := range stores {
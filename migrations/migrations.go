@@ -3,6 +3,11 @@ package migrations
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -34,6 +39,9 @@ var (
 		migrationCleanRegistryData,
 		migrationCleanRegistryDataIncludingSignerStorage,
 		migrationCleanRegistryDataShifuV2,
+		migrationCompactDecidedHistory,
+		migrationEncodeSharesSSZ,
+		migrationDefaultFeeRecipient,
 	}
 )
 
@@ -49,6 +57,12 @@ type MigrationFunc func(ctx context.Context, opt Options, key []byte) error
 type Migration struct {
 	Name string
 	Run  MigrationFunc
+	// Backup is true if the migration deletes or rewrites data that can't be recovered,
+	// a snapshot of the db is taken before it is executed
+	Backup bool
+	// Postponed returns true if the migration shouldn't be executed yet, e.g. until it is enabled by the config.
+	// a postponed migration is skipped without taking a snapshot
+	Postponed func(opt Options) bool
 }
 
 // Migrations is a slice of named migrations, meant to be executed
@@ -57,10 +71,19 @@ type Migrations []Migration
 
 // Options are configurations for migrations
 type Options struct {
-	Db      basedb.IDb
-	Logger  *zap.Logger
-	DbPath  string
+	Db     basedb.IDb
+	Logger *zap.Logger
+	DbPath string
+	// DbType is the type of the db, the dry run copies the db into a temporary db of the same type
+	DbType  string
 	Network beacon.Network
+	// CompactHistory is true if the decided history is saved as compact decided records
+	CompactHistory bool
+	// BackupDir is the dir of the snapshots that are taken before migrations that require a backup,
+	// snapshots are not taken if it's empty
+	BackupDir string
+	// DryRunDir is the dir of the temporary db copy of the dry run, the OS temp dir is used if it's empty
+	DryRunDir string
 }
 
 func (o Options) getRegistryStores() []eth1.RegistryStore {
@@ -82,21 +105,62 @@ func (o Options) signerStorage() ekm.Storage {
 	return ekm.NewSignerStorage(o.Db, o.Network, o.Logger)
 }
 
+// Status returns the status of the default migrations.
+func Status(opt Options) ([]MigrationStatus, error) {
+	return defaultMigrations.Status(opt)
+}
+
+// MigrationStatus is the status of a migration.
+type MigrationStatus struct {
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+	Backup  bool   `json:"backup"`
+}
+
+// Status returns the status of the migrations by their order.
+func (m Migrations) Status(opt Options) ([]MigrationStatus, error) {
+	res := make([]MigrationStatus, 0, len(m))
+	for _, migration := range m {
+		applied, err := isApplied(opt.Db, migration.Name)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, MigrationStatus{Name: migration.Name, Applied: applied, Backup: migration.Backup})
+	}
+	return res, nil
+}
+
 // Run executes the migrations.
 func (m Migrations) Run(ctx context.Context, opt Options) error {
 	opt.Logger.Info("Running migrations:")
 	count := 0
+	backedUp := false
 	for _, migration := range m {
 		// Skip the migration if it's already completed.
-		obj, _, err := opt.Db.Get(migrationsPrefix, []byte(migration.Name))
+		applied, err := isApplied(opt.Db, migration.Name)
 		if err != nil {
 			return err
 		}
-		if bytes.Equal(obj.Value, migrationCompleted) {
+		if applied {
 			opt.Logger.Debug("migration already applied, skipping", zap.String("name", migration.Name))
 			continue
 		}
 
+		if migration.Postponed != nil && migration.Postponed(opt) {
+			opt.Logger.Debug("migration is postponed", zap.String("name", migration.Name))
+			continue
+		}
+
+		// Take a single snapshot before the first migration that requires a backup.
+		if migration.Backup && !backedUp && len(opt.BackupDir) > 0 {
+			path, err := backup(opt, migration.Name)
+			if err != nil {
+				return errors.Wrapf(err, "could not take a snapshot before migration %q", migration.Name)
+			}
+			backedUp = true
+			opt.Logger.Info("took a db snapshot before migration", zap.String("name", migration.Name), zap.String("path", path))
+		}
+
 		// Execute the migration.
 		err = migration.Run(ctx, opt, []byte(migration.Name))
 		if err != nil {
@@ -111,3 +175,41 @@ func (m Migrations) Run(ctx context.Context, opt Options) error {
 
 	return nil
 }
+
+// isApplied returns true if the migration with the given name is completed.
+func isApplied(db basedb.IDb, name string) (bool, error) {
+	obj, _, err := db.Get(migrationsPrefix, []byte(name))
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(obj.Value, migrationCompleted), nil
+}
+
+// backuper is implemented by dbs that can write a snapshot of their data.
+type backuper interface {
+	Backup(w io.Writer) error
+}
+
+// backup writes a snapshot of the db into a new file in the backup dir, and returns its path.
+func backup(opt Options, name string) (string, error) {
+	db, ok := opt.Db.(backuper)
+	if !ok {
+		return "", errors.New("db doesn't support snapshots")
+	}
+	if err := os.MkdirAll(opt.BackupDir, 0700); err != nil {
+		return "", errors.Wrap(err, "could not create backup dir")
+	}
+	path := filepath.Join(opt.BackupDir, fmt.Sprintf("%s-%d.bak", name, time.Now().Unix()))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", errors.Wrap(err, "could not create backup file")
+	}
+	if err := db.Backup(f); err != nil {
+		_ = f.Close()
+		return "", errors.Wrap(err, "could not backup db")
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Wrap(err, "could not close backup file")
+	}
+	return path, nil
+}
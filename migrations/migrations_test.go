@@ -1,12 +1,21 @@
 package migrations
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
 	"fmt"
 	"os"
 	"path"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/storage/kv"
 	"github.com/pkg/errors"
@@ -31,6 +40,7 @@ func setupOptions(ctx context.Context, t *testing.T) (Options, error) {
 		Db:     db,
 		Logger: zap.L(),
 		DbPath: t.TempDir(),
+		DbType: options.Type,
 	}, nil
 }
 
@@ -153,3 +163,276 @@ func fakeMigration(name string, returnErr error) Migration {
 		},
 	}
 }
+
+func Test_EncodeSharesSSZ(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+
+	share := &types.SSVShare{
+		Share: spectypes.Share{
+			OperatorID:      1,
+			ValidatorPubKey: []byte{1, 2, 3},
+			Quorum:          3,
+		},
+		Metadata: types.Metadata{
+			OwnerAddress: "0x1",
+			Liquidated:   true,
+		},
+	}
+	// save the share with the legacy gob encoding
+	var b bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&b).Encode(share))
+	require.NoError(t, opt.Db.Set([]byte("share-"), share.ValidatorPubKey, b.Bytes()))
+
+	require.NoError(t, Migrations{migrationEncodeSharesSSZ}.Run(ctx, opt))
+
+	obj, found, err := opt.Db.Get([]byte("share-"), share.ValidatorPubKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.False(t, types.IsLegacyShareEncoding(obj.Value))
+	decoded := &types.SSVShare{}
+	require.NoError(t, decoded.Decode(obj.Value))
+	require.Equal(t, share, decoded)
+}
+
+func Test_DefaultFeeRecipient(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+
+	owner := common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F")
+	updatedOwner := common.HexToAddress("0xceefd323dd28a8d9514eddfec45a6c81800a7d49")
+	recipient := common.HexToAddress("0x1")
+	require.NoError(t, opt.nodeStorage().SaveRecipientData(&registrystorage.RecipientData{
+		OwnerAddress: updatedOwner,
+		FeeRecipient: recipient,
+	}))
+	newShare := func(pk byte, owner common.Address, feeRecipient bellatrix.ExecutionAddress) *types.SSVShare {
+		return &types.SSVShare{
+			Share:    spectypes.Share{ValidatorPubKey: []byte{pk}, FeeRecipientAddress: feeRecipient},
+			Metadata: types.Metadata{OwnerAddress: owner.String()},
+		}
+	}
+	validatorStorage := opt.validatorStorage()
+	require.NoError(t, validatorStorage.SaveValidatorShare(newShare(1, owner, bellatrix.ExecutionAddress{})))
+	require.NoError(t, validatorStorage.SaveValidatorShare(newShare(2, updatedOwner, bellatrix.ExecutionAddress{})))
+	require.NoError(t, validatorStorage.SaveValidatorShare(newShare(3, owner, bellatrix.ExecutionAddress{2})))
+
+	require.NoError(t, Migrations{migrationDefaultFeeRecipient}.Run(ctx, opt))
+
+	// the owner address is the default, unless the owner updated its recipient
+	expected := map[byte]bellatrix.ExecutionAddress{
+		1: bellatrix.ExecutionAddress(owner),
+		2: bellatrix.ExecutionAddress(recipient),
+		3: {2},
+	}
+	for pk, feeRecipient := range expected {
+		share, found, err := validatorStorage.GetValidatorShare([]byte{pk})
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, feeRecipient, share.FeeRecipientAddress)
+	}
+}
+
+func Test_Status(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+
+	migrations := Migrations{
+		fakeMigration("first", nil),
+		{Name: "second", Backup: true, Run: fakeMigration("second", nil).Run},
+	}
+	require.NoError(t, Migrations{migrations[0]}.Run(ctx, opt))
+
+	status, err := migrations.Status(opt)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationStatus{
+		{Name: "first", Applied: true},
+		{Name: "second", Applied: false, Backup: true},
+	}, status)
+}
+
+func Test_BackupBeforeDestructiveMigration(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+	opt.BackupDir = t.TempDir()
+	require.NoError(t, opt.Db.Set([]byte("share-"), []byte("pk"), []byte("share")))
+
+	destructive := func(name string) Migration {
+		return Migration{
+			Name:   name,
+			Backup: true,
+			Run: func(ctx context.Context, opt Options, key []byte) error {
+				if _, err := opt.Db.DeleteByPrefix([]byte("share-")); err != nil {
+					return err
+				}
+				return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+			},
+		}
+	}
+	postponed := destructive("postponed")
+	postponed.Postponed = func(opt Options) bool {
+		return true
+	}
+	migrations := Migrations{
+		fakeMigration("not_destructive", nil),
+		postponed,
+		destructive("destructive_1"),
+		destructive("destructive_2"),
+	}
+	require.NoError(t, migrations.Run(ctx, opt))
+
+	// a single snapshot is taken before the first destructive migration that isn't postponed
+	entries, err := os.ReadDir(opt.BackupDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Contains(t, entries[0].Name(), "destructive_1")
+
+	f, err := os.Open(path.Join(opt.BackupDir, entries[0].Name()))
+	require.NoError(t, err)
+	defer f.Close()
+	restored, err := kv.New(basedb.Options{Type: "badger-memory", Logger: zap.L()})
+	require.NoError(t, err)
+	defer restored.Close()
+	require.NoError(t, restored.(*kv.BadgerDb).Restore(f))
+	obj, found, err := restored.Get([]byte("share-"), []byte("pk"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("share"), obj.Value)
+
+	// no snapshot when the only pending migration is postponed
+	require.NoError(t, migrations.Run(ctx, opt))
+	entries, err = os.ReadDir(opt.BackupDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func Test_DryRun(t *testing.T) {
+	ctx := context.Background()
+	opt, err := setupOptions(ctx, t)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, opt.Db.Set([]byte("share-"), []byte{byte(i)}, []byte("share")))
+	}
+	require.NoError(t, opt.Db.Set([]byte("operator-"), []byte("syncOffset"), []byte{1}))
+
+	migrations := Migrations{
+		fakeMigration("applied", nil),
+		{
+			Name:   "clean_shares",
+			Backup: true,
+			Run: func(ctx context.Context, opt Options, key []byte) error {
+				if err := opt.Db.RemoveAllByCollection([]byte("share-")); err != nil {
+					return err
+				}
+				return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+			},
+		},
+		{
+			Name: "rewrite_sync_offset",
+			Run: func(ctx context.Context, opt Options, key []byte) error {
+				return opt.Db.Update(func(txn basedb.Txn) error {
+					if err := txn.Set([]byte("operator-"), []byte("syncOffset"), []byte{2}); err != nil {
+						return err
+					}
+					return txn.Set(migrationsPrefix, key, migrationCompleted)
+				})
+			},
+		},
+		{
+			Name: "postponed",
+			Run: func(ctx context.Context, opt Options, key []byte) error {
+				return nil
+			},
+		},
+		fakeMigration("failed", errors.New("fake error")),
+		fakeMigration("not_executed", nil),
+	}
+	require.NoError(t, Migrations{migrations[0]}.Run(ctx, opt))
+
+	reports, err := migrations.DryRun(ctx, opt)
+	require.NoError(t, err)
+	require.Len(t, reports, 4)
+
+	require.Equal(t, "clean_shares", reports[0].Name)
+	require.True(t, reports[0].Backup)
+	require.True(t, reports[0].Completed)
+	require.Equal(t, []*CollectionChanges{
+		{Collection: "migrations", Created: 1},
+		{Collection: "shares", Deleted: 3},
+	}, reports[0].Changes)
+
+	require.Equal(t, "rewrite_sync_offset", reports[1].Name)
+	require.True(t, reports[1].Completed)
+	require.Equal(t, []*CollectionChanges{
+		{Collection: "migrations", Created: 1},
+		{Collection: "sync-offset", Rewritten: 1},
+	}, reports[1].Changes)
+
+	require.Equal(t, "postponed", reports[2].Name)
+	require.False(t, reports[2].Completed)
+	require.Empty(t, reports[2].Changes)
+
+	// the changes of a failed transaction are not reported
+	require.Equal(t, "failed", reports[3].Name)
+	require.Equal(t, "fake error", reports[3].Error)
+	require.Empty(t, reports[3].Changes)
+
+	// the db is not changed
+	count, err := opt.Db.CountByCollection([]byte("share-"))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+	status, err := migrations.Status(opt)
+	require.NoError(t, err)
+	for _, s := range status {
+		require.Equal(t, s.Name == "applied", s.Applied, s.Name)
+	}
+}
+
+func Test_DryRunDbType(t *testing.T) {
+	ctx := context.Background()
+	db, err := storage.GetStorageFactory(basedb.Options{Type: "bolt-db", Logger: zap.L(), Path: t.TempDir()})
+	require.NoError(t, err)
+	defer db.Close()
+	opt := Options{Db: db, Logger: zap.L(), DbPath: t.TempDir(), DbType: "bolt-db", DryRunDir: t.TempDir()}
+	require.NoError(t, opt.Db.Set([]byte("share-"), []byte{1}, []byte("share")))
+
+	reports, err := Migrations{
+		{
+			Name: "clean_shares",
+			Run: func(ctx context.Context, opt Options, key []byte) error {
+				// the db copy is created in the dry run dir
+				entries, err := os.ReadDir(opt.DryRunDir)
+				if err != nil {
+					return err
+				}
+				if len(entries) != 1 {
+					return errors.Errorf("expected a db copy in the dry run dir, found %d entries", len(entries))
+				}
+				if _, err := opt.Db.DeleteByPrefix([]byte("share-")); err != nil {
+					return err
+				}
+				return opt.Db.Set(migrationsPrefix, key, migrationCompleted)
+			},
+		},
+	}.DryRun(ctx, opt)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.True(t, reports[0].Completed)
+	require.Equal(t, []*CollectionChanges{
+		{Collection: "migrations", Created: 1},
+		{Collection: "shares", Deleted: 1},
+	}, reports[0].Changes)
+
+	count, err := opt.Db.CountByCollection([]byte("share-"))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	// the db copy is removed
+	entries, err := os.ReadDir(opt.DryRunDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
@@ -53,6 +53,7 @@ type dutyController struct {
 	// executor enables to work with a custom execution
 	executor            DutyExecutor
 	fetcher             DutyFetcher
+	beaconClient        beaconprotocol.Beacon
 	validatorController validator.Controller
 	dutyLimit           uint64
 	ticker              slot_ticker.Ticker
@@ -68,6 +69,7 @@ func NewDutyController(opts *ControllerOptions) DutyController {
 		ctx:                 opts.Ctx,
 		ethNetwork:          opts.EthNetwork,
 		fetcher:             fetcher,
+		beaconClient:        opts.BeaconClient,
 		validatorController: opts.ValidatorController,
 		dutyLimit:           opts.DutyLimit,
 		executor:            opts.Executor,
@@ -82,6 +84,8 @@ func (dc *dutyController) Start() {
 	indices := dc.validatorController.GetValidatorsIndices()
 	dc.logger.Debug("warming up indices", zap.Int("count", len(indices)))
 
+	go dc.fetcher.ListenToBeaconEvents(dc.beaconClient.EventsFeed())
+
 	tickerChan := make(chan phase0.Slot, 32)
 	dc.ticker.Subscribe(tickerChan)
 	dc.listenToTicker(tickerChan)
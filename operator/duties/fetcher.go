@@ -3,6 +3,7 @@ package duties
 import (
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -10,6 +11,7 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/patrickmn/go-cache"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async/event"
 	"go.uber.org/zap"
 
 	"github.com/bloxapp/ssv/beacon/goclient"
@@ -32,8 +34,13 @@ type validatorsIndicesFetcher interface {
 // DutyFetcher represents the component that manages duties
 type DutyFetcher interface {
 	GetDuties(slot phase0.Slot) ([]spectypes.Duty, error)
+	// ListenToBeaconEvents invalidates the cached duties once their dependent root changes
+	ListenToBeaconEvents(feed *event.Feed)
 }
 
+// dutiesPrefetchSlots is the number of slots before the end of an epoch, at which the duties of the next epoch are fetched
+const dutiesPrefetchSlots = 4
+
 // newDutyFetcher creates a new instance
 func newDutyFetcher(logger *zap.Logger, beaconClient beacon.Beacon, indicesFetcher validatorsIndicesFetcher, network beacon.Network) DutyFetcher {
 	df := dutyFetcher{
@@ -42,6 +49,8 @@ func newDutyFetcher(logger *zap.Logger, beaconClient beacon.Beacon, indicesFetch
 		beaconClient:   beaconClient,
 		indicesFetcher: indicesFetcher,
 		cache:          cache.New(time.Minute*12, time.Minute*13),
+		dependentRoots: map[phase0.Epoch]beacon.DutiesDependentRoots{},
+		subscriptions:  map[phase0.Epoch]map[string]bool{},
 	}
 	return &df
 }
@@ -54,10 +63,21 @@ type dutyFetcher struct {
 	indicesFetcher validatorsIndicesFetcher
 
 	cache *cache.Cache
+	// fetchLock serializes the fetches of duties
+	fetchLock sync.Mutex
+
+	// dependentRoots are the dependent roots of the cached duties by their epoch,
+	// the duties of an epoch are fetched again once a head event reports other dependent roots
+	dependentRoots map[phase0.Epoch]beacon.DutiesDependentRoots
+	// subscriptions are the committee subnet subscriptions that were submitted by their epoch,
+	// so only new subscriptions are submitted once the duties change
+	subscriptions map[phase0.Epoch]map[string]bool
+	stateLock     sync.Mutex
 }
 
 // GetDuties tries to get slot's duties from cache, if not available in cache it fetches them from beacon
-// the relevant subnets will be subscribed once duties are fetched
+// the relevant subnets will be subscribed once duties are fetched.
+// the duties of the next epoch are prefetched dutiesPrefetchSlots before the end of the epoch
 func (df *dutyFetcher) GetDuties(slot phase0.Slot) ([]spectypes.Duty, error) {
 	var duties []spectypes.Duty
 
@@ -69,7 +89,7 @@ func (df *dutyFetcher) GetDuties(slot phase0.Slot) ([]spectypes.Duty, error) {
 		duties = raw.(cacheEntry).Duties
 	} else {
 		// epoch's duties does not exist in cache -> fetch
-		if err := df.updateDutiesFromBeacon(slot); err != nil {
+		if err := df.updateDutiesFromBeacon(epoch); err != nil {
 			logger.Warn("failed to get duties", zap.Error(err))
 			return nil, err
 		}
@@ -83,12 +103,115 @@ func (df *dutyFetcher) GetDuties(slot phase0.Slot) ([]spectypes.Duty, error) {
 			zap.Duration("duration", time.Since(start)))
 	}
 
+	if uint64(slot)%df.ethNetwork.SlotsPerEpoch() == df.ethNetwork.SlotsPerEpoch()-dutiesPrefetchSlots {
+		go func() {
+			if err := df.updateDutiesFromBeacon(epoch + 1); err != nil {
+				logger.Warn("failed to prefetch duties of the next epoch", zap.Error(err))
+			}
+		}()
+	}
+
 	return duties, nil
 }
 
+// ListenToBeaconEvents listens to the head and chain reorg events of the beacon node
+func (df *dutyFetcher) ListenToBeaconEvents(feed *event.Feed) {
+	cn := make(chan *eth2apiv1.Event, 32)
+	sub := feed.Subscribe(cn)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case e := <-cn:
+			switch data := e.Data.(type) {
+			case *eth2apiv1.HeadEvent:
+				df.onHeadEvent(data)
+			case *eth2apiv1.ChainReorgEvent:
+				df.onChainReorgEvent(data)
+			}
+		case err := <-sub.Err():
+			df.logger.Warn("beacon events subscription error", zap.Error(err))
+			return
+		}
+	}
+}
+
+// onHeadEvent fetches again the duties whose dependent root is not the dependent root of the head.
+// the previous dependent root of a head is the root of the attester duties of its epoch, and the current one
+// is the root of the proposer duties of its epoch and of the attester duties of the next epoch
+func (df *dutyFetcher) onHeadEvent(e *eth2apiv1.HeadEvent) {
+	epoch := df.ethNetwork.EstimatedEpochAtSlot(e.Slot)
+
+	var changed []phase0.Epoch
+	df.stateLock.Lock()
+	if roots, ok := df.dependentRoots[epoch]; ok {
+		if rootChanged(roots.Attester, e.PreviousDutyDependentRoot) || rootChanged(roots.Proposer, e.CurrentDutyDependentRoot) {
+			changed = append(changed, epoch)
+		}
+	}
+	if roots, ok := df.dependentRoots[epoch+1]; ok {
+		if rootChanged(roots.Attester, e.CurrentDutyDependentRoot) {
+			changed = append(changed, epoch+1)
+		}
+	}
+	df.stateLock.Unlock()
+
+	for _, epoch := range changed {
+		df.logger.Debug("duty dependent root changed, fetching duties", zap.Uint64("epoch", uint64(epoch)))
+		if err := df.refetchDuties(epoch); err != nil {
+			df.logger.Warn("failed to fetch duties", zap.Uint64("epoch", uint64(epoch)), zap.Error(err))
+		}
+	}
+}
+
+// rootChanged returns true if the head reports another dependent root, heads without dependent roots are ignored
+func rootChanged(root, headRoot phase0.Root) bool {
+	return headRoot != (phase0.Root{}) && root != headRoot
+}
+
+// onChainReorgEvent invalidates the duties of the epoch and of the next one, if the reorg crossed the epoch boundary
+func (df *dutyFetcher) onChainReorgEvent(e *eth2apiv1.ChainReorgEvent) {
+	if uint64(e.Slot)%df.ethNetwork.SlotsPerEpoch() >= e.Depth {
+		return
+	}
+	epoch := df.ethNetwork.EstimatedEpochAtSlot(e.Slot)
+	df.logger.Debug("chain reorg crossed an epoch boundary",
+		zap.Uint64("slot", uint64(e.Slot)), zap.Uint64("depth", e.Depth), zap.Uint64("epoch", uint64(epoch)))
+	df.invalidateEpoch(epoch)
+	df.invalidateEpoch(epoch + 1)
+}
+
+// invalidateEpoch removes the cached duties of the given epoch, so they will be fetched again
+func (df *dutyFetcher) invalidateEpoch(epoch phase0.Epoch) {
+	firstSlot := uint64(epoch) * df.ethNetwork.SlotsPerEpoch()
+	for i := uint64(0); i < df.ethNetwork.SlotsPerEpoch(); i++ {
+		df.cache.Delete(getDutyCacheKey(phase0.Slot(firstSlot + i)))
+	}
+	df.stateLock.Lock()
+	delete(df.dependentRoots, epoch)
+	df.stateLock.Unlock()
+}
+
 // updateDutiesFromBeacon will be called once in an epoch to update the cache with all the epoch's slots
-func (df *dutyFetcher) updateDutiesFromBeacon(slot phase0.Slot) error {
-	duties, err := df.fetchDuties(slot)
+func (df *dutyFetcher) updateDutiesFromBeacon(epoch phase0.Epoch) error {
+	df.fetchLock.Lock()
+	defer df.fetchLock.Unlock()
+
+	return df.fetchEpochDuties(epoch)
+}
+
+// refetchDuties replaces the cached duties of the given epoch with the duties from beacon
+func (df *dutyFetcher) refetchDuties(epoch phase0.Epoch) error {
+	df.fetchLock.Lock()
+	defer df.fetchLock.Unlock()
+
+	df.invalidateEpoch(epoch)
+	return df.fetchEpochDuties(epoch)
+}
+
+// fetchEpochDuties fetches the duties of the given epoch, it must be called with the fetch lock held
+func (df *dutyFetcher) fetchEpochDuties(epoch phase0.Epoch) error {
+	duties, roots, err := df.fetchDuties(epoch)
 	if err != nil {
 		return errors.Wrap(err, "failed to get duties from beacon")
 	}
@@ -102,28 +225,36 @@ func (df *dutyFetcher) updateDutiesFromBeacon(slot phase0.Slot) error {
 	}
 	df.logger.Debug("got duties", zap.Int("count", len(duties)), zap.Any("duties", toPrint))
 
-	if err := df.processFetchedDuties(duties); err != nil {
+	df.stateLock.Lock()
+	df.dependentRoots[epoch] = roots
+	for e := range df.dependentRoots {
+		if e+2 < epoch {
+			delete(df.dependentRoots, e)
+		}
+	}
+	df.stateLock.Unlock()
+
+	if err := df.processFetchedDuties(epoch, duties); err != nil {
 		return errors.Wrap(err, "failed to process fetched duties")
 	}
 
 	return nil
 }
 
-// fetchDuties fetches duties for the epoch of the given slot
-func (df *dutyFetcher) fetchDuties(slot phase0.Slot) ([]*spectypes.Duty, error) {
+// fetchDuties fetches duties for the given epoch
+func (df *dutyFetcher) fetchDuties(epoch phase0.Epoch) ([]*spectypes.Duty, beacon.DutiesDependentRoots, error) {
 	if indices := df.indicesFetcher.GetValidatorsIndices(); len(indices) > 0 {
 		df.logger.Debug("got indices for existing validators",
 			zap.Int("count", len(indices)), zap.Any("indices", indices))
-		epoch := df.ethNetwork.EstimatedEpochAtSlot(slot)
-		results, err := df.beaconClient.GetDuties(epoch, indices)
-		return results, err
+		return df.beaconClient.GetDuties(epoch, indices)
 	}
 	df.logger.Debug("no indices, duties won't be fetched")
-	return []*spectypes.Duty{}, nil
+	return []*spectypes.Duty{}, beacon.DutiesDependentRoots{}, nil
 }
 
-// processFetchedDuties loop over fetched duties and process them
-func (df *dutyFetcher) processFetchedDuties(fetchedDuties []*spectypes.Duty) error {
+// processFetchedDuties loop over fetched duties and process them,
+// only the subscriptions that weren't submitted for the epoch are submitted
+func (df *dutyFetcher) processFetchedDuties(epoch phase0.Epoch, fetchedDuties []*spectypes.Duty) error {
 	if len(fetchedDuties) > 0 {
 		var subscriptions []*eth2apiv1.BeaconCommitteeSubscription
 		var syncCommitteeSubscriptions []*eth2apiv1.SyncCommitteeSubscription
@@ -132,26 +263,66 @@ func (df *dutyFetcher) processFetchedDuties(fetchedDuties []*spectypes.Duty) err
 		for _, duty := range fetchedDuties {
 			df.fillEntry(entries, duty)
 			if duty.Type == spectypes.BNRoleSyncCommittee {
-				syncCommitteeSubscriptions = append(syncCommitteeSubscriptions, df.toSyncCommitteeSubscription(duty))
+				subscription := df.toSyncCommitteeSubscription(duty)
+				if df.isNewSubscription(epoch, fmt.Sprintf("sync-%d-%v-%d", subscription.ValidatorIndex, subscription.SyncCommitteeIndices, subscription.UntilEpoch)) {
+					syncCommitteeSubscriptions = append(syncCommitteeSubscriptions, subscription)
+				}
 			} else {
-				subscriptions = append(subscriptions, toSubscription(duty))
+				subscription := toSubscription(duty)
+				if df.isNewSubscription(epoch, fmt.Sprintf("%+v", *subscription)) {
+					subscriptions = append(subscriptions, subscription)
+				}
 			}
 		}
 
 		df.populateCache(entries)
 
-		if err := df.beaconClient.SubscribeToCommitteeSubnet(subscriptions); err != nil {
-			df.logger.Warn("failed to subscribe committee to subnet", zap.Error(err))
+		if len(subscriptions) > 0 {
+			if err := df.beaconClient.SubscribeToCommitteeSubnet(subscriptions); err != nil {
+				df.logger.Warn("failed to subscribe committee to subnet", zap.Error(err))
+				df.forgetSubscriptions(epoch)
+			}
 		}
 		if len(syncCommitteeSubscriptions) > 0 {
 			if err := df.beaconClient.SubmitSyncCommitteeSubscriptions(syncCommitteeSubscriptions); err != nil {
 				df.logger.Warn("failed to subscribe sync committee to subnet", zap.Error(err))
+				df.forgetSubscriptions(epoch)
 			}
 		}
 	}
 	return nil
 }
 
+// isNewSubscription returns true if the subscription with the given key wasn't submitted for the epoch, and records it
+func (df *dutyFetcher) isNewSubscription(epoch phase0.Epoch, key string) bool {
+	df.stateLock.Lock()
+	defer df.stateLock.Unlock()
+
+	submitted, ok := df.subscriptions[epoch]
+	if !ok {
+		submitted = map[string]bool{}
+		df.subscriptions[epoch] = submitted
+		for e := range df.subscriptions {
+			if e+2 < epoch {
+				delete(df.subscriptions, e)
+			}
+		}
+	}
+	if submitted[key] {
+		return false
+	}
+	submitted[key] = true
+	return true
+}
+
+// forgetSubscriptions removes the submitted subscriptions of the epoch after a failed submission, so they are submitted again
+func (df *dutyFetcher) forgetSubscriptions(epoch phase0.Epoch) {
+	df.stateLock.Lock()
+	defer df.stateLock.Unlock()
+
+	delete(df.subscriptions, epoch)
+}
+
 // fillEntry adds the given duty on the relevant slot
 func (df *dutyFetcher) fillEntry(entries map[phase0.Slot]cacheEntry, duty *spectypes.Duty) {
 	entry, slotExist := entries[duty.Slot]
@@ -3,10 +3,13 @@ package duties
 import (
 	"errors"
 	"testing"
+	"time"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/golang/mock/gomock"
+	"github.com/patrickmn/go-cache"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
@@ -118,6 +121,106 @@ func TestDutyFetcher_AddMissingSlots(t *testing.T) {
 	}
 }
 
+func TestDutyFetcher_DependentRoots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// epoch 29691 is slots 950112-950143
+	attesterDuty := func(slot phase0.Slot, index phase0.ValidatorIndex) *spectypes.Duty {
+		return &spectypes.Duty{Type: spectypes.BNRoleAttester, Slot: slot, ValidatorIndex: index}
+	}
+	client := beacon.NewMockBeacon(ctrl)
+	gomock.InOrder(
+		client.EXPECT().GetDuties(phase0.Epoch(29691), gomock.Any()).
+			Return([]*spectypes.Duty{attesterDuty(950112, 1), attesterDuty(950120, 2)}, beacon.DutiesDependentRoots{Attester: phase0.Root{1}, Proposer: phase0.Root{2}}, nil),
+		// validator 1 moved to another slot after the attester dependent root changed
+		client.EXPECT().GetDuties(phase0.Epoch(29691), gomock.Any()).
+			Return([]*spectypes.Duty{attesterDuty(950113, 1), attesterDuty(950120, 2)}, beacon.DutiesDependentRoots{Attester: phase0.Root{3}, Proposer: phase0.Root{2}}, nil),
+	)
+	var submitted [][]*eth2apiv1.BeaconCommitteeSubscription
+	client.EXPECT().SubscribeToCommitteeSubnet(gomock.Any()).DoAndReturn(func(subscriptions []*eth2apiv1.BeaconCommitteeSubscription) error {
+		submitted = append(submitted, subscriptions)
+		return nil
+	}).AnyTimes()
+	indicesFetcher := mocks.NewMockvalidatorsIndicesFetcher(ctrl)
+	indicesFetcher.EXPECT().GetValidatorsIndices().Return([]phase0.ValidatorIndex{1, 2}).AnyTimes()
+	df := newDutyFetcher(zap.L(), client, indicesFetcher, beacon.NewNetwork(core.PraterNetwork, 0)).(*dutyFetcher)
+
+	duties, err := df.GetDuties(950112)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	require.Len(t, submitted, 1)
+	require.Len(t, submitted[0], 2)
+
+	// the dependent roots didn't change
+	df.onHeadEvent(&eth2apiv1.HeadEvent{Slot: 950113, PreviousDutyDependentRoot: phase0.Root{1}, CurrentDutyDependentRoot: phase0.Root{2}})
+	// heads without dependent roots are ignored
+	df.onHeadEvent(&eth2apiv1.HeadEvent{Slot: 950113})
+
+	// the duties are replaced, and only the new subscription is submitted
+	df.onHeadEvent(&eth2apiv1.HeadEvent{Slot: 950113, PreviousDutyDependentRoot: phase0.Root{3}, CurrentDutyDependentRoot: phase0.Root{2}})
+	duties, err = df.GetDuties(950112)
+	require.NoError(t, err)
+	require.Len(t, duties, 0)
+	duties, err = df.GetDuties(950113)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	require.Len(t, submitted, 2)
+	require.Len(t, submitted[1], 1)
+	require.Equal(t, phase0.Slot(950113), submitted[1][0].Slot)
+}
+
+func TestDutyFetcher_PrefetchNextEpoch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := beacon.NewMockBeacon(ctrl)
+	client.EXPECT().GetDuties(phase0.Epoch(29691), gomock.Any()).
+		Return([]*spectypes.Duty{{Type: spectypes.BNRoleAttester, Slot: 950140}}, beacon.DutiesDependentRoots{Attester: phase0.Root{1}, Proposer: phase0.Root{2}}, nil)
+	client.EXPECT().GetDuties(phase0.Epoch(29692), gomock.Any()).
+		Return([]*spectypes.Duty{{Type: spectypes.BNRoleAttester, Slot: 950150}}, beacon.DutiesDependentRoots{Attester: phase0.Root{2}, Proposer: phase0.Root{4}}, nil)
+	client.EXPECT().SubscribeToCommitteeSubnet(gomock.Any()).Return(nil).Times(2)
+	indicesFetcher := mocks.NewMockvalidatorsIndicesFetcher(ctrl)
+	indicesFetcher.EXPECT().GetValidatorsIndices().Return([]phase0.ValidatorIndex{1}).AnyTimes()
+	df := newDutyFetcher(zap.L(), client, indicesFetcher, beacon.NewNetwork(core.PraterNetwork, 0)).(*dutyFetcher)
+
+	// the duties of the next epoch are fetched 4 slots before its start
+	duties, err := df.GetDuties(950140)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+	require.Eventually(t, func() bool {
+		_, ok := df.cache.Get(getDutyCacheKey(950150))
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	duties, err = df.GetDuties(950150)
+	require.NoError(t, err)
+	require.Len(t, duties, 1)
+}
+
+func TestDutyFetcher_ChainReorg(t *testing.T) {
+	df := &dutyFetcher{
+		logger:         zap.L(),
+		ethNetwork:     beacon.NewNetwork(core.PraterNetwork, 0),
+		cache:          cache.New(time.Minute, time.Minute),
+		dependentRoots: map[phase0.Epoch]beacon.DutiesDependentRoots{},
+	}
+	// epoch 29691 is slots 950112-950143
+	for slot := phase0.Slot(950112); slot < 950176; slot++ {
+		df.cache.SetDefault(getDutyCacheKey(slot), cacheEntry{})
+	}
+	cached := func(slot phase0.Slot) bool {
+		_, ok := df.cache.Get(getDutyCacheKey(slot))
+		return ok
+	}
+
+	df.onChainReorgEvent(&eth2apiv1.ChainReorgEvent{Slot: 950146, Depth: 2})
+	require.True(t, cached(950144))
+
+	df.onChainReorgEvent(&eth2apiv1.ChainReorgEvent{Slot: 950113, Depth: 2})
+	require.False(t, cached(950112))
+	require.False(t, cached(950175))
+}
+
 func createIndexFetcher(ctrl *gomock.Controller, result []phase0.ValidatorIndex) *mocks.MockvalidatorsIndicesFetcher {
 	indexFetcher := mocks.NewMockvalidatorsIndicesFetcher(ctrl)
 	indexFetcher.EXPECT().GetValidatorsIndices().Return(result).Times(1)
@@ -127,7 +230,7 @@ func createIndexFetcher(ctrl *gomock.Controller, result []phase0.ValidatorIndex)
 
 func createBeaconDutiesClient(ctrl *gomock.Controller, result []*spectypes.Duty, err error) *beacon.MockBeacon {
 	client := beacon.NewMockBeacon(ctrl)
-	client.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(result, err).MaxTimes(1)
+	client.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(result, beacon.DutiesDependentRoots{}, err).MaxTimes(1)
 	client.EXPECT().SubscribeToCommitteeSubnet(gomock.Any()).Return(nil).MaxTimes(1)
 
 	return client
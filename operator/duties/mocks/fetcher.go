@@ -10,6 +10,7 @@ import (
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	types "github.com/bloxapp/ssv-spec/types"
 	gomock "github.com/golang/mock/gomock"
+	event "github.com/prysmaticlabs/prysm/async/event"
 )
 
 // MockvalidatorsIndicesFetcher is a mock of validatorsIndicesFetcher interface.
@@ -86,3 +87,15 @@ func (mr *MockDutyFetcherMockRecorder) GetDuties(slot interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuties", reflect.TypeOf((*MockDutyFetcher)(nil).GetDuties), slot)
 }
+
+// ListenToBeaconEvents mocks base method.
+func (m *MockDutyFetcher) ListenToBeaconEvents(feed *event.Feed) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListenToBeaconEvents", feed)
+}
+
+// ListenToBeaconEvents indicates an expected call of ListenToBeaconEvents.
+func (mr *MockDutyFetcherMockRecorder) ListenToBeaconEvents(feed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenToBeaconEvents", reflect.TypeOf((*MockDutyFetcher)(nil).ListenToBeaconEvents), feed)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"strings"
 	"sync/atomic"
 
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
@@ -16,7 +15,6 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/pkg/errors"
 )
 
 //go:generate mockgen -package=mocks -destination=./mocks/controller.go -source=./controller.go
@@ -114,15 +112,10 @@ func (rc *recipientController) listenToTicker(slots chan phase0.Slot) {
 	}
 }
 
+// toProposalPreparation sets the fee recipient of the share, which is updated by the owner's registry events
 func toProposalPreparation(m map[phase0.ValidatorIndex]bellatrix.ExecutionAddress, share *types.SSVShare) error {
 	if share.HasBeaconMetadata() {
-		var pubkey [20]byte
-		pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(share.OwnerAddress, "0x"))
-		if err != nil {
-			return errors.Wrap(err, "failed to decode address")
-		}
-		copy(pubkey[:], pubKeyBytes)
-		m[share.BeaconMetadata.Index] = pubkey
+		m[share.BeaconMetadata.Index] = share.FeeRecipientAddress
 		return nil
 	}
 	return fmt.Errorf("missing meta data for pk %s", hex.EncodeToString(share.ValidatorPubKey))
@@ -55,6 +55,10 @@ func TestSubmitProposal(t *testing.T) {
 		var wg sync.WaitGroup
 		client := beacon.NewMockBeacon(ctrl)
 		client.EXPECT().SubmitProposalPreparation(gomock.Any()).DoAndReturn(func(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
+			// the fee recipients of the shares are submitted rather than their owners
+			for index, recipient := range feeRecipients {
+				require.Equal(t, feeRecipient(int(index)), recipient)
+			}
 			wg.Done()
 			return nil
 		}).MinTimes(numberOfRequests).MaxTimes(numberOfRequests) // call first time and on the first slot of epoch. each time should be 2 request as we have two batches
@@ -120,6 +124,12 @@ func createStorage(t *testing.T) (basedb.IDb, validator.ICollection) {
 	})
 }
 
+func feeRecipient(index int) bellatrix.ExecutionAddress {
+	var recipient bellatrix.ExecutionAddress
+	copy(recipient[:], fmt.Sprintf("recipient-%d", index))
+	return recipient
+}
+
 func populateStorage(t *testing.T, storage validator.ICollection, operatorKey string) {
 	createShare := func(index int, operatorKey string) *types.SSVShare {
 		ownerAddr := fmt.Sprintf("%d", index)
@@ -127,7 +137,10 @@ func populateStorage(t *testing.T, storage validator.ICollection, operatorKey st
 		copy(ownerAddrByte[:], ownerAddr)
 
 		return &types.SSVShare{
-			Share: spectypes.Share{ValidatorPubKey: []byte(fmt.Sprintf("pk%d", index))},
+			Share: spectypes.Share{
+				ValidatorPubKey:     []byte(fmt.Sprintf("pk%d", index)),
+				FeeRecipientAddress: feeRecipient(index),
+			},
 			Metadata: types.Metadata{
 				BeaconMetadata: &beacon.ValidatorMetadata{
 					Index: phase0.ValidatorIndex(index),
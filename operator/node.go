@@ -158,6 +158,7 @@ func (n *operatorNode) Start() error {
 	n.validatorsCtrl.StartValidators()
 	go n.net.UpdateSubnets()
 	go n.validatorsCtrl.UpdateValidatorMetaDataLoop()
+	go n.validatorsCtrl.PruneHistoryLoop()
 	go n.listenForCurrentSlot()
 	go n.reportOperators()
 
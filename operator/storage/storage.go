@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
@@ -25,24 +26,32 @@ type Storage interface {
 	eth1.SyncOffsetStorage
 	registry.RegistryStore
 	registrystorage.OperatorsCollection
+	registrystorage.RecipientsCollection
 
 	GetPrivateKey() (*rsa.PrivateKey, bool, error)
 	SetupPrivateKey(generateIfNone bool, operatorKeyBase64 string) error
+	SetupKeystorePrivateKey(skPem []byte) error
+	SetPrivateKey(sk *rsa.PrivateKey)
 }
 
 type storage struct {
 	db     basedb.IDb
 	logger *zap.Logger
 
-	operatorStore registrystorage.OperatorsCollection
+	// privateKey is set when the operator key is decrypted from a keystore, it is never persisted
+	privateKey *rsa.PrivateKey
+
+	operatorStore   registrystorage.OperatorsCollection
+	recipientsStore registrystorage.RecipientsCollection
 }
 
 // NewNodeStorage creates a new instance of Storage
 func NewNodeStorage(db basedb.IDb, logger *zap.Logger) Storage {
 	return &storage{
-		db:            db,
-		logger:        logger,
-		operatorStore: registrystorage.NewOperatorsStorage(db, logger, storagePrefix),
+		db:              db,
+		logger:          logger,
+		operatorStore:   registrystorage.NewOperatorsStorage(db, logger, storagePrefix),
+		recipientsStore: registrystorage.NewRecipientsStorage(db, logger, storagePrefix),
 	}
 }
 
@@ -70,6 +79,22 @@ func (s *storage) GetOperatorsPrefix() []byte {
 	return s.operatorStore.GetOperatorsPrefix()
 }
 
+func (s *storage) GetRecipientData(owner common.Address) (*registrystorage.RecipientData, bool, error) {
+	return s.recipientsStore.GetRecipientData(owner)
+}
+
+func (s *storage) SaveRecipientData(recipientData *registrystorage.RecipientData) error {
+	return s.recipientsStore.SaveRecipientData(recipientData)
+}
+
+func (s *storage) DeleteRecipientData(owner common.Address) error {
+	return s.recipientsStore.DeleteRecipientData(owner)
+}
+
+func (s *storage) GetRecipientsPrefix() []byte {
+	return s.recipientsStore.GetRecipientsPrefix()
+}
+
 func (s *storage) CleanRegistryData() error {
 	err := s.cleanSyncOffset()
 	if err != nil {
@@ -80,6 +105,11 @@ func (s *storage) CleanRegistryData() error {
 	if err != nil {
 		return errors.Wrap(err, "could not clean operators")
 	}
+
+	err = s.cleanRecipients()
+	if err != nil {
+		return errors.Wrap(err, "could not clean recipients")
+	}
 	return nil
 }
 
@@ -97,6 +127,11 @@ func (s *storage) cleanOperators() error {
 	return s.db.RemoveAllByCollection(append(storagePrefix, operatorsPrefix...))
 }
 
+func (s *storage) cleanRecipients() error {
+	recipientsPrefix := s.GetRecipientsPrefix()
+	return s.db.RemoveAllByCollection(append(storagePrefix, recipientsPrefix...))
+}
+
 // GetSyncOffset returns the offset
 func (s *storage) GetSyncOffset() (*eth1.SyncOffset, bool, error) {
 	obj, found, err := s.db.Get(storagePrefix, syncOffsetKey)
@@ -113,6 +148,9 @@ func (s *storage) GetSyncOffset() (*eth1.SyncOffset, bool, error) {
 
 // GetPrivateKey return rsa private key
 func (s *storage) GetPrivateKey() (*rsa.PrivateKey, bool, error) {
+	if s.privateKey != nil {
+		return s.privateKey, true, nil
+	}
 	obj, found, err := s.db.Get(storagePrefix, []byte("private-key"))
 	if err != nil {
 		return nil, false, err
@@ -155,6 +193,42 @@ func (s *storage) SetupPrivateKey(generateIfNone bool, operatorKeyBase64 string)
 	return nil
 }
 
+// SetupKeystorePrivateKey sets the operator private key decrypted from a keystore, the key is kept in memory only.
+// a plaintext key that was previously saved in db is removed, as long as it matches the given key
+func (s *storage) SetupKeystorePrivateKey(skPem []byte) error {
+	sk, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse keystore private key")
+	}
+
+	storedSk, found, err := s.GetPrivateKey()
+	if err != nil {
+		return errors.Wrap(err, "failed to get stored operator private key")
+	}
+	if found {
+		if !storedSk.Equal(sk) {
+			return errors.New("keystore private key does not match the operator private key stored in db")
+		}
+		if err := s.db.Delete(storagePrefix, []byte("private-key")); err != nil {
+			return errors.Wrap(err, "failed to remove plaintext operator private key")
+		}
+		s.logger.Info("removed plaintext operator private key from db")
+	}
+	s.privateKey = sk
+
+	operatorPublicKey, err := rsaencryption.ExtractPublicKey(sk)
+	if err != nil {
+		return errors.Wrap(err, "failed to extract operator public key")
+	}
+	s.logger.Info("setup operator privateKey from keystore is DONE!", zap.Any("public-key", operatorPublicKey))
+	return nil
+}
+
+// SetPrivateKey sets the operator private key in memory only, without changing the db
+func (s *storage) SetPrivateKey(sk *rsa.PrivateKey) {
+	s.privateKey = sk
+}
+
 // validateKey validate provided and exist key. save if needed.
 func (s *storage) validateKey(generateIfNone bool, operatorKey string) error {
 	// check if passed new key. if so, save new key (force to always save key when provided)
@@ -192,3 +192,65 @@ func TestStorage_SaveAndGetSyncOffset(t *testing.T) {
 	require.NoError(t, err)
 	require.Zero(t, offset.Cmp(o))
 }
+
+func TestSetupKeystorePrivateKey(t *testing.T) {
+	logger := zap.L()
+	skByte, err := base64.StdEncoding.DecodeString(skPem)
+	require.NoError(t, err)
+
+	t.Run("removes matching plaintext key", func(t *testing.T) {
+		db, err := ssvstorage.GetStorageFactory(basedb.Options{Type: "badger-memory", Logger: logger})
+		require.NoError(t, err)
+		defer db.Close()
+		s := NewNodeStorage(db, logger)
+		require.NoError(t, s.SetupPrivateKey(false, skPem))
+
+		require.NoError(t, s.SetupKeystorePrivateKey(skByte))
+		_, found, err := db.Get(storagePrefix, []byte("private-key"))
+		require.NoError(t, err)
+		require.False(t, found)
+
+		sk, found, err := s.GetPrivateKey()
+		require.NoError(t, err)
+		require.True(t, found)
+		operatorPublicKey, err := rsaencryption.ExtractPublicKey(sk)
+		require.NoError(t, err)
+		require.Equal(t, pkPem, operatorPublicKey)
+	})
+
+	t.Run("refuses mismatching plaintext key", func(t *testing.T) {
+		db, err := ssvstorage.GetStorageFactory(basedb.Options{Type: "badger-memory", Logger: logger})
+		require.NoError(t, err)
+		defer db.Close()
+		s := NewNodeStorage(db, logger)
+		require.NoError(t, s.SetupPrivateKey(false, skPem2))
+
+		require.EqualError(t, s.SetupKeystorePrivateKey(skByte), "keystore private key does not match the operator private key stored in db")
+		_, found, err := db.Get(storagePrefix, []byte("private-key"))
+		require.NoError(t, err)
+		require.True(t, found)
+	})
+}
+
+func TestSetPrivateKey(t *testing.T) {
+	logger := zap.L()
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{Type: "badger-memory", Logger: logger})
+	require.NoError(t, err)
+	defer db.Close()
+	s := NewNodeStorage(db, logger)
+
+	skByte, err := base64.StdEncoding.DecodeString(skPem)
+	require.NoError(t, err)
+	sk, err := rsaencryption.ConvertPemToPrivateKey(string(skByte))
+	require.NoError(t, err)
+	s.SetPrivateKey(sk)
+
+	stored, found, err := s.GetPrivateKey()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.True(t, sk.Equal(stored))
+	// the key isn't saved in the db
+	_, found, err = db.Get(storagePrefix, []byte("private-key"))
+	require.NoError(t, err)
+	require.False(t, found)
+}
@@ -12,10 +12,12 @@ import (
 
 	"github.com/bloxapp/ssv/protocol/v2/sync/handlers"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 	specssv "github.com/bloxapp/ssv-spec/ssv"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"go.uber.org/zap"
@@ -35,7 +37,6 @@ import (
 	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
 	"github.com/bloxapp/ssv/protocol/v2/ssv/validator"
 	"github.com/bloxapp/ssv/protocol/v2/types"
-	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 	"github.com/bloxapp/ssv/utils/tasks"
 )
@@ -67,15 +68,22 @@ type ControllerOptions struct {
 	Beacon                     beaconprotocol.Beacon
 	ShareEncryptionKeyProvider ShareEncryptionKeyProvider
 	CleanRegistryData          bool
-	FullNode                   bool `yaml:"FullNode" env:"FULLNODE" env-default:"false" env-description:"Save decided history rather than just highest messages"`
-	Exporter                   bool `yaml:"Exporter" env:"EXPORTER" env-default:"false" env-description:""`
+	FullNode                   bool                     `yaml:"FullNode" env:"FULLNODE" env-default:"false" env-description:"Save decided history rather than just highest messages"`
+	Exporter                   bool                     `yaml:"Exporter" env:"EXPORTER" env-default:"false" env-description:""`
+	CompactHistory             bool                     `yaml:"CompactHistory" env:"COMPACT_HISTORY" env-default:"false" env-description:"Save decided history as compact decided records rather than whole instances"`
+	HistoryRetention           storage.RetentionOptions `yaml:"HistoryRetention"`
 	KeyManager                 spectypes.KeyManager
 	OperatorPubKey             string
-	RegistryStorage            registrystorage.OperatorsCollection
+	RegistryStorage            RegistryStorage
 	ForkVersion                forksprotocol.ForkVersion
 	NewDecidedHandler          qbftcontroller.NewDecidedHandler
 	DutyRoles                  []spectypes.BeaconRole
 
+	// doppelganger protection flags, validators are held back until neither this operator nor the validator
+	// outside of its committee were seen signing for DoppelgangerEpochs epochs
+	DoppelgangerProtection bool   `yaml:"DoppelgangerProtection" env:"DOPPELGANGER_PROTECTION" env-default:"false" env-description:"Hold back validators until they weren't seen on the network for DoppelgangerEpochs epochs"`
+	DoppelgangerEpochs     uint64 `yaml:"DoppelgangerEpochs" env:"DOPPELGANGER_EPOCHS" env-default:"2" env-description:"Number of epochs in which validators must not be seen on the network before they start"`
+
 	// worker flags
 	WorkersCount    int `yaml:"MsgWorkersCount" env:"MSG_WORKERS_COUNT" env-default:"4096" env-description:"Number of goroutines to use for message workers"`
 	QueueBufferSize int `yaml:"MsgWorkerBufferSize" env:"MSG_WORKER_BUFFER_SIZE" env-default:"1024" env-description:"Buffer size for message workers"`
@@ -89,6 +97,7 @@ type Controller interface {
 	GetValidatorsIndices() []phase0.ValidatorIndex
 	GetValidator(pubKey string) (*validator.Validator, bool)
 	UpdateValidatorMetaDataLoop()
+	PruneHistoryLoop()
 	StartNetworkHandlers()
 	Eth1EventHandler(ongoingSync bool) eth1.SyncEventHandler
 	GetAllValidatorShares() ([]*types.SSVShare, error)
@@ -104,7 +113,7 @@ type Controller interface {
 type controller struct {
 	context        context.Context
 	collection     ICollection
-	storage        registrystorage.OperatorsCollection
+	storage        RegistryStorage
 	ibftStorageMap *storage.QBFTStores
 	logger         *zap.Logger
 	beacon         beaconprotocol.Beacon
@@ -119,6 +128,9 @@ type controller struct {
 	metadataUpdateQueue    utilsprotocol.Queue
 	metadataUpdateInterval time.Duration
 
+	ethNetwork       beaconprotocol.Network
+	historyRetention storage.RetentionOptions
+
 	operatorsIDs  *sync.Map
 	network       network.P2PNetwork
 	forkVersion   forksprotocol.ForkVersion
@@ -129,6 +141,11 @@ type controller struct {
 	// messages with identical public key and role are processed one at a time.
 	nonCommitteeLocks map[spectypes.MessageID]*sync.Mutex
 	nonCommitteeMutex sync.Mutex
+
+	// doppelganger is nil when doppelganger protection is disabled
+	doppelganger *doppelgangerDetector
+
+	exitRequests ExitRequests
 }
 
 // NewController creates a new validator controller instance
@@ -139,12 +156,16 @@ func NewController(options ControllerOptions) Controller {
 	})
 
 	options.Logger.Debug("CreatingController", zap.Bool("full_node", options.FullNode))
+	newStore := storage.New
+	if options.CompactHistory {
+		newStore = storage.NewCompact
+	}
 	storageMap := storage.NewStores()
-	storageMap.Add(spectypes.BNRoleAttester, storage.New(options.DB, options.Logger, spectypes.BNRoleAttester.String(), options.ForkVersion))
-	storageMap.Add(spectypes.BNRoleProposer, storage.New(options.DB, options.Logger, spectypes.BNRoleProposer.String(), options.ForkVersion))
-	storageMap.Add(spectypes.BNRoleAggregator, storage.New(options.DB, options.Logger, spectypes.BNRoleAggregator.String(), options.ForkVersion))
-	storageMap.Add(spectypes.BNRoleSyncCommittee, storage.New(options.DB, options.Logger, spectypes.BNRoleSyncCommittee.String(), options.ForkVersion))
-	storageMap.Add(spectypes.BNRoleSyncCommitteeContribution, storage.New(options.DB, options.Logger, spectypes.BNRoleSyncCommitteeContribution.String(), options.ForkVersion))
+	storageMap.Add(spectypes.BNRoleAttester, newStore(options.DB, options.Logger, spectypes.BNRoleAttester.String(), options.ForkVersion))
+	storageMap.Add(spectypes.BNRoleProposer, newStore(options.DB, options.Logger, spectypes.BNRoleProposer.String(), options.ForkVersion))
+	storageMap.Add(spectypes.BNRoleAggregator, newStore(options.DB, options.Logger, spectypes.BNRoleAggregator.String(), options.ForkVersion))
+	storageMap.Add(spectypes.BNRoleSyncCommittee, newStore(options.DB, options.Logger, spectypes.BNRoleSyncCommittee.String(), options.ForkVersion))
+	storageMap.Add(spectypes.BNRoleSyncCommitteeContribution, newStore(options.DB, options.Logger, spectypes.BNRoleSyncCommitteeContribution.String(), options.ForkVersion))
 
 	// lookup in a map that holds all relevant operators
 	operatorsIDs := &sync.Map{}
@@ -190,12 +211,21 @@ func NewController(options ControllerOptions) Controller {
 		metadataUpdateQueue:    tasks.NewExecutionQueue(10 * time.Millisecond),
 		metadataUpdateInterval: options.MetadataUpdateInterval,
 
+		ethNetwork:       options.ETHNetwork,
+		historyRetention: options.HistoryRetention,
+
 		operatorsIDs: operatorsIDs,
 
 		messageRouter: newMessageRouter(options.Logger, msgID),
 		messageWorker: worker.NewWorker(workerCfg),
 
 		nonCommitteeLocks: make(map[spectypes.MessageID]*sync.Mutex),
+
+		exitRequests: NewExitRequests(options.DB),
+	}
+
+	if options.DoppelgangerProtection {
+		ctrl.doppelganger = newDoppelgangerDetector(ctrl.logger, options.Beacon, options.DoppelgangerEpochs, ctrl.onDoppelgangerPassed)
 	}
 
 	if err := ctrl.initShares(options); err != nil {
@@ -269,6 +299,10 @@ func (c *controller) handleRouterMessages() {
 			pk := msg.GetID().GetPubKey()
 			hexPK := hex.EncodeToString(pk)
 			if v, ok := c.validatorsMap.GetValidator(hexPK); ok {
+				if c.isObserved(v, &msg) {
+					c.observeDoppelganger(v, &msg)
+					continue
+				}
 				v.HandleMessage(&msg)
 			} else {
 				if msg.MsgType != spectypes.SSVConsensusMsgType {
@@ -358,11 +392,15 @@ func (c *controller) StartValidators() {
 	if err != nil {
 		c.logger.Fatal("failed to get validators shares", zap.Error(err))
 	}
+	if c.doppelganger != nil {
+		go c.doppelganger.run(c.context, c.ethNetwork)
+	}
 	if len(shares) == 0 {
 		c.logger.Info("could not find validators")
 		return
 	}
 	c.setupValidators(shares)
+	c.handleExitRequests()
 }
 
 func (c *controller) getValidators() ([]*types.SSVShare, error) {
@@ -499,7 +537,8 @@ func (c *controller) GetValidatorsIndices() []phase0.ValidatorIndex {
 	err := c.validatorsMap.ForEach(func(v *validator.Validator) error {
 		if !v.Share.HasBeaconMetadata() {
 			toFetch = append(toFetch, v.Share.ValidatorPubKey)
-		} else if v.Share.BeaconMetadata.IsActive() { // eth-client throws error once trying to fetch duties for existed validator
+		} else if v.Share.BeaconMetadata.IsActive() && // eth-client throws error once trying to fetch duties for existed validator
+			(c.doppelganger == nil || c.doppelganger.isPassed(v.Share.BeaconMetadata.Index)) {
 			indices = append(indices, v.Share.BeaconMetadata.Index)
 		}
 		return nil
@@ -549,6 +588,12 @@ func (c *controller) onShareCreate(validatorEvent abiparser.ValidatorRegistratio
 		return nil, false, errors.Wrap(err, "could not extract validator share from event")
 	}
 
+	recipient, err := c.feeRecipient(validatorEvent.OwnerAddress)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not get fee recipient")
+	}
+	share.FeeRecipientAddress = bellatrix.ExecutionAddress(recipient)
+
 	// determine if the share belongs to operator
 	isOperatorShare := share.BelongsToOperator(c.operatorPubKey)
 
@@ -580,6 +625,18 @@ func (c *controller) onShareCreate(validatorEvent abiparser.ValidatorRegistratio
 	return share, isOperatorShare, nil
 }
 
+// feeRecipient returns the fee recipient of the given owner, the owner address is used until it is updated
+func (c *controller) feeRecipient(owner common.Address) (common.Address, error) {
+	recipientData, found, err := c.storage.GetRecipientData(owner)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !found {
+		return owner, nil
+	}
+	return recipientData.FeeRecipient, nil
+}
+
 // onShareRemove is called when a validator was removed
 // TODO: think how we can make this function atomic (i.e. failing wouldn't stop the removal of the share)
 func (c *controller) onShareRemove(pk string, removeSecret bool) error {
@@ -588,6 +645,9 @@ func (c *controller) onShareRemove(pk string, removeSecret bool) error {
 
 	// stop instance
 	if v != nil {
+		if c.doppelganger != nil && v.Share.HasBeaconMetadata() {
+			c.doppelganger.remove(v.Share.BeaconMetadata.Index)
+		}
 		if err := v.Stop(); err != nil {
 			return errors.Wrap(err, "could not close validator")
 		}
@@ -619,6 +679,13 @@ func (c *controller) startValidator(v *validator.Validator) (bool, error) {
 	if v.Share.BeaconMetadata.Index == 0 {
 		return false, errors.New("could not start validator: index not found")
 	}
+	if c.doppelganger != nil && !c.doppelganger.canStart(v.Share.BeaconMetadata.Index, hex.EncodeToString(v.Share.ValidatorPubKey), c.ethNetwork.EstimatedCurrentEpoch()) {
+		// the messages of the validator are observed by doppelganger detection, it is started once it passed
+		if err := c.network.Subscribe(v.Share.ValidatorPubKey); err != nil {
+			return false, errors.Wrap(err, "could not subscribe to validator topic")
+		}
+		return false, nil
+	}
 	if err := v.Start(); err != nil {
 		metricsValidatorStatus.WithLabelValues(hex.EncodeToString(v.Share.ValidatorPubKey)).Set(float64(validatorStatusError))
 		return false, errors.Wrap(err, "could not start validator")
@@ -626,6 +693,46 @@ func (c *controller) startValidator(v *validator.Validator) (bool, error) {
 	return true, nil
 }
 
+// isObserved returns true if the message is observed by doppelganger detection rather than handled,
+// as its validator is held back. exit messages are always handled, since exits don't wait for the detection
+// and their messages carry the signatures of this operator, which would be detected as a doppelganger
+func (c *controller) isObserved(v *validator.Validator, msg *spectypes.SSVMessage) bool {
+	if msg.MsgID.GetRoleType() == types.BNRoleVoluntaryExit {
+		return false
+	}
+	return c.doppelganger != nil && v.Share.HasBeaconMetadata() && !c.doppelganger.isPassed(v.Share.BeaconMetadata.Index)
+}
+
+// observeDoppelganger passes the signers of a message of a held back validator to doppelganger detection
+func (c *controller) observeDoppelganger(v *validator.Validator, msg *spectypes.SSVMessage) {
+	signers, err := messageSigners(v.Share, msg)
+	if err != nil {
+		c.logger.Debug("could not get message signers", zap.String("pubkey", hex.EncodeToString(v.Share.ValidatorPubKey)), zap.Error(err))
+		return
+	}
+	if len(signers) == 0 {
+		return
+	}
+	c.doppelganger.observe(v.Share.BeaconMetadata.Index, v.Share.OperatorID, signers, c.ethNetwork.EstimatedCurrentEpoch())
+}
+
+// onDoppelgangerPassed starts the validator that passed doppelganger detection
+func (c *controller) onDoppelgangerPassed(index phase0.ValidatorIndex) {
+	var v *validator.Validator
+	_ = c.validatorsMap.ForEach(func(val *validator.Validator) error {
+		if val.Share.HasBeaconMetadata() && val.Share.BeaconMetadata.Index == index {
+			v = val
+		}
+		return nil
+	})
+	if v == nil {
+		return
+	}
+	if _, err := c.startValidator(v); err != nil {
+		c.logger.Warn("could not start validator", zap.String("pubkey", hex.EncodeToString(v.Share.ValidatorPubKey)), zap.Error(err))
+	}
+}
+
 // UpdateValidatorMetaDataLoop updates metadata of validators in an interval
 func (c *controller) UpdateValidatorMetaDataLoop() {
 	go c.metadataUpdateQueue.Start()
@@ -645,6 +752,48 @@ func (c *controller) UpdateValidatorMetaDataLoop() {
 		c.logger.Debug("updating metadata in loop", zap.Int("shares count", len(shares)))
 		beaconprotocol.UpdateValidatorsMetadataBatch(pks, c.metadataUpdateQueue, c,
 			c.beacon, c.onMetadataUpdated, metadataBatchSize)
+		// exits are retried until the metadata shows the validators exiting
+		c.handleExitRequests()
+	}
+}
+
+// PruneHistoryLoop periodically removes the decided history that is out of the retention policy,
+// the history is saved only by full nodes
+func (c *controller) PruneHistoryLoop() {
+	if !c.validatorOptions.FullNode || !c.historyRetention.Enabled() {
+		return
+	}
+	if err := c.historyRetention.Validate(); err != nil {
+		c.logger.Error("history pruning is disabled", zap.Error(err))
+		return
+	}
+	roles := []spectypes.BeaconRole{
+		spectypes.BNRoleAttester,
+		spectypes.BNRoleProposer,
+		spectypes.BNRoleAggregator,
+		spectypes.BNRoleSyncCommittee,
+		spectypes.BNRoleSyncCommitteeContribution,
+	}
+	ticker := time.NewTicker(c.historyRetention.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.context.Done():
+			return
+		case <-ticker.C:
+		}
+
+		currentSlot := c.ethNetwork.EstimatedCurrentSlot()
+		for _, role := range roles {
+			store := c.ibftStorageMap.Get(role)
+			if store == nil {
+				continue
+			}
+			// the pruned instances are logged by the store
+			if _, err := store.PruneInstances(c.historyRetention.Policy(role), currentSlot); err != nil {
+				c.logger.Warn("could not prune decided history", zap.String("role", role.String()), zap.Error(err))
+			}
+		}
 	}
 }
 
@@ -712,5 +861,9 @@ func SetupRunners(ctx context.Context, logger *zap.Logger, options validator.Opt
 			runners[role] = runner.NewSyncCommitteeAggregatorRunner(spectypes.PraterNetwork, &options.SSVShare.Share, qbftCtrl, options.Beacon, options.Network, options.Signer, syncCommitteeContributionValueCheckF)
 		}
 	}
+	// voluntary exits don't run consensus, the exit is signed by a threshold of the operators
+	if exitsBeacon, ok := options.Beacon.(runner.VoluntaryExitBeaconNode); ok {
+		runners[types.BNRoleVoluntaryExit] = runner.NewVoluntaryExitRunner(spectypes.PraterNetwork, &options.SSVShare.Share, exitsBeacon, options.Network, options.Signer)
+	}
 	return runners
 }
@@ -67,12 +67,17 @@ func TestHandleNonCommitteeMessages(t *testing.T) {
 		Data:    []byte("data"),
 	})
 
+	// the timeout ends with the test, so it doesn't fire while the next tests run
+	done := make(chan struct{})
 	go func() {
-		time.Sleep(time.Second * 4)
-		panic("time out!")
+		wg.Wait()
+		close(done)
 	}()
-
-	wg.Wait()
+	select {
+	case <-done:
+	case <-time.After(time.Second * 4):
+		t.Fatal("time out!")
+	}
 }
 
 func TestGetIndices(t *testing.T) {
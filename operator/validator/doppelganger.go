@@ -0,0 +1,278 @@
+package validator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// livenessProvider provides the liveness of validators on the network
+type livenessProvider interface {
+	GetValidatorsLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error)
+}
+
+// doppelgangerState is the detection state of a validator
+type doppelgangerState struct {
+	pubKey string
+	// startEpoch is the first epoch of the detection window
+	startEpoch phase0.Epoch
+	// nextEpoch is the next epoch to check the liveness of
+	nextEpoch phase0.Epoch
+	// detected is true if the validator was detected since it is held back
+	detected bool
+	// committeeEpochs are the epochs in which the other operators of the committee were seen signing
+	committeeEpochs map[phase0.Epoch]bool
+}
+
+// doppelgangerDetector holds back validators until they weren't seen on the network for a number of epochs,
+// so the node won't sign while the validator is still running elsewhere.
+// while held back, the messages of the validator are observed, a message that is signed by this operator
+// means that the operator key is running on another node.
+// the liveness of a validator is the liveness of its whole committee, therefore the validator is detected live
+// only in epochs in which the committee wasn't seen signing (e.g. the validator runs outside of ssv).
+// a detection restarts the window, so the validator starts once its doppelganger is gone
+type doppelgangerDetector struct {
+	logger *zap.Logger
+	beacon livenessProvider
+	epochs phase0.Epoch
+	// onPassed is called once a validator passed the detection
+	onPassed func(index phase0.ValidatorIndex)
+
+	lock       sync.Mutex
+	validators map[phase0.ValidatorIndex]*doppelgangerState
+	passed     map[phase0.ValidatorIndex]bool
+}
+
+func newDoppelgangerDetector(logger *zap.Logger, beacon livenessProvider, epochs uint64, onPassed func(index phase0.ValidatorIndex)) *doppelgangerDetector {
+	return &doppelgangerDetector{
+		logger:     logger.With(zap.String("who", "doppelgangerDetector")),
+		beacon:     beacon,
+		epochs:     phase0.Epoch(epochs),
+		onPassed:   onPassed,
+		validators: make(map[phase0.ValidatorIndex]*doppelgangerState),
+		passed:     make(map[phase0.ValidatorIndex]bool),
+	}
+}
+
+// canStart returns true if the validator passed the detection,
+// otherwise the detection of the validator starts, if it didn't start already.
+// the window starts in the epoch after the given one, as the messages of the validator are observed from now on
+func (d *doppelgangerDetector) canStart(index phase0.ValidatorIndex, pubKey string, currentEpoch phase0.Epoch) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.passed[index] {
+		return true
+	}
+	if state, ok := d.validators[index]; ok {
+		// overrides the status reported by startValidator
+		status := validatorStatusDoppelgangerCheck
+		if state.detected {
+			status = validatorStatusDoppelganger
+		}
+		metricsValidatorStatus.WithLabelValues(pubKey).Set(float64(status))
+		return false
+	}
+	d.validators[index] = &doppelgangerState{
+		pubKey:          pubKey,
+		startEpoch:      currentEpoch + 1,
+		nextEpoch:       currentEpoch + 1,
+		committeeEpochs: make(map[phase0.Epoch]bool),
+	}
+	metricsValidatorStatus.WithLabelValues(pubKey).Set(float64(validatorStatusDoppelgangerCheck))
+	metricsDoppelgangerPending.Set(float64(len(d.validators)))
+	d.logger.Info("validator is held back until it isn't seen on the network",
+		zap.String("pubKey", pubKey), zap.Uint64("index", uint64(index)),
+		zap.Uint64("epoch", uint64(currentEpoch)), zap.Uint64("epochs", uint64(d.epochs)))
+	return false
+}
+
+// isPassed returns true if the validator passed the detection
+func (d *doppelgangerDetector) isPassed(index phase0.ValidatorIndex) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.passed[index]
+}
+
+// remove forgets the given validator
+func (d *doppelgangerDetector) remove(index phase0.ValidatorIndex) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	delete(d.validators, index)
+	delete(d.passed, index)
+	metricsDoppelgangerPending.Set(float64(len(d.validators)))
+}
+
+// observe records the signers of a message of a held back validator, that was received in the given epoch
+func (d *doppelgangerDetector) observe(index phase0.ValidatorIndex, operatorID spectypes.OperatorID, signers []spectypes.OperatorID, epoch phase0.Epoch) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	state, ok := d.validators[index]
+	if !ok {
+		return
+	}
+	for _, signer := range signers {
+		if signer == operatorID {
+			d.detect(index, state, epoch, "validator is signed by this operator on another node")
+			return
+		}
+	}
+	state.committeeEpochs[epoch] = true
+}
+
+// livenessCheckSlots is the number of slots at the end of an epoch in which the liveness of the previous epoch is checked
+const livenessCheckSlots = 4
+
+// run checks the liveness of the pending validators at the end of every epoch, until the context is done
+func (d *doppelgangerDetector) run(ctx context.Context, network beaconprotocol.Network) {
+	for {
+		slot := network.EstimatedCurrentSlot()
+		if isLivenessCheckSlot(network, slot) {
+			d.check(network.EstimatedEpochAtSlot(slot))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(network.GetSlotStartTime(slot + 1))):
+		}
+	}
+}
+
+// isLivenessCheckSlot returns true if the liveness of the previous epoch is checked in the given slot.
+// beacon nodes serve the liveness of the current and the previous epochs only, therefore the previous epoch
+// is checked at the end of the current epoch, once most of its attestations were included in blocks
+func isLivenessCheckSlot(network beaconprotocol.Network, slot phase0.Slot) bool {
+	return uint64(slot)%network.SlotsPerEpoch() >= network.SlotsPerEpoch()-livenessCheckSlots
+}
+
+// check checks the liveness of the pending validators in the previous epoch,
+// validators that weren't seen during the whole window are passed to onPassed
+func (d *doppelgangerDetector) check(currentEpoch phase0.Epoch) {
+	if currentEpoch == 0 {
+		return
+	}
+	epoch := currentEpoch - 1
+	indices := d.pending(epoch)
+	if len(indices) == 0 {
+		return
+	}
+	liveness, err := d.beacon.GetValidatorsLiveness(epoch, indices)
+	if err != nil {
+		// the epoch is checked again on the next slot
+		d.logger.Warn("could not get validators liveness", zap.Uint64("epoch", uint64(epoch)), zap.Error(err))
+		return
+	}
+
+	var passed []phase0.ValidatorIndex
+	d.lock.Lock()
+	for _, index := range indices {
+		state, ok := d.validators[index]
+		if !ok || state.nextEpoch != epoch {
+			continue
+		}
+		live, ok := liveness[index]
+		if !ok {
+			continue
+		}
+		committee := state.committeeEpochs[epoch]
+		delete(state.committeeEpochs, epoch)
+		if live && !committee {
+			d.detect(index, state, currentEpoch, "validator is live on the network while its committee isn't")
+			continue
+		}
+		state.nextEpoch = epoch + 1
+		if state.nextEpoch >= state.startEpoch+d.epochs {
+			delete(d.validators, index)
+			d.passed[index] = true
+			passed = append(passed, index)
+			d.logger.Info("validator passed doppelganger detection",
+				zap.String("pubKey", state.pubKey), zap.Uint64("index", uint64(index)))
+		}
+	}
+	metricsDoppelgangerPending.Set(float64(len(d.validators)))
+	d.lock.Unlock()
+
+	for _, index := range passed {
+		d.onPassed(index)
+	}
+}
+
+// detect restarts the window of a validator that was seen in the given epoch, must be called with the lock held
+func (d *doppelgangerDetector) detect(index phase0.ValidatorIndex, state *doppelgangerState, epoch phase0.Epoch, reason string) {
+	state.detected = true
+	state.startEpoch = epoch + 1
+	state.nextEpoch = epoch + 1
+	for e := range state.committeeEpochs {
+		if e <= epoch {
+			delete(state.committeeEpochs, e)
+		}
+	}
+	metricsValidatorStatus.WithLabelValues(state.pubKey).Set(float64(validatorStatusDoppelganger))
+	metricsDoppelgangerDetected.Inc()
+	d.logger.Error("validator was detected on the network, it is held back until it isn't seen for the whole window",
+		zap.String("reason", reason), zap.String("pubKey", state.pubKey), zap.Uint64("index", uint64(index)),
+		zap.Uint64("epoch", uint64(epoch)), zap.Uint64("epochs", uint64(d.epochs)))
+}
+
+// pending returns the validators whose liveness in the given epoch should be checked
+func (d *doppelgangerDetector) pending(epoch phase0.Epoch) []phase0.ValidatorIndex {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	var indices []phase0.ValidatorIndex
+	for index, state := range d.validators {
+		if state.nextEpoch < epoch {
+			// the liveness of the missed epochs can't be checked anymore, the window restarts
+			state.startEpoch = epoch
+			state.nextEpoch = epoch
+			for e := range state.committeeEpochs {
+				if e < epoch {
+					delete(state.committeeEpochs, e)
+				}
+			}
+		}
+		if state.nextEpoch == epoch {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// messageSigners returns the verified signers of a consensus or a partial signature message of the given share
+func messageSigners(share *types.SSVShare, msg *spectypes.SSVMessage) ([]spectypes.OperatorID, error) {
+	switch msg.MsgType {
+	case spectypes.SSVConsensusMsgType:
+		signedMsg := &specqbft.SignedMessage{}
+		if err := signedMsg.Decode(msg.Data); err != nil {
+			return nil, errors.Wrap(err, "could not decode consensus message")
+		}
+		if err := signedMsg.Signature.VerifyByOperators(signedMsg, share.DomainType, spectypes.QBFTSignatureType, share.Committee); err != nil {
+			return nil, errors.Wrap(err, "could not verify consensus message")
+		}
+		return signedMsg.Signers, nil
+	case spectypes.SSVPartialSignatureMsgType:
+		signedMsg := &specssv.SignedPartialSignatureMessage{}
+		if err := signedMsg.Decode(msg.Data); err != nil {
+			return nil, errors.Wrap(err, "could not decode partial signature message")
+		}
+		if err := signedMsg.GetSignature().VerifyByOperators(signedMsg, share.DomainType, spectypes.PartialSignatureType, share.Committee); err != nil {
+			return nil, errors.Wrap(err, "could not verify partial signature message")
+		}
+		return signedMsg.GetSigners(), nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,177 @@
+package validator
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// fakeLiveness returns the liveness of the validators that are live in each epoch
+type fakeLiveness struct {
+	lock    sync.Mutex
+	live    map[phase0.Epoch]map[phase0.ValidatorIndex]bool
+	fail    bool
+	queried []phase0.Epoch
+}
+
+func (f *fakeLiveness) GetValidatorsLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.fail {
+		return nil, errors.New("liveness is not available")
+	}
+	f.queried = append(f.queried, epoch)
+	res := make(map[phase0.ValidatorIndex]bool)
+	for _, index := range validatorIndices {
+		res[index] = f.live[epoch][index]
+	}
+	return res, nil
+}
+
+func TestDoppelgangerDetector(t *testing.T) {
+	beacon := &fakeLiveness{live: map[phase0.Epoch]map[phase0.ValidatorIndex]bool{
+		11: {1: true, 2: true},
+		12: {1: true},
+	}}
+	var passed []phase0.ValidatorIndex
+	d := newDoppelgangerDetector(zap.L(), beacon, 2, func(index phase0.ValidatorIndex) {
+		passed = append(passed, index)
+	})
+
+	// the window starts in the next epoch, once the messages of the validators are observed
+	require.False(t, d.canStart(1, "a", 10))
+	require.False(t, d.canStart(2, "b", 10))
+	require.False(t, d.canStart(1, "a", 10))
+
+	// the committee of validator 1 signs in epochs 11 and 12, so its liveness isn't a detection
+	d.observe(1, 1, []spectypes.OperatorID{2, 3, 4}, 11)
+	d.observe(1, 1, []spectypes.OperatorID{2}, 12)
+
+	// the previous epoch is checked at the end of the current epoch
+	d.check(11)
+	require.Empty(t, beacon.queried)
+	d.check(12)
+	require.Equal(t, []phase0.Epoch{11}, beacon.queried)
+	d.check(12)
+	require.Equal(t, []phase0.Epoch{11}, beacon.queried)
+
+	// a failed check is retried
+	beacon.fail = true
+	d.check(13)
+	require.Empty(t, passed)
+	beacon.fail = false
+	d.check(13)
+	require.Equal(t, []phase0.ValidatorIndex{1}, passed)
+	require.True(t, d.isPassed(1))
+	require.True(t, d.canStart(1, "a", 13))
+
+	// validator 2 was live while its committee wasn't signing, its window restarts in epoch 13
+	require.False(t, d.canStart(2, "b", 13))
+	d.check(14)
+	require.Equal(t, []phase0.ValidatorIndex{1}, passed)
+	d.check(15)
+	require.Equal(t, []phase0.ValidatorIndex{1, 2}, passed)
+	require.Equal(t, []phase0.Epoch{11, 12, 13, 14}, beacon.queried)
+
+	d.remove(1)
+	require.False(t, d.canStart(1, "a", 15))
+}
+
+func TestDoppelgangerDetector_OwnSignatures(t *testing.T) {
+	beacon := &fakeLiveness{live: map[phase0.Epoch]map[phase0.ValidatorIndex]bool{
+		11: {1: true},
+		12: {1: true},
+		13: {1: true},
+		14: {1: true},
+	}}
+	var passed []phase0.ValidatorIndex
+	d := newDoppelgangerDetector(zap.L(), beacon, 2, func(index phase0.ValidatorIndex) {
+		passed = append(passed, index)
+	})
+
+	require.False(t, d.canStart(1, "a", 10))
+	d.observe(1, 1, []spectypes.OperatorID{2, 3}, 11)
+	// this operator signs on another node, the window restarts in epoch 13
+	d.observe(1, 1, []spectypes.OperatorID{1, 2, 3}, 12)
+	d.observe(1, 1, []spectypes.OperatorID{2, 3}, 12)
+	d.observe(1, 1, []spectypes.OperatorID{2, 3}, 13)
+	d.observe(1, 1, []spectypes.OperatorID{2, 3}, 14)
+
+	d.check(12)
+	d.check(13)
+	require.Empty(t, passed)
+	require.Empty(t, beacon.queried)
+	d.check(14)
+	require.Empty(t, passed)
+	d.check(15)
+	require.Equal(t, []phase0.ValidatorIndex{1}, passed)
+	require.Equal(t, []phase0.Epoch{13, 14}, beacon.queried)
+}
+
+func TestDoppelgangerDetector_MissedEpochs(t *testing.T) {
+	beacon := &fakeLiveness{}
+	var passed []phase0.ValidatorIndex
+	d := newDoppelgangerDetector(zap.L(), beacon, 2, func(index phase0.ValidatorIndex) {
+		passed = append(passed, index)
+	})
+
+	require.False(t, d.canStart(1, "a", 10))
+	// epoch 11 wasn't checked, the window restarts in epoch 12
+	d.check(13)
+	require.Empty(t, passed)
+	d.check(14)
+	require.Equal(t, []phase0.ValidatorIndex{1}, passed)
+	require.Equal(t, []phase0.Epoch{12, 13}, beacon.queried)
+}
+
+func TestIsLivenessCheckSlot(t *testing.T) {
+	network := beacon.NewNetwork(core.PraterNetwork, 0)
+	// the last slots of each epoch
+	for slot := phase0.Slot(320); slot < 352; slot++ {
+		require.Equal(t, slot >= 352-livenessCheckSlots, isLivenessCheckSlot(network, slot), "slot %d", slot)
+	}
+}
+
+func TestMessageSigners(t *testing.T) {
+	ks := testingutils.Testing4SharesSet()
+	share := &types.SSVShare{Share: *testingutils.TestingShare(ks)}
+
+	signers, err := messageSigners(share, testingutils.SSVMsgAttester(nil, testingutils.PostConsensusAttestationMsg(ks.Shares[2], 2, specqbft.FirstHeight)))
+	require.NoError(t, err)
+	require.Equal(t, []spectypes.OperatorID{2}, signers)
+
+	// a message that isn't signed by its signer is ignored
+	signers, err = messageSigners(share, testingutils.SSVMsgAttester(nil, testingutils.PostConsensusAttestationMsg(ks.Shares[2], 1, specqbft.FirstHeight)))
+	require.Error(t, err)
+	require.Nil(t, signers)
+}
+
+func TestController_IsObserved(t *testing.T) {
+	ctrl := setupController(zap.L(), nil)
+	v := newValidator(&beacon.ValidatorMetadata{Index: 1})
+	attestation := &spectypes.SSVMessage{MsgID: spectypes.NewMsgID([]byte{1, 2, 3}, spectypes.BNRoleAttester)}
+	exit := &spectypes.SSVMessage{MsgID: spectypes.NewMsgID([]byte{1, 2, 3}, types.BNRoleVoluntaryExit)}
+
+	// without doppelganger protection nothing is observed
+	require.False(t, ctrl.isObserved(v, attestation))
+
+	ctrl.doppelganger = newDoppelgangerDetector(zap.L(), &fakeLiveness{}, 2, func(index phase0.ValidatorIndex) {})
+	require.False(t, ctrl.doppelganger.canStart(1, "a", 10))
+	require.True(t, ctrl.isObserved(v, attestation))
+	// the exit is handled while the validator is held back
+	require.False(t, ctrl.isObserved(v, exit))
+
+	ctrl.doppelganger.passed[1] = true
+	require.False(t, ctrl.isObserved(v, attestation))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -36,6 +37,12 @@ func (c *controller) Eth1EventHandler(ongoingSync bool) eth1.SyncEventHandler {
 		case abiparser.AccountEnable:
 			ev := e.Data.(abiparser.AccountEnableEvent)
 			return c.handleAccountEnableEvent(ev, ongoingSync)
+		case abiparser.FeeRecipientAddressUpdated:
+			ev := e.Data.(abiparser.FeeRecipientAddressUpdatedEvent)
+			return c.handleFeeRecipientAddressUpdatedEvent(ev)
+		case abiparser.ValidatorExited:
+			ev := e.Data.(abiparser.ValidatorExitedEvent)
+			return c.handleValidatorExitedEvent(ev, ongoingSync)
 		default:
 			c.logger.Debug("could not handle unknown event")
 		}
@@ -323,3 +330,102 @@ func (c *controller) handleAccountEnableEvent(
 
 	return logFields, nil
 }
+
+// handleFeeRecipientAddressUpdatedEvent handles registry contract event for fee recipient address updated,
+// the recipient is saved for the owner and set on the shares of its validators
+func (c *controller) handleFeeRecipientAddressUpdatedEvent(
+	event abiparser.FeeRecipientAddressUpdatedEvent,
+) ([]zap.Field, error) {
+	recipientData := registrystorage.RecipientData{
+		OwnerAddress: event.OwnerAddress,
+		FeeRecipient: event.RecipientAddress,
+	}
+	if err := c.storage.SaveRecipientData(&recipientData); err != nil {
+		return nil, errors.Wrap(err, "could not save recipient data")
+	}
+
+	shares, err := c.collection.GetFilteredValidatorShares(ByOwnerAddress(event.OwnerAddress.String()))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator shares by owner address")
+	}
+	operatorSharePubKeys := make([]string, 0)
+
+	recipient := bellatrix.ExecutionAddress(event.RecipientAddress)
+	for _, share := range shares {
+		share.FeeRecipientAddress = recipient
+		if err := c.collection.SaveValidatorShare(share); err != nil {
+			return nil, errors.Wrap(err, "could not save validator share")
+		}
+		pk := hex.EncodeToString(share.ValidatorPubKey)
+		// the runners of a running validator use its share
+		if v, found := c.validatorsMap.GetValidator(pk); found {
+			v.UpdateFeeRecipient(recipient)
+		}
+		if share.BelongsToOperator(c.operatorPubKey) || c.validatorOptions.FullNode {
+			operatorSharePubKeys = append(operatorSharePubKeys, pk)
+		}
+	}
+
+	logFields := make([]zap.Field, 0)
+	if len(operatorSharePubKeys) > 0 {
+		logFields = append(logFields,
+			zap.String("ownerAddress", event.OwnerAddress.String()),
+			zap.String("feeRecipient", event.RecipientAddress.String()),
+			zap.Strings("updatedShares", operatorSharePubKeys),
+		)
+	}
+
+	return logFields, nil
+}
+
+// handleValidatorExitedEvent handles registry contract event for validator exit requested,
+// the exit is signed for the epoch of the event block so all the operators sign the same exit.
+// the request is saved and dispatched once the validators are started, or right away during an ongoing sync.
+// the request is removed by handleExitRequests once the validator is exiting
+func (c *controller) handleValidatorExitedEvent(
+	event abiparser.ValidatorExitedEvent,
+	ongoingSync bool,
+) ([]zap.Field, error) {
+	share, found, err := c.collection.GetValidatorShare(event.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check if validator share exist")
+	}
+	if !found {
+		return nil, &abiparser.MalformedEventError{
+			Err: errors.New("could not find validator share"),
+		}
+	}
+	// only the owner of the validator can exit it
+	if !strings.EqualFold(share.OwnerAddress, event.OwnerAddress.String()) {
+		return nil, &abiparser.MalformedEventError{
+			Err: errors.New("could not match validator owner address with provided event owner address"),
+		}
+	}
+
+	isOperatorShare := share.BelongsToOperator(c.operatorPubKey)
+	if !isOperatorShare && !c.validatorOptions.FullNode {
+		return nil, nil
+	}
+	pk := hex.EncodeToString(share.ValidatorPubKey)
+	epoch := c.ethNetwork.EstimatedEpochAtSlot(c.ethNetwork.EstimatedSlotAtTime(int64(event.BlockTime)))
+	logFields := []zap.Field{
+		zap.String("validatorPubKey", pk),
+		zap.String("ownerAddress", share.OwnerAddress),
+		zap.Uint64("exitEpoch", uint64(epoch)),
+	}
+	if !isOperatorShare {
+		return logFields, nil
+	}
+
+	if err := c.exitRequests.SaveExitRequest(&ExitRequest{PubKey: pk, Epoch: epoch}); err != nil {
+		return nil, errors.Wrap(err, "could not save exit request")
+	}
+	if ongoingSync {
+		// the request is kept until the validator is exiting, so a failed exit is retried
+		if err := c.ExitValidator(pk, epoch); err != nil {
+			c.logger.Warn("could not exit validator", zap.String("pubKey", pk), zap.Error(err))
+		}
+	}
+
+	return logFields, nil
+}
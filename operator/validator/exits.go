@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/protocol/v2/message"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func exitRequestsPrefix() []byte {
+	return []byte("exit-request-")
+}
+
+// ExitRequest is a request to exit a validator, the exit is signed for the given epoch so all the operators sign the same exit
+type ExitRequest struct {
+	PubKey string       `json:"pubKey"`
+	Epoch  phase0.Epoch `json:"epoch"`
+}
+
+// ExitRequests is the storage of the pending exit requests, requests are kept until the validator is exiting
+type ExitRequests interface {
+	SaveExitRequest(request *ExitRequest) error
+	GetExitRequests() ([]*ExitRequest, error)
+	DeleteExitRequest(pubKey string) error
+}
+
+type exitRequestsStorage struct {
+	db   basedb.IDb
+	lock sync.RWMutex
+}
+
+// NewExitRequests creates a new exit requests storage
+func NewExitRequests(db basedb.IDb) ExitRequests {
+	return &exitRequestsStorage{db: db}
+}
+
+// SaveExitRequest saves an exit request, it replaces the previous request of the validator
+func (s *exitRequestsStorage) SaveExitRequest(request *ExitRequest) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	raw, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal exit request")
+	}
+	return s.db.Set(exitRequestsPrefix(), exitRequestKey(request.PubKey), raw)
+}
+
+// GetExitRequests returns all the pending exit requests
+func (s *exitRequestsStorage) GetExitRequests() ([]*ExitRequest, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var res []*ExitRequest
+	err := s.db.GetAll(exitRequestsPrefix(), func(i int, obj basedb.Obj) error {
+		var request ExitRequest
+		if err := json.Unmarshal(obj.Value, &request); err != nil {
+			return errors.Wrap(err, "could not unmarshal exit request")
+		}
+		res = append(res, &request)
+		return nil
+	})
+	return res, err
+}
+
+// DeleteExitRequest removes the exit request of the given validator
+func (s *exitRequestsStorage) DeleteExitRequest(pubKey string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Delete(exitRequestsPrefix(), exitRequestKey(pubKey))
+}
+
+func exitRequestKey(pubKey string) []byte {
+	return []byte(strings.ToLower(strings.TrimPrefix(pubKey, "0x")))
+}
+
+// ExitValidator dispatches a voluntary exit duty of the given validator for the given epoch,
+// the exit is submitted once a threshold of the operators signed it
+func (c *controller) ExitValidator(pubKey string, epoch phase0.Epoch) error {
+	v, ok := c.validatorsMap.GetValidator(pubKey)
+	if !ok {
+		return errors.New("validator not found")
+	}
+	if !v.Share.HasBeaconMetadata() {
+		return errors.New("validator has no beacon metadata")
+	}
+	if _, ok := v.Queues[types.BNRoleVoluntaryExit]; !ok {
+		return errors.New("validator has no voluntary exit runner")
+	}
+	// the queue is consumed once the validator is started, the exit isn't slashable and won't wait for doppelganger detection,
+	// its messages are handled while the validator is held back (see isObserved)
+	if err := v.Start(); err != nil {
+		return errors.Wrap(err, "could not start validator")
+	}
+
+	duty := &spectypes.Duty{
+		Type:           types.BNRoleVoluntaryExit,
+		PubKey:         phase0.BLSPubKey{},
+		Slot:           c.ethNetwork.GetEpochFirstSlot(epoch),
+		ValidatorIndex: v.Share.BeaconMetadata.Index,
+	}
+	copy(duty.PubKey[:], v.Share.ValidatorPubKey)
+
+	executeDutyData, err := json.Marshal(types.ExecuteDutyData{Duty: duty})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal execute duty data")
+	}
+	data, err := (&types.EventMsg{Type: types.ExecuteDuty, Data: executeDutyData}).Encode()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode event msg")
+	}
+	dec, err := queue.DecodeSSVMessage(&spectypes.SSVMessage{
+		MsgType: message.SSVEventMsgType,
+		MsgID:   spectypes.NewMsgID(v.Share.ValidatorPubKey, types.BNRoleVoluntaryExit),
+		Data:    data,
+	})
+	if err != nil {
+		return err
+	}
+	v.Queues[types.BNRoleVoluntaryExit].Q.Push(dec)
+	c.logger.Info("dispatched voluntary exit", zap.String("pubKey", pubKey), zap.Uint64("epoch", uint64(epoch)))
+	return nil
+}
+
+// handleExitRequests dispatches the pending exit requests of the started validators.
+// requests are kept until the beacon chain shows the validator exiting, so failed exits are retried
+// on the next start and on every metadata update. requests of validators that aren't ours are removed
+func (c *controller) handleExitRequests() {
+	requests, err := c.exitRequests.GetExitRequests()
+	if err != nil {
+		c.logger.Warn("could not get exit requests", zap.Error(err))
+		return
+	}
+	for _, request := range requests {
+		pk := strings.ToLower(strings.TrimPrefix(request.PubKey, "0x"))
+		logger := c.logger.With(zap.String("pubKey", pk), zap.Uint64("epoch", uint64(request.Epoch)))
+		if c.isExitRequestDone(pk) {
+			logger.Debug("removing exit request of a validator that exited or isn't managed by this operator")
+			if err := c.exitRequests.DeleteExitRequest(pk); err != nil {
+				logger.Warn("could not delete exit request", zap.Error(err))
+			}
+			continue
+		}
+		if err := c.ExitValidator(pk, request.Epoch); err != nil {
+			logger.Warn("could not exit validator, the exit will be retried", zap.Error(err))
+		}
+	}
+}
+
+// isExitRequestDone returns true if the given validator is exiting or exited, or is not managed by the operator
+func (c *controller) isExitRequestDone(pubKey string) bool {
+	pk, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return true
+	}
+	share, found, err := c.collection.GetValidatorShare(pk)
+	if err != nil || !found || !share.BelongsToOperator(c.operatorPubKey) {
+		return err == nil
+	}
+	if !share.HasBeaconMetadata() {
+		return false
+	}
+	// the exit was included once the validator is exiting
+	meta := share.BeaconMetadata
+	return meta.Status == v1.ValidatorStateActiveExiting || meta.Exiting() || meta.Slashed()
+}
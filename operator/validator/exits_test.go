@@ -0,0 +1,231 @@
+package validator
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/eth2-key-manager/core"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/queue"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
+	"github.com/bloxapp/ssv/protocol/v2/ssv/validator"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestExitRequests(t *testing.T) {
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	exitRequests := NewExitRequests(db)
+	requests, err := exitRequests.GetExitRequests()
+	require.NoError(t, err)
+	require.Empty(t, requests)
+
+	require.NoError(t, exitRequests.SaveExitRequest(&ExitRequest{PubKey: "aa01", Epoch: 10}))
+	require.NoError(t, exitRequests.SaveExitRequest(&ExitRequest{PubKey: "bb02", Epoch: 11}))
+	// the request of a validator replaces its previous request, regardless of the key format
+	require.NoError(t, exitRequests.SaveExitRequest(&ExitRequest{PubKey: "0xAA01", Epoch: 12}))
+
+	requests, err = exitRequests.GetExitRequests()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*ExitRequest{
+		{PubKey: "0xAA01", Epoch: 12},
+		{PubKey: "bb02", Epoch: 11},
+	}, requests)
+
+	require.NoError(t, exitRequests.DeleteExitRequest("aa01"))
+	requests, err = exitRequests.GetExitRequests()
+	require.NoError(t, err)
+	require.Equal(t, []*ExitRequest{{PubKey: "bb02", Epoch: 11}}, requests)
+}
+
+func TestExitValidator(t *testing.T) {
+	ctrl, done := newExitsController(t)
+	defer done()
+	pk := addExitsValidator(t, ctrl, 1, v1.ValidatorStateActiveOngoing, true)
+
+	require.EqualError(t, ctrl.ExitValidator("aa01", 10), "validator not found")
+
+	require.NoError(t, ctrl.ExitValidator(pk, 10))
+	requireExitDuty(t, ctrl, pk, 1, 10)
+}
+
+func TestHandleValidatorExitedEvent(t *testing.T) {
+	ctrl, done := newExitsController(t)
+	defer done()
+	pk := addExitsValidator(t, ctrl, 1, v1.ValidatorStateActiveOngoing, true)
+	otherPK := addExitsValidator(t, ctrl, 2, v1.ValidatorStateActiveOngoing, false)
+
+	// the exit epoch is the epoch of the block time
+	genesis := ctrl.ethNetwork.MinGenesisTime()
+	event := abiparser.ValidatorExitedEvent{
+		OwnerAddress: exitsOwner,
+		PublicKey:    mustDecodeHex(t, pk),
+		BlockTime:    genesis + uint64(ctrl.ethNetwork.SlotDurationSec().Seconds())*(32*5+3),
+	}
+	_, err := ctrl.handleValidatorExitedEvent(event, false)
+	require.NoError(t, err)
+	requireExitRequests(t, ctrl, &ExitRequest{PubKey: pk, Epoch: 5})
+	// the exit is dispatched once the validators are started
+	v, _ := ctrl.validatorsMap.GetValidator(pk)
+	require.True(t, v.Queues[types.BNRoleVoluntaryExit].Q.IsEmpty())
+
+	// the exit is dispatched right away during an ongoing sync, the request is kept until the validator is exiting
+	_, err = ctrl.handleValidatorExitedEvent(event, true)
+	require.NoError(t, err)
+	requireExitDuty(t, ctrl, pk, 1, 5)
+	requireExitRequests(t, ctrl, &ExitRequest{PubKey: pk, Epoch: 5})
+
+	// only the owner can exit the validator
+	_, err = ctrl.handleValidatorExitedEvent(abiparser.ValidatorExitedEvent{OwnerAddress: common.Address{1}, PublicKey: event.PublicKey, BlockTime: event.BlockTime}, true)
+	require.IsType(t, &abiparser.MalformedEventError{}, err)
+	v, _ = ctrl.validatorsMap.GetValidator(pk)
+	require.True(t, v.Queues[types.BNRoleVoluntaryExit].Q.IsEmpty())
+
+	// validators of other operators are ignored
+	_, err = ctrl.handleValidatorExitedEvent(abiparser.ValidatorExitedEvent{OwnerAddress: exitsOwner, PublicKey: mustDecodeHex(t, otherPK), BlockTime: event.BlockTime}, true)
+	require.NoError(t, err)
+	requireExitRequests(t, ctrl, &ExitRequest{PubKey: pk, Epoch: 5})
+
+	_, err = ctrl.handleValidatorExitedEvent(abiparser.ValidatorExitedEvent{PublicKey: []byte{1, 2, 3}, BlockTime: event.BlockTime}, true)
+	require.IsType(t, &abiparser.MalformedEventError{}, err)
+}
+
+func TestHandleExitRequests(t *testing.T) {
+	ctrl, done := newExitsController(t)
+	defer done()
+	pk := addExitsValidator(t, ctrl, 1, v1.ValidatorStateActiveOngoing, true)
+	exitingPK := addExitsValidator(t, ctrl, 2, v1.ValidatorStateActiveExiting, true)
+	otherPK := addExitsValidator(t, ctrl, 3, v1.ValidatorStateActiveOngoing, false)
+	// a validator that isn't started yet, its exit fails
+	pendingPK := addExitsValidator(t, ctrl, 4, v1.ValidatorStateActiveOngoing, true)
+	ctrl.validatorsMap.RemoveValidator(pendingPK)
+
+	for i, requestPK := range []string{pk, exitingPK, otherPK, pendingPK, "aa01"} {
+		require.NoError(t, ctrl.exitRequests.SaveExitRequest(&ExitRequest{PubKey: requestPK, Epoch: phase0.Epoch(10 + i)}))
+	}
+
+	// the requests of validators that are exiting or aren't ours are removed, the others are kept for a retry
+	ctrl.handleExitRequests()
+	requireExitDuty(t, ctrl, pk, 1, 10)
+	requireExitRequests(t, ctrl, &ExitRequest{PubKey: pk, Epoch: 10}, &ExitRequest{PubKey: pendingPK, Epoch: 13})
+
+	// the exit is retried until the validator is exiting
+	ctrl.handleExitRequests()
+	requireExitDuty(t, ctrl, pk, 1, 10)
+	share, found, err := ctrl.collection.GetValidatorShare(mustDecodeHex(t, pk))
+	require.NoError(t, err)
+	require.True(t, found)
+	share.BeaconMetadata.Status = v1.ValidatorStateActiveExiting
+	require.NoError(t, ctrl.collection.SaveValidatorShare(share))
+
+	ctrl.handleExitRequests()
+	v, _ := ctrl.validatorsMap.GetValidator(pk)
+	require.True(t, v.Queues[types.BNRoleVoluntaryExit].Q.IsEmpty())
+	requireExitRequests(t, ctrl, &ExitRequest{PubKey: pendingPK, Epoch: 13})
+}
+
+var exitsOwner = common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+
+// newExitsController creates a controller whose validators aren't connected to a network,
+// so the dispatched exits stay in their queues
+func newExitsController(t *testing.T) (*controller, func()) {
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+
+	ctrl := &controller{
+		context:          context.Background(),
+		logger:           zap.L(),
+		collection:       NewCollection(CollectionOptions{DB: db, Logger: zap.L()}),
+		operatorPubKey:   "operator",
+		validatorOptions: &validator.Options{},
+		validatorsMap: &validatorsMap{
+			logger:        zap.L(),
+			ctx:           context.Background(),
+			validatorsMap: make(map[string]*validator.Validator),
+		},
+		ethNetwork:   beacon.NewNetwork(core.PraterNetwork, 0),
+		exitRequests: NewExitRequests(db),
+	}
+	return ctrl, db.Close
+}
+
+// addExitsValidator saves the share of a validator, the validator is created if it belongs to the operator
+func addExitsValidator(t *testing.T, ctrl *controller, index phase0.ValidatorIndex, status v1.ValidatorState, ours bool) string {
+	operator := []byte("other")
+	if ours {
+		operator = []byte(ctrl.operatorPubKey)
+	}
+	share := &types.SSVShare{
+		Share: spectypes.Share{
+			OperatorID:      1,
+			ValidatorPubKey: []byte{byte(index), 1, 2, 3},
+		},
+		Metadata: types.Metadata{
+			BeaconMetadata: &beacon.ValidatorMetadata{Index: index, Status: status},
+			OwnerAddress:   exitsOwner.String(),
+			Operators:      [][]byte{operator},
+		},
+	}
+	require.NoError(t, ctrl.collection.SaveValidatorShare(share))
+	pk := hex.EncodeToString(share.ValidatorPubKey)
+	if ours {
+		ctx, cancel := context.WithCancel(context.Background())
+		ctrl.validatorsMap.validatorsMap[pk] = validator.NewValidator(ctx, cancel, validator.Options{
+			SSVShare: share,
+			DutyRunners: runner.DutyRunners{
+				types.BNRoleVoluntaryExit: runner.NewVoluntaryExitRunner(spectypes.PraterNetwork, &share.Share, nil, nil, nil),
+			},
+		})
+	}
+	return pk
+}
+
+// requireExitDuty pops the exit duty that was dispatched to the given validator
+func requireExitDuty(t *testing.T, ctrl *controller, pk string, index phase0.ValidatorIndex, epoch phase0.Epoch) {
+	v, ok := ctrl.validatorsMap.GetValidator(pk)
+	require.True(t, ok)
+	q := v.Queues[types.BNRoleVoluntaryExit].Q
+	msg := q.Pop(queue.NewMessagePrioritizer(&queue.State{}))
+	require.NotNil(t, msg)
+	require.True(t, q.IsEmpty())
+	require.Equal(t, spectypes.NewMsgID(v.Share.ValidatorPubKey, types.BNRoleVoluntaryExit), msg.MsgID)
+
+	executeDutyData, err := msg.Body.(*types.EventMsg).GetExecuteDutyData()
+	require.NoError(t, err)
+	require.Equal(t, types.BNRoleVoluntaryExit, executeDutyData.Duty.Type)
+	require.Equal(t, ctrl.ethNetwork.GetEpochFirstSlot(epoch), executeDutyData.Duty.Slot)
+	require.Equal(t, index, executeDutyData.Duty.ValidatorIndex)
+}
+
+func requireExitRequests(t *testing.T, ctrl *controller, expected ...*ExitRequest) {
+	requests, err := ctrl.exitRequests.GetExitRequests()
+	require.NoError(t, err)
+	require.ElementsMatch(t, expected, requests)
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
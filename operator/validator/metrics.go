@@ -19,6 +19,14 @@ var (
 		Name: "ssv:validator:v2:status",
 		Help: "Validator status",
 	}, []string{"pubKey"})
+	metricsDoppelgangerPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "ssv:validator:v2:doppelganger_pending",
+		Help: "Count of validators that are held back by doppelganger detection",
+	})
+	metricsDoppelgangerDetected = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ssv:validator:v2:doppelganger_detected",
+		Help: "Count of validators that were detected live on the network by doppelganger detection",
+	})
 )
 
 func init() {
@@ -28,6 +36,12 @@ func init() {
 	if err := prometheus.Register(metricsValidatorStatus); err != nil {
 		log.Println("could not register prometheus collector")
 	}
+	if err := prometheus.Register(metricsDoppelgangerPending); err != nil {
+		log.Println("could not register prometheus collector")
+	}
+	if err := prometheus.Register(metricsDoppelgangerDetected); err != nil {
+		log.Println("could not register prometheus collector")
+	}
 }
 
 // ReportValidatorStatus reports the current status of validator
@@ -74,4 +88,8 @@ var (
 	validatorStatusNotFound     validatorStatus = 7
 	validatorStatusPending      validatorStatus = 8
 	validatorStatusUnknown      validatorStatus = 9
+	// validatorStatusDoppelgangerCheck is a validator that is held back by doppelganger detection
+	validatorStatusDoppelgangerCheck validatorStatus = 10
+	// validatorStatusDoppelganger is a validator that was detected live on the network by doppelganger detection
+	validatorStatusDoppelganger validatorStatus = 11
 )
@@ -102,6 +102,18 @@ func (mr *MockControllerMockRecorder) UpdateValidatorMetaDataLoop() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValidatorMetaDataLoop", reflect.TypeOf((*MockController)(nil).UpdateValidatorMetaDataLoop))
 }
 
+// PruneHistoryLoop mocks base method
+func (m *MockController) PruneHistoryLoop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PruneHistoryLoop")
+}
+
+// PruneHistoryLoop indicates an expected call of PruneHistoryLoop
+func (mr *MockControllerMockRecorder) PruneHistoryLoop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneHistoryLoop", reflect.TypeOf((*MockController)(nil).PruneHistoryLoop))
+}
+
 // StartNetworkHandlers mocks base method
 func (m *MockController) StartNetworkHandlers() {
 	m.ctrl.T.Helper()
@@ -12,6 +12,7 @@ import (
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
 	"github.com/bloxapp/ssv/protocol/v2/blockchain/eth1"
 	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/storage/basedb"
 )
 
@@ -26,6 +27,12 @@ type ICollection interface {
 	DeleteValidatorShare(key []byte) error
 }
 
+// RegistryStorage is the registry data of operators and owners, which is updated by the contract events
+type RegistryStorage interface {
+	registrystorage.OperatorsCollection
+	registrystorage.RecipientsCollection
+}
+
 func collectionPrefix() []byte {
 	return []byte("share-")
 }
@@ -111,13 +118,15 @@ func (s *Collection) GetAllValidatorShares() ([]*types.SSVShare, error) {
 
 	var res []*types.SSVShare
 
-	err := s.db.GetAll(collectionPrefix(), func(i int, obj basedb.Obj) error {
-		val := &types.SSVShare{}
-		if err := val.Decode(obj.Value); err != nil {
-			return fmt.Errorf("failed to deserialize share: %w", err)
-		}
-		res = append(res, val)
-		return nil
+	err := s.db.View(func(txn basedb.ReadTxn) error {
+		return txn.GetAll(collectionPrefix(), func(i int, obj basedb.Obj) error {
+			val := &types.SSVShare{}
+			if err := val.Decode(obj.Value); err != nil {
+				return fmt.Errorf("failed to deserialize share: %w", err)
+			}
+			res = append(res, val)
+			return nil
+		})
 	})
 
 	return res, err
@@ -158,15 +167,17 @@ func (s *Collection) GetFilteredValidatorShares(filter func(share *types.SSVShar
 
 	var res []*types.SSVShare
 
-	err := s.db.GetAll(collectionPrefix(), func(i int, obj basedb.Obj) error {
-		share := &types.SSVShare{}
-		if err := share.Decode(obj.Value); err != nil {
-			return fmt.Errorf("failed to deserialize validator: %w", err)
-		}
-		if filter(share) {
-			res = append(res, share)
-		}
-		return nil
+	err := s.db.View(func(txn basedb.ReadTxn) error {
+		return txn.GetAll(collectionPrefix(), func(i int, obj basedb.Obj) error {
+			share := &types.SSVShare{}
+			if err := share.Decode(obj.Value); err != nil {
+				return fmt.Errorf("failed to deserialize validator: %w", err)
+			}
+			if filter(share) {
+				res = append(res, share)
+			}
+			return nil
+		})
 	})
 
 	return res, err
@@ -18,6 +18,7 @@ import (
 	"github.com/bloxapp/ssv/protocol/v2/types"
 	registrystorage "github.com/bloxapp/ssv/registry/storage"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
 )
 
 // UpdateShareMetadata will update the given share object w/o involving storage,
@@ -96,9 +97,17 @@ func ShareFromValidatorEvent(
 		}
 	}
 
-	f := uint64(len(committee)-1) / 3
-	validatorShare.Quorum = 3 * f
-	validatorShare.PartialQuorum = 2 * f
+	// the quorum is the threshold that the validator key is split with, see threshold.Quorum
+	quorum, err := threshold.Quorum(uint64(len(committee)))
+	if err != nil {
+		return nil, nil, &abiparser.MalformedEventError{Err: err}
+	}
+	partialQuorum, err := threshold.PartialQuorum(uint64(len(committee)))
+	if err != nil {
+		return nil, nil, &abiparser.MalformedEventError{Err: err}
+	}
+	validatorShare.Quorum = quorum
+	validatorShare.PartialQuorum = partialQuorum
 	validatorShare.DomainType = types.GetDefaultDomain()
 	validatorShare.Committee = committee
 	validatorShare.SetOperators(validatorRegistrationEvent.OperatorPublicKeys)
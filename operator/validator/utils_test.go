@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"crypto/rsa"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+func TestShareFromValidatorEvent_Quorum(t *testing.T) {
+	threshold.Init()
+
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+	registry := registrystorage.NewOperatorsStorage(db, zap.L(), []byte("test"))
+
+	// 7 operators, f=2
+	operatorIDs := []uint64{1, 2, 3, 4, 5, 6, 7}
+	operatorSks := make([]*rsa.PrivateKey, len(operatorIDs))
+	operatorPks := make([]string, len(operatorIDs))
+	for i, id := range operatorIDs {
+		_, skPem, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		operatorSks[i], err = rsaencryption.ConvertPemToPrivateKey(string(skPem))
+		require.NoError(t, err)
+		operatorPks[i], err = rsaencryption.ExtractPublicKey(operatorSks[i])
+		require.NoError(t, err)
+		require.NoError(t, registry.SaveOperatorData(&registrystorage.OperatorData{Index: id, PublicKey: operatorPks[i]}))
+	}
+
+	// the key is split the way create-threshold splits it
+	k, err := threshold.Quorum(uint64(len(operatorIDs)))
+	require.NoError(t, err)
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	keyShares, err := threshold.CreateKeyShares(sk, k, operatorIDs, operatorPks)
+	require.NoError(t, err)
+
+	p := &ValidatorRegistrationPayload{PublicKey: keyShares.ValidatorPublicKey}
+	for _, share := range keyShares.Shares {
+		p.OperatorIds = append(p.OperatorIds, uint32(share.OperatorID))
+		p.SharesPublicKeys = append(p.SharesPublicKeys, share.SharePublicKey)
+		p.EncryptedKeys = append(p.EncryptedKeys, share.EncryptedKey)
+	}
+	event, err := p.ToEvent()
+	require.NoError(t, err)
+
+	keyProvider := func() (*rsa.PrivateKey, bool, error) {
+		return operatorSks[0], true, nil
+	}
+	share, shareSecret, err := ShareFromValidatorEvent(*event, registry, keyProvider, operatorPks[0])
+	require.NoError(t, err)
+	require.NotNil(t, shareSecret)
+	require.Equal(t, uint64(5), share.Quorum)
+	require.Equal(t, uint64(3), share.PartialQuorum)
+	// a quorum of the operators reconstructs the validator signature
+	require.Equal(t, keyShares.Threshold, share.Quorum)
+	verified, err := threshold.VerifyKeyShares(keyShares, operatorSks)
+	require.NoError(t, err)
+	require.Equal(t, 21, verified)
+}
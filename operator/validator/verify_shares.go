@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// ValidatorRegistrationPayload is the validator registration data as it is passed to the contract,
+// public keys are hex encoded and encrypted keys are base64 encoded
+type ValidatorRegistrationPayload struct {
+	PublicKey        string   `json:"publicKey"`
+	OwnerAddress     string   `json:"ownerAddress,omitempty"`
+	OperatorIds      []uint32 `json:"operatorIds"`
+	SharesPublicKeys []string `json:"sharesPublicKeys"`
+	EncryptedKeys    []string `json:"encryptedKeys"`
+}
+
+// ToEvent converts the payload into the event that the contract would emit for it
+func (p *ValidatorRegistrationPayload) ToEvent() (*abiparser.ValidatorRegistrationEvent, error) {
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(p.PublicKey, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode validator public key")
+	}
+	sharesPublicKeys := make([][]byte, len(p.SharesPublicKeys))
+	for i, pk := range p.SharesPublicKeys {
+		sharesPublicKeys[i], err = hex.DecodeString(strings.TrimPrefix(pk, "0x"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode share public key %d", i)
+		}
+	}
+	encryptedKeys := make([][]byte, len(p.EncryptedKeys))
+	for i, ek := range p.EncryptedKeys {
+		encryptedKeys[i] = []byte(ek)
+	}
+	return &abiparser.ValidatorRegistrationEvent{
+		PublicKey:        pubKey,
+		OwnerAddress:     common.HexToAddress(p.OwnerAddress),
+		OperatorIds:      p.OperatorIds,
+		SharesPublicKeys: sharesPublicKeys,
+		EncryptedKeys:    encryptedKeys,
+	}, nil
+}
+
+// VerifyValidatorRegistration checks a validator registration before it is submitted to the contract.
+// it checks the committee against the registry, and decrypts the share of the given operator
+// the same way as when the event is received, to verify it matches the declared share public key
+func VerifyValidatorRegistration(
+	validatorRegistrationEvent abiparser.ValidatorRegistrationEvent,
+	registryStorage registrystorage.OperatorsCollection,
+	shareEncryptionKeyProvider ShareEncryptionKeyProvider,
+	operatorPubKey string,
+) (*types.SSVShare, error) {
+	committeeSize := len(validatorRegistrationEvent.OperatorIds)
+	if len(validatorRegistrationEvent.SharesPublicKeys) != committeeSize || len(validatorRegistrationEvent.EncryptedKeys) != committeeSize {
+		return nil, errors.Errorf("got %d operator ids, %d share public keys and %d encrypted keys",
+			committeeSize, len(validatorRegistrationEvent.SharesPublicKeys), len(validatorRegistrationEvent.EncryptedKeys))
+	}
+	if _, err := threshold.FaultTolerance(uint64(committeeSize)); err != nil {
+		return nil, err
+	}
+
+	operatorIDs := make(map[uint32]bool)
+	for i, operatorID := range validatorRegistrationEvent.OperatorIds {
+		if operatorIDs[operatorID] {
+			return nil, errors.Errorf("duplicate operator id %d", operatorID)
+		}
+		operatorIDs[operatorID] = true
+
+		sharePubKey := &bls.PublicKey{}
+		if err := sharePubKey.Deserialize(validatorRegistrationEvent.SharesPublicKeys[i]); err != nil {
+			return nil, errors.Wrapf(err, "invalid share public key of operator %d", operatorID)
+		}
+	}
+
+	share, shareSecret, err := ShareFromValidatorEvent(validatorRegistrationEvent, registryStorage, shareEncryptionKeyProvider, operatorPubKey)
+	if err != nil {
+		return nil, err
+	}
+	if !share.BelongsToOperator(operatorPubKey) {
+		return nil, errors.New("operator is not part of the committee")
+	}
+	if shareSecret == nil {
+		return nil, errors.New("could not decode shareSecret")
+	}
+	if !bytes.Equal(shareSecret.GetPublicKey().Serialize(), share.SharePubKey) {
+		return nil, errors.Errorf("decrypted share of operator %d does not match the declared share public key", share.OperatorID)
+	}
+	return share, nil
+}
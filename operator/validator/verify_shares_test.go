@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"crypto/rsa"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+func TestVerifyValidatorRegistration(t *testing.T) {
+	threshold.Init()
+
+	db, err := storage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+	registry := registrystorage.NewOperatorsStorage(db, zap.L(), []byte("test"))
+
+	operatorIDs := []uint64{1, 2, 3, 4}
+	operatorSks := make([]*rsa.PrivateKey, len(operatorIDs))
+	operatorPks := make([]string, len(operatorIDs))
+	for i, id := range operatorIDs {
+		_, skPem, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		operatorSks[i], err = rsaencryption.ConvertPemToPrivateKey(string(skPem))
+		require.NoError(t, err)
+		operatorPks[i], err = rsaencryption.ExtractPublicKey(operatorSks[i])
+		require.NoError(t, err)
+		// the last operator is not in the registry
+		if id < 4 {
+			require.NoError(t, registry.SaveOperatorData(&registrystorage.OperatorData{Index: id, PublicKey: operatorPks[i]}))
+		}
+	}
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	keyShares, err := threshold.CreateKeyShares(sk, 3, operatorIDs, operatorPks)
+	require.NoError(t, err)
+
+	payload := func() *ValidatorRegistrationPayload {
+		p := &ValidatorRegistrationPayload{PublicKey: keyShares.ValidatorPublicKey}
+		for _, share := range keyShares.Shares {
+			p.OperatorIds = append(p.OperatorIds, uint32(share.OperatorID))
+			p.SharesPublicKeys = append(p.SharesPublicKeys, share.SharePublicKey)
+			p.EncryptedKeys = append(p.EncryptedKeys, share.EncryptedKey)
+		}
+		return p
+	}
+	verify := func(p *ValidatorRegistrationPayload, operator int) error {
+		event, err := p.ToEvent()
+		require.NoError(t, err)
+		keyProvider := func() (*rsa.PrivateKey, bool, error) {
+			return operatorSks[operator], true, nil
+		}
+		_, err = VerifyValidatorRegistration(*event, registry, keyProvider, operatorPks[operator])
+		return err
+	}
+
+	require.EqualError(t, verify(payload(), 0), "could not set operator public keys: could not find operator data by index")
+
+	require.NoError(t, registry.SaveOperatorData(&registrystorage.OperatorData{Index: 4, PublicKey: operatorPks[3]}))
+	for operator := range operatorIDs {
+		require.NoError(t, verify(payload(), operator))
+	}
+
+	t.Run("share encrypted to another operator", func(t *testing.T) {
+		p := payload()
+		p.EncryptedKeys[0] = p.EncryptedKeys[1]
+		require.EqualError(t, verify(p, 0), "failed to decrypt share private key: Failed to decrypt key: crypto/rsa: decryption error")
+	})
+
+	t.Run("wrong share public key", func(t *testing.T) {
+		p := payload()
+		p.SharesPublicKeys[0] = p.SharesPublicKeys[1]
+		require.EqualError(t, verify(p, 0), "decrypted share of operator 1 does not match the declared share public key")
+	})
+
+	t.Run("missing share", func(t *testing.T) {
+		p := payload()
+		p.SharesPublicKeys = p.SharesPublicKeys[:3]
+		require.EqualError(t, verify(p, 0), "got 4 operator ids, 3 share public keys and 4 encrypted keys")
+	})
+
+	t.Run("invalid committee size", func(t *testing.T) {
+		p := payload()
+		p.OperatorIds = p.OperatorIds[:3]
+		p.SharesPublicKeys = p.SharesPublicKeys[:3]
+		p.EncryptedKeys = p.EncryptedKeys[:3]
+		require.EqualError(t, verify(p, 0), "invalid committee size 3, must be 3f+1 (4, 7, 10, 13, ...)")
+	})
+
+	t.Run("duplicate operator", func(t *testing.T) {
+		p := payload()
+		p.OperatorIds[1] = p.OperatorIds[0]
+		require.EqualError(t, verify(p, 0), "duplicate operator id 1")
+	})
+}
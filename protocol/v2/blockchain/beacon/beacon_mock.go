@@ -9,6 +9,7 @@ import (
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	fssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async/event"
 )
 
 // TODO need to use mockgen instead
@@ -27,6 +28,10 @@ func NewBeaconMock() Beacon {
 	return beaconMock{}
 }
 
+func (b beaconMock) EventsFeed() *event.Feed {
+	return new(event.Feed)
+}
+
 func (b beaconMock) GetBeaconNetwork() spectypes.BeaconNetwork {
 	//TODO implement me
 	panic("implement me")
@@ -96,7 +101,7 @@ func (b beaconMock) DomainData(epoch phase0.Epoch, domain phase0.DomainType) (ph
 	return phase0.Domain{}, nil
 }
 
-func (b beaconMock) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error) {
+func (b beaconMock) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, DutiesDependentRoots, error) {
 	//TODO implement me
 	panic("implement me")
 }
@@ -116,6 +121,11 @@ func (b beaconMock) GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[p
 	panic("implement me")
 }
 
+func (b beaconMock) GetValidatorsLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (b beaconMock) SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error {
 	//TODO implement me
 	panic("implement me")
@@ -148,3 +158,8 @@ func (b beaconMock) signingData(rootFunc func() ([32]byte, error), domain []byte
 	}
 	return container.HashTreeRoot()
 }
+
+func (b beaconMock) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	//TODO implement me
+	panic("implement me")
+}
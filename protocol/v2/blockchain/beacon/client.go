@@ -2,12 +2,14 @@ package beacon
 
 import (
 	"context"
+	"strings"
 
 	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv-spec/ssv"
 	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/prysmaticlabs/prysm/async/event"
 	"go.uber.org/zap"
 )
 
@@ -15,10 +17,17 @@ import (
 
 //go:generate mockgen -package=beacon -destination=./mock_client.go -source=./client.go
 
+// DutiesDependentRoots are the dependent roots of the attester and proposer duties of an epoch,
+// the duties change once their dependent root changes. a root is zero if its duties couldn't be fetched
+type DutiesDependentRoots struct {
+	Attester phase0.Root
+	Proposer phase0.Root
+}
+
 // beaconDuties interface serves all duty related calls
 type beaconDuties interface {
-	// GetDuties returns duties for the passed validators indices
-	GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, error)
+	// GetDuties returns duties for the passed validators indices, and their dependent roots
+	GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*spectypes.Duty, DutiesDependentRoots, error)
 }
 
 // beaconSubscriber interface serves all committee subscribe to subnet (p2p topic)
@@ -32,6 +41,8 @@ type beaconSubscriber interface {
 type beaconValidator interface {
 	// GetValidatorData returns metadata (balance, index, status, more) for each pubkey from the node
 	GetValidatorData(validatorPubKeys []phase0.BLSPubKey) (map[phase0.ValidatorIndex]*eth2apiv1.Validator, error)
+	// GetValidatorsLiveness returns whether each of the given validators was seen on the network (attesting or proposing) in the given epoch
+	GetValidatorsLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error)
 }
 
 type proposer interface {
@@ -39,6 +50,16 @@ type proposer interface {
 	SubmitProposalPreparation(feeRecipients map[phase0.ValidatorIndex]bellatrix.ExecutionAddress) error
 }
 
+type exits interface {
+	// SubmitVoluntaryExit submits a signed voluntary exit of a validator
+	SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error
+}
+
+type beaconEvents interface {
+	// EventsFeed returns the feed of the head and chain reorg events (*eth2apiv1.Event) of the beacon node
+	EventsFeed() *event.Feed
+}
+
 // TODO need to handle differently (by spec)
 type signer interface {
 	ComputeSigningRoot(object interface{}, domain phase0.Domain) ([32]byte, error)
@@ -52,6 +73,8 @@ type Beacon interface {
 	beaconValidator
 	signer // TODO need to handle differently
 	proposer
+	exits
+	beaconEvents
 }
 
 // Options for controller struct creation
@@ -60,6 +83,17 @@ type Options struct {
 	Logger         *zap.Logger
 	Network        string `yaml:"Network" env:"NETWORK" env-default:"prater"`
 	MinGenesisTime uint64 `yaml:"MinGenesisTime" env:"MinGenesisTime"`
-	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true"`
+	BeaconNodeAddr string `yaml:"BeaconNodeAddr" env:"BEACON_NODE_ADDR" env-required:"true" env-description:"Beacon node addresses separated by commas, reads fail over between them and submissions are sent to all of them"`
 	Graffiti       []byte
 }
+
+// Addrs returns the beacon node addresses
+func (o Options) Addrs() []string {
+	var res []string
+	for _, addr := range strings.Split(o.BeaconNodeAddr, ",") {
+		if addr = strings.TrimSpace(addr); len(addr) > 0 {
+			res = append(res, addr)
+		}
+	}
+	return res
+}
@@ -0,0 +1,13 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_Addrs(t *testing.T) {
+	require.Equal(t, []string{"localhost:5052"}, Options{BeaconNodeAddr: "localhost:5052"}.Addrs())
+	require.Equal(t, []string{"localhost:5052", "http://b:3500"}, Options{BeaconNodeAddr: " localhost:5052, http://b:3500 ,"}.Addrs())
+	require.Empty(t, Options{}.Addrs())
+}
@@ -14,6 +14,7 @@ import (
 	phase0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	types "github.com/bloxapp/ssv-spec/types"
 	gomock "github.com/golang/mock/gomock"
+	event "github.com/prysmaticlabs/prysm/async/event"
 )
 
 // MockbeaconDuties is a mock of beaconDuties interface.
@@ -40,12 +41,13 @@ func (m *MockbeaconDuties) EXPECT() *MockbeaconDutiesMockRecorder {
 }
 
 // GetDuties mocks base method.
-func (m *MockbeaconDuties) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*types.Duty, error) {
+func (m *MockbeaconDuties) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*types.Duty, DutiesDependentRoots, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetDuties", epoch, validatorIndices)
 	ret0, _ := ret[0].([]*types.Duty)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(DutiesDependentRoots)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetDuties indicates an expected call of GetDuties.
@@ -143,6 +145,21 @@ func (mr *MockbeaconValidatorMockRecorder) GetValidatorData(validatorPubKeys int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorData", reflect.TypeOf((*MockbeaconValidator)(nil).GetValidatorData), validatorPubKeys)
 }
 
+// GetValidatorsLiveness mocks base method.
+func (m *MockbeaconValidator) GetValidatorsLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorsLiveness", epoch, validatorIndices)
+	ret0, _ := ret[0].(map[phase0.ValidatorIndex]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorsLiveness indicates an expected call of GetValidatorsLiveness.
+func (mr *MockbeaconValidatorMockRecorder) GetValidatorsLiveness(epoch, validatorIndices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorsLiveness", reflect.TypeOf((*MockbeaconValidator)(nil).GetValidatorsLiveness), epoch, validatorIndices)
+}
+
 // Mockproposer is a mock of proposer interface.
 type Mockproposer struct {
 	ctrl     *gomock.Controller
@@ -180,6 +197,80 @@ func (mr *MockproposerMockRecorder) SubmitProposalPreparation(feeRecipients inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitProposalPreparation", reflect.TypeOf((*Mockproposer)(nil).SubmitProposalPreparation), feeRecipients)
 }
 
+// Mockexits is a mock of exits interface.
+type Mockexits struct {
+	ctrl     *gomock.Controller
+	recorder *MockexitsMockRecorder
+}
+
+// MockexitsMockRecorder is the mock recorder for Mockexits.
+type MockexitsMockRecorder struct {
+	mock *Mockexits
+}
+
+// NewMockexits creates a new mock instance.
+func NewMockexits(ctrl *gomock.Controller) *Mockexits {
+	mock := &Mockexits{ctrl: ctrl}
+	mock.recorder = &MockexitsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockexits) EXPECT() *MockexitsMockRecorder {
+	return m.recorder
+}
+
+// SubmitVoluntaryExit mocks base method.
+func (m *Mockexits) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitVoluntaryExit", voluntaryExit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitVoluntaryExit indicates an expected call of SubmitVoluntaryExit.
+func (mr *MockexitsMockRecorder) SubmitVoluntaryExit(voluntaryExit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitVoluntaryExit", reflect.TypeOf((*Mockexits)(nil).SubmitVoluntaryExit), voluntaryExit)
+}
+
+// MockbeaconEvents is a mock of beaconEvents interface.
+type MockbeaconEvents struct {
+	ctrl     *gomock.Controller
+	recorder *MockbeaconEventsMockRecorder
+}
+
+// MockbeaconEventsMockRecorder is the mock recorder for MockbeaconEvents.
+type MockbeaconEventsMockRecorder struct {
+	mock *MockbeaconEvents
+}
+
+// NewMockbeaconEvents creates a new mock instance.
+func NewMockbeaconEvents(ctrl *gomock.Controller) *MockbeaconEvents {
+	mock := &MockbeaconEvents{ctrl: ctrl}
+	mock.recorder = &MockbeaconEventsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbeaconEvents) EXPECT() *MockbeaconEventsMockRecorder {
+	return m.recorder
+}
+
+// EventsFeed mocks base method.
+func (m *MockbeaconEvents) EventsFeed() *event.Feed {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventsFeed")
+	ret0, _ := ret[0].(*event.Feed)
+	return ret0
+}
+
+// EventsFeed indicates an expected call of EventsFeed.
+func (mr *MockbeaconEventsMockRecorder) EventsFeed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsFeed", reflect.TypeOf((*MockbeaconEvents)(nil).EventsFeed))
+}
+
 // Mocksigner is a mock of signer interface.
 type Mocksigner struct {
 	ctrl     *gomock.Controller
@@ -271,6 +362,20 @@ func (mr *MockBeaconMockRecorder) DomainData(epoch, domain interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainData", reflect.TypeOf((*MockBeacon)(nil).DomainData), epoch, domain)
 }
 
+// EventsFeed mocks base method.
+func (m *MockBeacon) EventsFeed() *event.Feed {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventsFeed")
+	ret0, _ := ret[0].(*event.Feed)
+	return ret0
+}
+
+// EventsFeed indicates an expected call of EventsFeed.
+func (mr *MockBeaconMockRecorder) EventsFeed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventsFeed", reflect.TypeOf((*MockBeacon)(nil).EventsFeed))
+}
+
 // GetAttestationData mocks base method.
 func (m *MockBeacon) GetAttestationData(slot phase0.Slot, committeeIndex phase0.CommitteeIndex) (*phase0.AttestationData, error) {
 	m.ctrl.T.Helper()
@@ -331,12 +436,13 @@ func (mr *MockBeaconMockRecorder) GetBlindedBeaconBlock(slot, committeeIndex, gr
 }
 
 // GetDuties mocks base method.
-func (m *MockBeacon) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*types.Duty, error) {
+func (m *MockBeacon) GetDuties(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) ([]*types.Duty, DutiesDependentRoots, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetDuties", epoch, validatorIndices)
 	ret0, _ := ret[0].([]*types.Duty)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(DutiesDependentRoots)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetDuties indicates an expected call of GetDuties.
@@ -390,6 +496,21 @@ func (mr *MockBeaconMockRecorder) GetValidatorData(validatorPubKeys interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorData", reflect.TypeOf((*MockBeacon)(nil).GetValidatorData), validatorPubKeys)
 }
 
+// GetValidatorsLiveness mocks base method.
+func (m *MockBeacon) GetValidatorsLiveness(epoch phase0.Epoch, validatorIndices []phase0.ValidatorIndex) (map[phase0.ValidatorIndex]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorsLiveness", epoch, validatorIndices)
+	ret0, _ := ret[0].(map[phase0.ValidatorIndex]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorsLiveness indicates an expected call of GetValidatorsLiveness.
+func (mr *MockBeaconMockRecorder) GetValidatorsLiveness(epoch, validatorIndices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorsLiveness", reflect.TypeOf((*MockBeacon)(nil).GetValidatorsLiveness), epoch, validatorIndices)
+}
+
 // IsSyncCommitteeAggregator mocks base method.
 func (m *MockBeacon) IsSyncCommitteeAggregator(proof []byte) (bool, error) {
 	m.ctrl.T.Helper()
@@ -532,6 +653,20 @@ func (mr *MockBeaconMockRecorder) SubmitSyncMessage(msg interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitSyncMessage", reflect.TypeOf((*MockBeacon)(nil).SubmitSyncMessage), msg)
 }
 
+// SubmitVoluntaryExit mocks base method.
+func (m *MockBeacon) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitVoluntaryExit", voluntaryExit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitVoluntaryExit indicates an expected call of SubmitVoluntaryExit.
+func (mr *MockBeaconMockRecorder) SubmitVoluntaryExit(voluntaryExit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitVoluntaryExit", reflect.TypeOf((*MockBeacon)(nil).SubmitVoluntaryExit), voluntaryExit)
+}
+
 // SubscribeToCommitteeSubnet mocks base method.
 func (m *MockBeacon) SubscribeToCommitteeSubnet(subscription []*v1.BeaconCommitteeSubscription) error {
 	m.ctrl.T.Helper()
@@ -1,10 +1,12 @@
 package beacon
 
 import (
+	"encoding/hex"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/eth2-key-manager/core"
+	"github.com/pkg/errors"
 )
 
 // Network is a beacon chain network.
@@ -67,3 +69,23 @@ func (n Network) IsFirstSlotOfEpoch(slot phase0.Slot) bool {
 func (n Network) GetEpochFirstSlot(epoch phase0.Epoch) phase0.Slot {
 	return phase0.Slot(epoch * 32)
 }
+
+// GenesisValidatorsRoot returns the genesis validators root of the network
+func (n Network) GenesisValidatorsRoot() (phase0.Root, error) {
+	var root string
+	switch n.Network {
+	case core.MainNetwork:
+		root = "4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+	case core.PraterNetwork:
+		root = "043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"
+	default:
+		return phase0.Root{}, errors.Errorf("unknown genesis validators root for network %s", n.Network)
+	}
+	b, err := hex.DecodeString(root)
+	if err != nil {
+		return phase0.Root{}, errors.Wrap(err, "could not decode genesis validators root")
+	}
+	var ret phase0.Root
+	copy(ret[:], b)
+	return ret, nil
+}
@@ -0,0 +1,122 @@
+package qbftstorage
+
+import (
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+)
+
+//go:generate sszgen --path decided_record.go --objs DecidedRecord --output decided_record_encoding.go
+
+// decidedRecordVersion is the first byte of an encoded DecidedRecord,
+// it separates a DecidedRecord from a json encoded StoredInstance, which starts with '{'
+const decidedRecordVersion byte = 1
+
+// DecidedRecord is the compact form of a historical instance, rather than the whole instance state
+// it holds only the decided message, i.e. the commit data with the decided value and the aggregated signature
+type DecidedRecord struct {
+	Height     uint64
+	Round      uint64
+	Identifier []byte   `ssz-max:"64"`
+	Data       []byte   `ssz-max:"16777216"`
+	Signature  []byte   `ssz-max:"96"`
+	Signers    []uint64 `ssz-max:"13"`
+}
+
+// NewDecidedRecord creates a DecidedRecord of the given decided message
+func NewDecidedRecord(msg *specqbft.SignedMessage) (*DecidedRecord, error) {
+	if msg == nil || msg.Message == nil {
+		return nil, errors.New("missing decided message")
+	}
+	signers := make([]uint64, 0, len(msg.Signers))
+	for _, signer := range msg.Signers {
+		signers = append(signers, uint64(signer))
+	}
+	return &DecidedRecord{
+		Height:     uint64(msg.Message.Height),
+		Round:      uint64(msg.Message.Round),
+		Identifier: msg.Message.Identifier,
+		Data:       msg.Message.Data,
+		Signature:  msg.Signature,
+		Signers:    signers,
+	}, nil
+}
+
+// Encode returns the version byte followed by the ssz encoded record
+func (r *DecidedRecord) Encode() ([]byte, error) {
+	buf := make([]byte, 1, 1+r.SizeSSZ())
+	buf[0] = decidedRecordVersion
+	return r.MarshalSSZTo(buf)
+}
+
+// Decode decodes a record that was encoded by Encode
+func (r *DecidedRecord) Decode(data []byte) error {
+	if !IsDecidedRecord(data) {
+		return errors.New("not a decided record")
+	}
+	return r.UnmarshalSSZ(data[1:])
+}
+
+// SignedMessage returns the decided message of the record
+func (r *DecidedRecord) SignedMessage() *specqbft.SignedMessage {
+	signers := make([]spectypes.OperatorID, 0, len(r.Signers))
+	for _, signer := range r.Signers {
+		signers = append(signers, spectypes.OperatorID(signer))
+	}
+	return &specqbft.SignedMessage{
+		Signature: r.Signature,
+		Signers:   signers,
+		Message: &specqbft.Message{
+			MsgType:    specqbft.CommitMsgType,
+			Height:     specqbft.Height(r.Height),
+			Round:      specqbft.Round(r.Round),
+			Identifier: r.Identifier,
+			Data:       r.Data,
+		},
+	}
+}
+
+// StoredInstance returns a StoredInstance of the record, the state holds only the decided value
+// and the message containers are empty
+func (r *DecidedRecord) StoredInstance() (*StoredInstance, error) {
+	msg := r.SignedMessage()
+	commitData, err := msg.Message.GetCommitData()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode commit data")
+	}
+	return &StoredInstance{
+		State: &specqbft.State{
+			ID:                   r.Identifier,
+			Round:                specqbft.Round(r.Round),
+			Height:               specqbft.Height(r.Height),
+			Decided:              true,
+			DecidedValue:         commitData.Data,
+			ProposeContainer:     specqbft.NewMsgContainer(),
+			PrepareContainer:     specqbft.NewMsgContainer(),
+			CommitContainer:      specqbft.NewMsgContainer(),
+			RoundChangeContainer: specqbft.NewMsgContainer(),
+		},
+		DecidedMessage: msg,
+	}, nil
+}
+
+// IsDecidedRecord returns true if the given data is an encoded DecidedRecord
+func IsDecidedRecord(data []byte) bool {
+	return len(data) > 0 && data[0] == decidedRecordVersion
+}
+
+// DecodeHistoricalInstance decodes a historical instance that is saved either as a DecidedRecord or as a StoredInstance
+func DecodeHistoricalInstance(data []byte) (*StoredInstance, error) {
+	if IsDecidedRecord(data) {
+		record := &DecidedRecord{}
+		if err := record.Decode(data); err != nil {
+			return nil, err
+		}
+		return record.StoredInstance()
+	}
+	instance := &StoredInstance{}
+	if err := instance.Decode(data); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
@@ -0,0 +1,61 @@
+package qbftstorage
+
+import (
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecidedRecord(t *testing.T) {
+	id := spectypes.NewMsgID([]byte("pk"), spectypes.BNRoleAttester)
+	commitData, err := (&specqbft.CommitData{Data: []byte("decided value")}).Encode()
+	require.NoError(t, err)
+	msg := &specqbft.SignedMessage{
+		Signature: make([]byte, 96),
+		Signers:   []spectypes.OperatorID{1, 2, 4},
+		Message: &specqbft.Message{
+			MsgType:    specqbft.CommitMsgType,
+			Height:     10,
+			Round:      2,
+			Identifier: id[:],
+			Data:       commitData,
+		},
+	}
+
+	record, err := NewDecidedRecord(msg)
+	require.NoError(t, err)
+	data, err := record.Encode()
+	require.NoError(t, err)
+	require.True(t, IsDecidedRecord(data))
+
+	decoded := &DecidedRecord{}
+	require.NoError(t, decoded.Decode(data))
+	require.Equal(t, msg, decoded.SignedMessage())
+
+	instance, err := DecodeHistoricalInstance(data)
+	require.NoError(t, err)
+	require.Equal(t, msg, instance.DecidedMessage)
+	require.Equal(t, specqbft.Height(10), instance.State.Height)
+	require.Equal(t, specqbft.Round(2), instance.State.Round)
+	require.Equal(t, id[:], instance.State.ID)
+	require.True(t, instance.State.Decided)
+	require.Equal(t, []byte("decided value"), instance.State.DecidedValue)
+
+	// whole instances are decoded as well
+	data, err = instance.Encode()
+	require.NoError(t, err)
+	require.False(t, IsDecidedRecord(data))
+	instance, err = DecodeHistoricalInstance(data)
+	require.NoError(t, err)
+	require.Equal(t, msg, instance.DecidedMessage)
+
+	_, err = NewDecidedRecord(nil)
+	require.Error(t, err)
+	msg.Signers = make([]spectypes.OperatorID, 14)
+	record, err = NewDecidedRecord(msg)
+	require.NoError(t, err)
+	_, err = record.Encode()
+	require.Error(t, err)
+}
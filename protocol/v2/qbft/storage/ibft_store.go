@@ -3,6 +3,7 @@ package qbftstorage
 import (
 	"encoding/json"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	specqbft "github.com/bloxapp/ssv-spec/qbft"
 )
 
@@ -44,6 +45,30 @@ type InstanceStore interface {
 
 	// CleanAllInstances removes all historical and highest instances for the given identifier.
 	CleanAllInstances(msgID []byte) error
+
+	// PruneInstances removes the historical instances that are out of the given retention policy,
+	// the highest instances are never removed.
+	PruneInstances(policy RetentionPolicy, currentSlot phase0.Slot) (*PruneResult, error)
+}
+
+// RetentionPolicy defines which historical instances are kept, a zero limit keeps all instances
+type RetentionPolicy struct {
+	// Heights is the number of latest heights to keep per identifier
+	Heights uint64 `yaml:"Heights"`
+	// Slots is the number of latest slots to keep, by the slot of the decided duty
+	Slots uint64 `yaml:"Slots"`
+}
+
+// Enabled returns true if the policy prunes instances
+func (p RetentionPolicy) Enabled() bool {
+	return p.Heights > 0 || p.Slots > 0
+}
+
+// PruneResult holds the number and size of the pruned instances
+type PruneResult struct {
+	Pruned int
+	// ReclaimedBytes is the size of the keys and values that were removed
+	ReclaimedBytes int64
 }
 
 // QBFTStore is the store used by QBFT components
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv-spec/qbft"
 	specssv "github.com/bloxapp/ssv-spec/ssv"
@@ -15,6 +16,9 @@ import (
 
 type ValidatorRegistrationRunner struct {
 	BaseRunner *BaseRunner
+	// FeeRecipientF returns the fee recipient of the share, which can be updated while the validator is running.
+	// the fee recipient of the share is used if it's nil
+	FeeRecipientF func() bellatrix.ExecutionAddress `json:"-"`
 
 	beacon   specssv.BeaconNode
 	network  specssv.Network
@@ -136,8 +140,13 @@ func (r *ValidatorRegistrationRunner) calculateValidatorRegistration() (*v1.Vali
 
 	epoch := r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(r.BaseRunner.State.StartingDuty.Slot)
 
+	feeRecipient := r.BaseRunner.Share.FeeRecipientAddress
+	if r.FeeRecipientF != nil {
+		feeRecipient = r.FeeRecipientF()
+	}
+
 	return &v1.ValidatorRegistration{
-		FeeRecipient: r.BaseRunner.Share.FeeRecipientAddress,
+		FeeRecipient: feeRecipient,
 		GasLimit:     1,
 		Timestamp:    r.BaseRunner.BeaconNetwork.EpochStartTime(epoch),
 		Pubkey:       pk,
@@ -0,0 +1,205 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/bloxapp/ssv-spec/qbft"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// VoluntaryExitBeaconNode is the beacon node of the voluntary exit runner, which submits the reconstructed exit
+type VoluntaryExitBeaconNode interface {
+	specssv.BeaconNode
+	// SubmitVoluntaryExit submits a signed voluntary exit
+	SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error
+}
+
+// VoluntaryExitRunner exits the validator, the operators exchange partial signatures over the voluntary exit
+// of the duty's epoch and the exit is submitted once it is reconstructed, there is no consensus phase
+type VoluntaryExitRunner struct {
+	BaseRunner *BaseRunner
+
+	beacon   VoluntaryExitBeaconNode
+	network  specssv.Network
+	signer   spectypes.KeyManager
+	valCheck qbft.ProposedValueCheckF
+}
+
+func NewVoluntaryExitRunner(
+	beaconNetwork spectypes.BeaconNetwork,
+	share *spectypes.Share,
+	beacon VoluntaryExitBeaconNode,
+	network specssv.Network,
+	signer spectypes.KeyManager,
+) Runner {
+	return &VoluntaryExitRunner{
+		BaseRunner: &BaseRunner{
+			BeaconRoleType: types.BNRoleVoluntaryExit,
+			BeaconNetwork:  beaconNetwork,
+			Share:          share,
+		},
+
+		beacon:  beacon,
+		network: network,
+		signer:  signer,
+	}
+}
+
+// StartNewDuty starts a voluntary exit, a running exit is replaced as there is no consensus instance to wait for
+func (r *VoluntaryExitRunner) StartNewDuty(duty *spectypes.Duty) error {
+	r.BaseRunner.State = NewRunnerState(r.BaseRunner.Share.Quorum, duty)
+	return r.executeDuty(duty)
+}
+
+// HasRunningDuty returns true if a duty is already running (StartNewDuty called and returned nil)
+func (r *VoluntaryExitRunner) HasRunningDuty() bool {
+	return r.BaseRunner.hasRunningDuty()
+}
+
+func (r *VoluntaryExitRunner) ProcessPreConsensus(signedMsg *specssv.SignedPartialSignatureMessage) error {
+	quorum, roots, err := r.BaseRunner.basePreConsensusMsgProcessing(r, signedMsg)
+	if err != nil {
+		return errors.Wrap(err, "failed processing voluntary exit message")
+	}
+
+	// quorum returns true only once (first time quorum achieved)
+	if !quorum {
+		return nil
+	}
+
+	// only 1 root, verified in basePreConsensusMsgProcessing
+	root := roots[0]
+	fullSig, err := r.GetState().ReconstructBeaconSig(r.GetState().PreConsensusContainer, root, r.GetShare().ValidatorPubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not reconstruct voluntary exit sig")
+	}
+	signedExit := &phase0.SignedVoluntaryExit{
+		Message: r.calculateVoluntaryExit(),
+	}
+	copy(signedExit.Signature[:], fullSig)
+	if err := r.beacon.SubmitVoluntaryExit(signedExit); err != nil {
+		return errors.Wrap(err, "could not submit voluntary exit")
+	}
+
+	r.GetState().Finished = true
+	return nil
+}
+
+func (r *VoluntaryExitRunner) ProcessConsensus(signedMsg *qbft.SignedMessage) error {
+	return errors.New("no consensus phase for voluntary exit")
+}
+
+func (r *VoluntaryExitRunner) ProcessPostConsensus(signedMsg *specssv.SignedPartialSignatureMessage) error {
+	return errors.New("no post consensus phase for voluntary exit")
+}
+
+func (r *VoluntaryExitRunner) expectedPreConsensusRootsAndDomain() ([]ssz.HashRoot, phase0.DomainType, error) {
+	return []ssz.HashRoot{r.calculateVoluntaryExit()}, spectypes.DomainVoluntaryExit, nil
+}
+
+// expectedPostConsensusRootsAndDomain an INTERNAL function, returns the expected post-consensus roots to sign
+func (r *VoluntaryExitRunner) expectedPostConsensusRootsAndDomain() ([]ssz.HashRoot, phase0.DomainType, error) {
+	return nil, [4]byte{}, errors.New("no post consensus roots for voluntary exit")
+}
+
+func (r *VoluntaryExitRunner) executeDuty(duty *spectypes.Duty) error {
+	// sign partial voluntary exit
+	msg, err := r.BaseRunner.signBeaconObject(r, r.calculateVoluntaryExit(), duty.Slot, spectypes.DomainVoluntaryExit)
+	if err != nil {
+		return errors.Wrap(err, "could not sign voluntary exit")
+	}
+	msgs := specssv.PartialSignatureMessages{
+		Type:     types.VoluntaryExitPartialSig,
+		Messages: []*specssv.PartialSignatureMessage{msg},
+	}
+
+	// sign msg
+	signature, err := r.GetSigner().SignRoot(msgs, spectypes.PartialSignatureType, r.GetShare().SharePubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not sign voluntary exit msg")
+	}
+	signedPartialMsg := &specssv.SignedPartialSignatureMessage{
+		Message:   msgs,
+		Signature: signature,
+		Signer:    r.GetShare().OperatorID,
+	}
+
+	// broadcast
+	data, err := signedPartialMsg.Encode()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode voluntary exit pre-consensus signature msg")
+	}
+	msgToBroadcast := &spectypes.SSVMessage{
+		MsgType: spectypes.SSVPartialSignatureMsgType,
+		MsgID:   spectypes.NewMsgID(r.GetShare().ValidatorPubKey, r.BaseRunner.BeaconRoleType),
+		Data:    data,
+	}
+	if err := r.GetNetwork().Broadcast(msgToBroadcast); err != nil {
+		return errors.Wrap(err, "can't broadcast partial voluntary exit sig")
+	}
+	return nil
+}
+
+// calculateVoluntaryExit returns the voluntary exit of the duty, its epoch is the epoch of the duty's slot
+func (r *VoluntaryExitRunner) calculateVoluntaryExit() *phase0.VoluntaryExit {
+	duty := r.BaseRunner.State.StartingDuty
+	return &phase0.VoluntaryExit{
+		Epoch:          r.BaseRunner.BeaconNetwork.EstimatedEpochAtSlot(duty.Slot),
+		ValidatorIndex: duty.ValidatorIndex,
+	}
+}
+
+func (r *VoluntaryExitRunner) GetBaseRunner() *BaseRunner {
+	return r.BaseRunner
+}
+
+func (r *VoluntaryExitRunner) GetNetwork() specssv.Network {
+	return r.network
+}
+
+func (r *VoluntaryExitRunner) GetBeaconNode() specssv.BeaconNode {
+	return r.beacon
+}
+
+func (r *VoluntaryExitRunner) GetShare() *spectypes.Share {
+	return r.BaseRunner.Share
+}
+
+func (r *VoluntaryExitRunner) GetState() *State {
+	return r.BaseRunner.State
+}
+
+func (r *VoluntaryExitRunner) GetValCheckF() qbft.ProposedValueCheckF {
+	return r.valCheck
+}
+
+func (r *VoluntaryExitRunner) GetSigner() spectypes.KeyManager {
+	return r.signer
+}
+
+// Encode returns the encoded struct in bytes or error
+func (r *VoluntaryExitRunner) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// Decode returns error if decoding failed
+func (r *VoluntaryExitRunner) Decode(data []byte) error {
+	return json.Unmarshal(data, &r)
+}
+
+// GetRoot returns the root used for signing and verification
+func (r *VoluntaryExitRunner) GetRoot() ([]byte, error) {
+	marshaledRoot, err := r.Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode DutyRunnerState")
+	}
+	ret := sha256.Sum256(marshaledRoot)
+	return ret[:], nil
+}
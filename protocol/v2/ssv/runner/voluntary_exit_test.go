@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/bloxapp/ssv-spec/types/testingutils"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+// exitsBeaconNode records the submitted voluntary exits
+type exitsBeaconNode struct {
+	*testingutils.TestingBeaconNode
+	exits []*phase0.SignedVoluntaryExit
+}
+
+func (bn *exitsBeaconNode) SubmitVoluntaryExit(voluntaryExit *phase0.SignedVoluntaryExit) error {
+	bn.exits = append(bn.exits, voluntaryExit)
+	return nil
+}
+
+func TestVoluntaryExitRunner(t *testing.T) {
+	types.SetDefaultDomain(spectypes.PrimusTestnet)
+	ks := testingutils.Testing4SharesSet()
+	km := testingutils.NewTestingKeyManager()
+	beacon := &exitsBeaconNode{TestingBeaconNode: testingutils.NewTestingBeaconNode()}
+	duty := &spectypes.Duty{
+		Type:           types.BNRoleVoluntaryExit,
+		PubKey:         testingutils.TestingValidatorPubKey,
+		Slot:           testingutils.TestingDutySlot,
+		ValidatorIndex: testingutils.TestingValidatorIndex,
+	}
+
+	runners := make(map[spectypes.OperatorID]Runner)
+	var msgs []*specssv.SignedPartialSignatureMessage
+	for id := spectypes.OperatorID(1); id <= 3; id++ {
+		share := testingutils.TestingShare(ks)
+		share.OperatorID = id
+		share.SharePubKey = ks.Shares[id].GetPublicKey().Serialize()
+		network := testingutils.NewTestingNetwork()
+		r := NewVoluntaryExitRunner(spectypes.PraterNetwork, share, beacon, network, km)
+		require.NoError(t, r.StartNewDuty(duty))
+		runners[id] = r
+
+		require.Len(t, network.BroadcastedMsgs, 1)
+		require.Equal(t, spectypes.NewMsgID(share.ValidatorPubKey, types.BNRoleVoluntaryExit), network.BroadcastedMsgs[0].MsgID)
+		msg := &specssv.SignedPartialSignatureMessage{}
+		require.NoError(t, msg.Decode(network.BroadcastedMsgs[0].Data))
+		require.Equal(t, types.VoluntaryExitPartialSig, msg.Message.Type)
+		msgs = append(msgs, msg)
+	}
+
+	// the exit is reconstructed and submitted once there is a quorum of partial signatures
+	r := runners[1]
+	require.NoError(t, r.ProcessPreConsensus(msgs[0]))
+	require.NoError(t, r.ProcessPreConsensus(msgs[1]))
+	require.Empty(t, beacon.exits)
+	require.NoError(t, r.ProcessPreConsensus(msgs[2]))
+	require.Len(t, beacon.exits, 1)
+	require.False(t, r.HasRunningDuty())
+
+	exit := beacon.exits[0]
+	require.Equal(t, phase0.VoluntaryExit{
+		Epoch:          spectypes.PraterNetwork.EstimatedEpochAtSlot(duty.Slot),
+		ValidatorIndex: duty.ValidatorIndex,
+	}, *exit.Message)
+	domain, err := beacon.DomainData(exit.Message.Epoch, spectypes.DomainVoluntaryExit)
+	require.NoError(t, err)
+	root, err := spectypes.ComputeETHSigningRoot(exit.Message, domain)
+	require.NoError(t, err)
+	signature := exit.Signature
+	sig := &bls.Sign{}
+	require.NoError(t, sig.Deserialize(signature[:]))
+	require.True(t, sig.VerifyByte(ks.ValidatorPK, root[:]))
+
+	// an exit of another epoch has a different root
+	other := *duty
+	other.Slot += 32
+	require.NoError(t, runners[2].StartNewDuty(&other))
+	require.Error(t, runners[2].ProcessPreConsensus(msgs[0]))
+}
@@ -120,7 +120,7 @@ func (v *Validator) logMsg(msg *queue.DecodedSSVMessage, logMsg string, fields .
 // GetLastHeight returns the last height for the given identifier
 func (v *Validator) GetLastHeight(identifier spectypes.MessageID) specqbft.Height {
 	r := v.DutyRunners.DutyRunnerForMsgID(identifier)
-	if r == nil {
+	if r == nil || r.GetBaseRunner().QBFTController == nil {
 		return specqbft.Height(0)
 	}
 	return r.GetBaseRunner().QBFTController.Height
@@ -23,16 +23,22 @@ func (v *Validator) Start() error {
 				continue
 			}
 			identifier := spectypes.NewMsgID(r.GetBaseRunner().Share.ValidatorPubKey, role)
-			if err := r.GetBaseRunner().QBFTController.LoadHighestInstance(identifier[:]); err != nil {
-				v.logger.Warn("failed to load highest instance",
-					zap.String("identifier", identifier.String()),
-					zap.Error(err))
+			// runners without consensus (e.g. voluntary exit) have no controller to load or sync
+			hasController := r.GetBaseRunner().QBFTController != nil
+			if hasController {
+				if err := r.GetBaseRunner().QBFTController.LoadHighestInstance(identifier[:]); err != nil {
+					v.logger.Warn("failed to load highest instance",
+						zap.String("identifier", identifier.String()),
+						zap.Error(err))
+				}
 			}
 			if err := n.Subscribe(identifier.GetPubKey()); err != nil {
 				return err
 			}
 			go v.StartQueueConsumer(identifier, v.ProcessMessage)
-			go v.sync(identifier)
+			if hasController {
+				go v.sync(identifier)
+			}
 		}
 	}
 	return nil
@@ -3,6 +3,9 @@ package validator
 import (
 	"context"
 	"encoding/hex"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 
 	"github.com/bloxapp/ssv/protocol/v2/message"
 
@@ -39,6 +42,8 @@ type Validator struct {
 	Queues  map[spectypes.BeaconRole]queueContainer
 
 	state uint32
+	// feeRecipientLock guards the fee recipient of the share, which is updated by registry events
+	feeRecipientLock sync.RWMutex
 }
 
 // NewValidator creates a new instance of Validator.
@@ -64,6 +69,9 @@ func NewValidator(pctx context.Context, cancel func(), options Options) *Validat
 	for _, dutyRunner := range options.DutyRunners {
 		// set timeout F
 		dutyRunner.GetBaseRunner().TimeoutF = v.onTimeout
+		if r, ok := dutyRunner.(*runner.ValidatorRegistrationRunner); ok {
+			r.FeeRecipientF = v.FeeRecipient
+		}
 		v.Queues[dutyRunner.GetBaseRunner().BeaconRoleType] = queueContainer{
 			Q: queue.New(),
 			queueState: &queue.State{
@@ -78,6 +86,20 @@ func NewValidator(pctx context.Context, cancel func(), options Options) *Validat
 	return v
 }
 
+// FeeRecipient returns the fee recipient of the share
+func (v *Validator) FeeRecipient() bellatrix.ExecutionAddress {
+	v.feeRecipientLock.RLock()
+	defer v.feeRecipientLock.RUnlock()
+	return v.Share.FeeRecipientAddress
+}
+
+// UpdateFeeRecipient updates the fee recipient of the share, while the validator is running
+func (v *Validator) UpdateFeeRecipient(recipient bellatrix.ExecutionAddress) {
+	v.feeRecipientLock.Lock()
+	defer v.feeRecipientLock.Unlock()
+	v.Share.FeeRecipientAddress = recipient
+}
+
 // StartDuty starts a duty for the validator
 func (v *Validator) StartDuty(duty *spectypes.Duty) error {
 	dutyRunner := v.DutyRunners[duty.Type]
@@ -0,0 +1,45 @@
+package validator
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/protocol/v2/ssv/runner"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+)
+
+func TestValidator_UpdateFeeRecipient(t *testing.T) {
+	share := &types.SSVShare{Share: spectypes.Share{ValidatorPubKey: make([]byte, 48)}}
+	registration := runner.NewValidatorRegistrationRunner(spectypes.PraterNetwork, &share.Share, nil, nil, nil).(*runner.ValidatorRegistrationRunner)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	v := NewValidator(ctx, cancel, Options{
+		SSVShare:    share,
+		DutyRunners: runner.DutyRunners{spectypes.BNRoleValidatorRegistration: registration},
+	})
+	require.NotNil(t, registration.FeeRecipientF)
+
+	// the runner reads the fee recipient while it's updated by registry events
+	var wg sync.WaitGroup
+	for i := byte(1); i <= 10; i++ {
+		wg.Add(2)
+		go func(i byte) {
+			defer wg.Done()
+			v.UpdateFeeRecipient(bellatrix.ExecutionAddress{i})
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = registration.FeeRecipientF()
+		}()
+	}
+	wg.Wait()
+
+	v.UpdateFeeRecipient(bellatrix.ExecutionAddress{0xaa})
+	require.Equal(t, bellatrix.ExecutionAddress{0xaa}, registration.FeeRecipientF())
+	require.Equal(t, bellatrix.ExecutionAddress{0xaa}, v.FeeRecipient())
+}
@@ -0,0 +1,16 @@
+package types
+
+import (
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+)
+
+// BNRoleVoluntaryExit is the role of voluntary exit duties, which is not part of the spec roles.
+// it is set far out of the range of the spec roles, so roles that are added to the spec won't collide with it.
+// it is encoded in the message ids, therefore it must not change
+const BNRoleVoluntaryExit spectypes.BeaconRole = 1000
+
+// VoluntaryExitPartialSig is a partial signature over a VoluntaryExit object, which is not part of the spec types.
+// it is set far out of the range of the spec types, so types that are added to the spec won't collide with it.
+// it is encoded in the partial signature messages, therefore it must not change
+const VoluntaryExitPartialSig specssv.PartialSigMsgType = 1000
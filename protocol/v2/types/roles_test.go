@@ -0,0 +1,18 @@
+package types
+
+import (
+	"testing"
+
+	specssv "github.com/bloxapp/ssv-spec/ssv"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoles(t *testing.T) {
+	require.Greater(t, BNRoleVoluntaryExit, spectypes.BNRoleValidatorRegistration)
+	require.Greater(t, VoluntaryExitPartialSig, specssv.ValidatorRegistrationPartialSig)
+
+	// the role is encoded in the message id
+	msgID := spectypes.NewMsgID([]byte{1, 2, 3}, BNRoleVoluntaryExit)
+	require.Equal(t, BNRoleVoluntaryExit, msgID.GetRoleType())
+}
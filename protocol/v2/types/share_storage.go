@@ -0,0 +1,29 @@
+package types
+
+// storageShare is the ssz encoded form of SSVShare,
+// slices are ssz lists so that missing values don't fail the encoding
+type storageShare struct {
+	OperatorID          uint64
+	ValidatorPubKey     []byte             `ssz-max:"48"`
+	SharePubKey         []byte             `ssz-max:"48"`
+	Committee           []*storageOperator `ssz-max:"13"`
+	Quorum              uint64
+	PartialQuorum       uint64
+	DomainType          []byte   `ssz-max:"32"`
+	FeeRecipientAddress [20]byte `ssz-size:"20"`
+	Graffiti            []byte   `ssz-max:"32"`
+
+	HasBeaconMetadata bool
+	Balance           uint64
+	Status            uint64
+	Index             uint64
+	OwnerAddress      []byte   `ssz-max:"128"`
+	Operators         [][]byte `ssz-max:"13,2048"`
+	Liquidated        bool
+}
+
+// storageOperator is the ssz encoded form of a committee member
+type storageOperator struct {
+	OperatorID uint64
+	PubKey     []byte `ssz-max:"48"`
+}
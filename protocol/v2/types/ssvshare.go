@@ -5,6 +5,8 @@ import (
 	"encoding/gob"
 	"fmt"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 
 	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
@@ -16,19 +18,51 @@ type SSVShare struct {
 	Metadata
 }
 
-// Encode encodes SSVShare using gob.
+const (
+	// shareEncodingPrefix is the first byte of a versioned share encoding,
+	// a legacy gob encoding never starts with it as gob messages are never empty
+	shareEncodingPrefix byte = 0
+	// shareEncodingVersion is the version of the ssz encoding of storageShare
+	shareEncodingVersion byte = 1
+)
+
+// Encode encodes SSVShare using ssz, prefixed by the encoding version.
 func (s *SSVShare) Encode() ([]byte, error) {
-	var b bytes.Buffer
-	e := gob.NewEncoder(&b)
-	if err := e.Encode(s); err != nil {
+	stored := s.toStorage()
+	buf := make([]byte, 2, 2+stored.SizeSSZ())
+	buf[0], buf[1] = shareEncodingPrefix, shareEncodingVersion
+	data, err := stored.MarshalSSZTo(buf)
+	if err != nil {
 		return nil, fmt.Errorf("encode SSVShare: %w", err)
 	}
 
-	return b.Bytes(), nil
+	return data, nil
 }
 
-// Decode decodes SSVShare using gob.
+// Decode decodes SSVShare, either versioned ssz or legacy gob.
 func (s *SSVShare) Decode(data []byte) error {
+	if IsLegacyShareEncoding(data) {
+		return s.decodeGob(data)
+	}
+	if len(data) < 2 || data[1] != shareEncodingVersion {
+		return fmt.Errorf("decode SSVShare: unknown encoding version")
+	}
+	stored := &storageShare{}
+	if err := stored.UnmarshalSSZ(data[2:]); err != nil {
+		return fmt.Errorf("decode SSVShare: %w", err)
+	}
+	s.fromStorage(stored)
+
+	return nil
+}
+
+// IsLegacyShareEncoding returns true if the given data is a gob encoded SSVShare.
+func IsLegacyShareEncoding(data []byte) bool {
+	return len(data) > 0 && data[0] != shareEncodingPrefix
+}
+
+// decodeGob decodes SSVShare using gob, which is the legacy encoding.
+func (s *SSVShare) decodeGob(data []byte) error {
 	d := gob.NewDecoder(bytes.NewReader(data))
 	if err := d.Decode(s); err != nil {
 		return fmt.Errorf("decode SSVShare: %w", err)
@@ -37,6 +71,78 @@ func (s *SSVShare) Decode(data []byte) error {
 	return nil
 }
 
+func (s *SSVShare) toStorage() *storageShare {
+	stored := &storageShare{
+		OperatorID:          uint64(s.OperatorID),
+		ValidatorPubKey:     s.ValidatorPubKey,
+		SharePubKey:         s.SharePubKey,
+		Quorum:              s.Quorum,
+		PartialQuorum:       s.PartialQuorum,
+		DomainType:          s.DomainType,
+		FeeRecipientAddress: s.FeeRecipientAddress,
+		Graffiti:            s.Graffiti,
+		OwnerAddress:        []byte(s.OwnerAddress),
+		Operators:           s.Operators,
+		Liquidated:          s.Liquidated,
+	}
+	for _, operator := range s.Committee {
+		stored.Committee = append(stored.Committee, &storageOperator{
+			OperatorID: uint64(operator.OperatorID),
+			PubKey:     operator.PubKey,
+		})
+	}
+	if s.BeaconMetadata != nil {
+		stored.HasBeaconMetadata = true
+		stored.Balance = uint64(s.BeaconMetadata.Balance)
+		stored.Status = uint64(s.BeaconMetadata.Status)
+		stored.Index = uint64(s.BeaconMetadata.Index)
+	}
+	return stored
+}
+
+// fromStorage sets the share from its stored form, empty slices are set to nil as in gob
+func (s *SSVShare) fromStorage(stored *storageShare) {
+	*s = SSVShare{
+		Share: spectypes.Share{
+			OperatorID:          spectypes.OperatorID(stored.OperatorID),
+			ValidatorPubKey:     nilIfEmpty(stored.ValidatorPubKey),
+			SharePubKey:         nilIfEmpty(stored.SharePubKey),
+			Quorum:              stored.Quorum,
+			PartialQuorum:       stored.PartialQuorum,
+			DomainType:          nilIfEmpty(stored.DomainType),
+			FeeRecipientAddress: stored.FeeRecipientAddress,
+			Graffiti:            nilIfEmpty(stored.Graffiti),
+		},
+		Metadata: Metadata{
+			OwnerAddress: string(stored.OwnerAddress),
+			Liquidated:   stored.Liquidated,
+		},
+	}
+	for _, operator := range stored.Committee {
+		s.Committee = append(s.Committee, &spectypes.Operator{
+			OperatorID: spectypes.OperatorID(operator.OperatorID),
+			PubKey:     nilIfEmpty(operator.PubKey),
+		})
+	}
+	for _, pk := range stored.Operators {
+		s.Operators = append(s.Operators, nilIfEmpty(pk))
+	}
+	if stored.HasBeaconMetadata {
+		s.BeaconMetadata = &beaconprotocol.ValidatorMetadata{
+			Balance: phase0.Gwei(stored.Balance),
+			Status:  eth2apiv1.ValidatorState(stored.Status),
+			Index:   phase0.ValidatorIndex(stored.Index),
+		}
+	}
+}
+
+func nilIfEmpty(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
 // BelongsToOperator checks whether the share belongs to operator.
 func (s *SSVShare) BelongsToOperator(operatorPubKey string) bool {
 	for _, pk := range s.Operators {
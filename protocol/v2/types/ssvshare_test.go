@@ -1,8 +1,12 @@
 package types
 
 import (
+	"bytes"
+	"encoding/gob"
 	"testing"
 
+	eth2apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	spectypes "github.com/bloxapp/ssv-spec/types"
 	"github.com/stretchr/testify/require"
 
@@ -101,3 +105,114 @@ func TestSSVShare_SetOperators(t *testing.T) {
 	metadata.SetOperators(operators)
 	require.EqualValues(t, operators, metadata.Operators)
 }
+
+func TestSSVShare_Encoding(t *testing.T) {
+	share := &SSVShare{
+		Share: spectypes.Share{
+			OperatorID:      1,
+			ValidatorPubKey: bytes.Repeat([]byte{1}, 48),
+			SharePubKey:     bytes.Repeat([]byte{2}, 48),
+			Committee: []*spectypes.Operator{
+				{OperatorID: 1, PubKey: bytes.Repeat([]byte{2}, 48)},
+				{OperatorID: 2, PubKey: bytes.Repeat([]byte{3}, 48)},
+				{OperatorID: 3, PubKey: bytes.Repeat([]byte{4}, 48)},
+				{OperatorID: 4, PubKey: bytes.Repeat([]byte{5}, 48)},
+			},
+			Quorum:              3,
+			PartialQuorum:       2,
+			DomainType:          spectypes.PrimusTestnet,
+			FeeRecipientAddress: bellatrix.ExecutionAddress{1, 2, 3},
+			Graffiti:            []byte("ssv.network"),
+		},
+		Metadata: Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{
+				Balance: 32000000000,
+				Status:  eth2apiv1.ValidatorStateActiveOngoing,
+				Index:   100,
+			},
+			OwnerAddress: "0x0000000000000000000000000000000000000001",
+			Operators:    [][]byte{[]byte("pk1"), []byte("pk2"), []byte("pk3"), []byte("pk4")},
+			Liquidated:   true,
+		},
+	}
+
+	t.Run("ssz", func(t *testing.T) {
+		data, err := share.Encode()
+		require.NoError(t, err)
+		require.False(t, IsLegacyShareEncoding(data))
+
+		decoded := &SSVShare{}
+		require.NoError(t, decoded.Decode(data))
+		require.Equal(t, share, decoded)
+	})
+
+	t.Run("legacy gob", func(t *testing.T) {
+		var b bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&b).Encode(share))
+		require.True(t, IsLegacyShareEncoding(b.Bytes()))
+
+		decoded := &SSVShare{}
+		require.NoError(t, decoded.Decode(b.Bytes()))
+		require.Equal(t, share, decoded)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		// missing values are decoded as in gob
+		empty := &SSVShare{Share: spectypes.Share{Graffiti: []byte{}}}
+		data, err := empty.Encode()
+		require.NoError(t, err)
+		decoded := &SSVShare{}
+		require.NoError(t, decoded.Decode(data))
+		require.Equal(t, &SSVShare{}, decoded)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		data, err := share.Encode()
+		require.NoError(t, err)
+		data[1] = 100
+		require.Error(t, (&SSVShare{}).Decode(data))
+	})
+
+	t.Run("too many operators", func(t *testing.T) {
+		invalid := &SSVShare{Metadata: Metadata{Operators: make([][]byte, 14)}}
+		_, err := invalid.Encode()
+		require.Error(t, err)
+	})
+}
+
+func BenchmarkSSVShare_Decode(b *testing.B) {
+	share := &SSVShare{
+		Share: spectypes.Share{
+			OperatorID:      1,
+			ValidatorPubKey: bytes.Repeat([]byte{1}, 48),
+			SharePubKey:     bytes.Repeat([]byte{2}, 48),
+			Committee: []*spectypes.Operator{
+				{OperatorID: 1, PubKey: bytes.Repeat([]byte{2}, 48)},
+				{OperatorID: 2, PubKey: bytes.Repeat([]byte{3}, 48)},
+				{OperatorID: 3, PubKey: bytes.Repeat([]byte{4}, 48)},
+				{OperatorID: 4, PubKey: bytes.Repeat([]byte{5}, 48)},
+			},
+			Quorum:        3,
+			PartialQuorum: 2,
+		},
+		Metadata: Metadata{
+			BeaconMetadata: &beaconprotocol.ValidatorMetadata{Index: 100},
+			Operators:      [][]byte{[]byte("pk1"), []byte("pk2"), []byte("pk3"), []byte("pk4")},
+		},
+	}
+	sszData, err := share.Encode()
+	require.NoError(b, err)
+	var gobData bytes.Buffer
+	require.NoError(b, gob.NewEncoder(&gobData).Encode(share))
+
+	b.Run("ssz", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, (&SSVShare{}).Decode(sszData))
+		}
+	})
+	b.Run("gob", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, (&SSVShare{}).Decode(gobData.Bytes()))
+		}
+	})
+}
@@ -113,15 +113,17 @@ func (s *operatorsStorage) getOperatorData(index uint64) (*OperatorData, bool, e
 
 func (s *operatorsStorage) listOperators(from, to uint64) ([]OperatorData, error) {
 	var operators []OperatorData
-	err := s.db.GetAll(append(s.prefix, operatorsPrefix...), func(i int, obj basedb.Obj) error {
-		var od OperatorData
-		if err := json.Unmarshal(obj.Value, &od); err != nil {
-			return err
-		}
-		if (od.Index >= from && od.Index <= to) || (to == 0) {
-			operators = append(operators, od)
-		}
-		return nil
+	err := s.db.View(func(txn basedb.ReadTxn) error {
+		return txn.GetAll(append(s.prefix, operatorsPrefix...), func(i int, obj basedb.Obj) error {
+			var od OperatorData
+			if err := json.Unmarshal(obj.Value, &od); err != nil {
+				return err
+			}
+			if (od.Index >= from && od.Index <= to) || (to == 0) {
+				operators = append(operators, od)
+			}
+			return nil
+		})
 	})
 
 	return operators, err
@@ -132,30 +134,33 @@ func (s *operatorsStorage) SaveOperatorData(operatorData *OperatorData) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	if operatorData.Index == 0 {
-		nextIndex, err := s.nextIndex()
-		if err != nil {
-			return errors.Wrap(err, "could not calculate next operator index")
+	// the index is calculated and the operator is saved in a single transaction
+	return s.db.Update(func(txn basedb.Txn) error {
+		if operatorData.Index == 0 {
+			nextIndex, err := s.nextIndex(txn)
+			if err != nil {
+				return errors.Wrap(err, "could not calculate next operator index")
+			}
+			operatorData.Index = nextIndex
 		}
-		operatorData.Index = uint64(nextIndex)
-	}
 
-	_, found, err := s.getOperatorData(operatorData.Index)
-	if err != nil {
-		return errors.Wrap(err, "could not get operator's data")
-	}
-	if found {
-		s.logger.Debug("operator already exist",
-			zap.String("pubKey", operatorData.PublicKey),
-			zap.Uint64("index", operatorData.Index))
-		return nil
-	}
+		_, found, err := txn.Get(s.prefix, buildOperatorKey(operatorData.Index))
+		if err != nil {
+			return errors.Wrap(err, "could not get operator's data")
+		}
+		if found {
+			s.logger.Debug("operator already exist",
+				zap.String("pubKey", operatorData.PublicKey),
+				zap.Uint64("index", operatorData.Index))
+			return nil
+		}
 
-	raw, err := json.Marshal(operatorData)
-	if err != nil {
-		return errors.Wrap(err, "could not marshal operator information")
-	}
-	return s.db.Set(s.prefix, buildOperatorKey(operatorData.Index), raw)
+		raw, err := json.Marshal(operatorData)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal operator information")
+		}
+		return txn.Set(s.prefix, buildOperatorKey(operatorData.Index), raw)
+	})
 }
 
 func (s *operatorsStorage) DeleteOperatorData(index uint64) error {
@@ -170,6 +175,11 @@ func buildOperatorKey(index uint64) []byte {
 	return bytes.Join([][]byte{operatorsPrefix, []byte(strconv.FormatUint(index, 10))}, []byte("/"))
 }
 
-func (s *operatorsStorage) nextIndex() (int64, error) {
-	return s.db.CountByCollection(append(s.prefix, operatorsPrefix...))
+func (s *operatorsStorage) nextIndex(txn basedb.ReadTxn) (uint64, error) {
+	var count uint64
+	err := txn.GetAll(append(s.prefix, operatorsPrefix...), func(i int, obj basedb.Obj) error {
+		count++
+		return nil
+	})
+	return count, err
 }
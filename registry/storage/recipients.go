@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+var (
+	recipientsPrefix = []byte("recipients")
+)
+
+// RecipientData the fee recipient of the validators of an owner
+type RecipientData struct {
+	OwnerAddress common.Address `json:"ownerAddress"`
+	FeeRecipient common.Address `json:"feeRecipient"`
+}
+
+// RecipientsCollection is the interface for managing the fee recipients of owners
+type RecipientsCollection interface {
+	GetRecipientData(owner common.Address) (*RecipientData, bool, error)
+	SaveRecipientData(recipientData *RecipientData) error
+	DeleteRecipientData(owner common.Address) error
+	GetRecipientsPrefix() []byte
+}
+
+type recipientsStorage struct {
+	db     basedb.IDb
+	logger *zap.Logger
+	lock   sync.RWMutex
+	prefix []byte
+}
+
+// NewRecipientsStorage creates a new instance of Storage
+func NewRecipientsStorage(db basedb.IDb, logger *zap.Logger, prefix []byte) RecipientsCollection {
+	return &recipientsStorage{
+		db:     db,
+		logger: logger.With(zap.String("component", fmt.Sprintf("%srecipients", prefix))),
+		prefix: prefix,
+	}
+}
+
+// GetRecipientsPrefix returns the prefix
+func (s *recipientsStorage) GetRecipientsPrefix() []byte {
+	return recipientsPrefix
+}
+
+// GetRecipientData returns the fee recipient of the given owner
+func (s *recipientsStorage) GetRecipientData(owner common.Address) (*RecipientData, bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	obj, found, err := s.db.Get(s.prefix, buildRecipientKey(owner))
+	if err != nil {
+		return nil, found, err
+	}
+	if !found {
+		return nil, found, nil
+	}
+	var recipientData RecipientData
+	err = json.Unmarshal(obj.Value, &recipientData)
+	return &recipientData, found, err
+}
+
+// SaveRecipientData saves the fee recipient of an owner, it replaces the previous one
+func (s *recipientsStorage) SaveRecipientData(recipientData *RecipientData) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	raw, err := json.Marshal(recipientData)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal recipient data")
+	}
+	return s.db.Set(s.prefix, buildRecipientKey(recipientData.OwnerAddress), raw)
+}
+
+// DeleteRecipientData removes the fee recipient of the given owner
+func (s *recipientsStorage) DeleteRecipientData(owner common.Address) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Delete(s.prefix, buildRecipientKey(owner))
+}
+
+// buildRecipientKey builds recipient key using recipientsPrefix & owner address, e.g. "recipients/0x00..."
+func buildRecipientKey(owner common.Address) []byte {
+	return bytes.Join([][]byte{recipientsPrefix, []byte(strings.ToLower(owner.Hex()))}, []byte("/"))
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	ssvstorage "github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestStorage_SaveAndGetRecipientData(t *testing.T) {
+	db, err := ssvstorage.GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+	storage := NewRecipientsStorage(db, zap.L(), []byte("test"))
+
+	owner := common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F")
+	recipientData, found, err := storage.GetRecipientData(owner)
+	require.NoError(t, err)
+	require.False(t, found)
+	require.Nil(t, recipientData)
+
+	// the last recipient of the owner is kept
+	require.NoError(t, storage.SaveRecipientData(&RecipientData{OwnerAddress: owner, FeeRecipient: common.HexToAddress("0x1")}))
+	require.NoError(t, storage.SaveRecipientData(&RecipientData{OwnerAddress: owner, FeeRecipient: common.HexToAddress("0x2")}))
+	recipientData, found, err = storage.GetRecipientData(owner)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, owner, recipientData.OwnerAddress)
+	require.Equal(t, common.HexToAddress("0x2"), recipientData.FeeRecipient)
+
+	require.NoError(t, storage.DeleteRecipientData(owner))
+	_, found, err = storage.GetRecipientData(owner)
+	require.NoError(t, err)
+	require.False(t, found)
+}
@@ -8,19 +8,29 @@ import (
 
 // Options for creating all db type
 type Options struct {
-	Type      string `yaml:"Type" env:"DB_TYPE" env-default:"badger-db" env-description:"Type of db badger-db or badger-memory"`
+	Type      string `yaml:"Type" env:"DB_TYPE" env-default:"badger-db" env-description:"Type of db badger-db, badger-memory or bolt-db"`
 	Path      string `yaml:"Path" env:"DB_PATH" env-default:"./data/db" env-description:"Path for storage"`
 	Reporting bool   `yaml:"Reporting" env:"DB_REPORTING" env-default:"false" env-description:"Flag to run on-off db size reporting"`
 	Logger    *zap.Logger
 	Ctx       context.Context
 }
 
+// ReadTxn interface for badger read-only transaction like functions,
+// all the reads of a transaction are done over the same snapshot
+type ReadTxn interface {
+	Get(prefix []byte, key []byte) (Obj, bool, error)
+	// GetAll iterates over the items of a given collection by key order, keys are trimmed from the prefix
+	GetAll(prefix []byte, handler func(int, Obj) error) error
+	// GetRange iterates over the items of a given collection whose (trimmed) keys are in [from, to),
+	// a nil from or to leaves the range open on that side. reverse iterates by descending key order
+	GetRange(prefix []byte, from []byte, to []byte, reverse bool, handler func(int, Obj) error) error
+}
+
 // Txn interface for badger transaction like functions
 type Txn interface {
+	ReadTxn
 	Set(prefix []byte, key []byte, value []byte) error
-	Get(prefix []byte, key []byte) (Obj, bool, error)
 	Delete(prefix []byte, key []byte) error
-	// TODO: add iterator
 }
 
 // IDb interface for all db kind
@@ -35,6 +45,7 @@ type IDb interface {
 	CountByCollection(prefix []byte) (int64, error)
 	RemoveAllByCollection(prefix []byte) error
 	Update(fn func(Txn) error) error
+	View(fn func(ReadTxn) error) error
 	Close()
 }
 
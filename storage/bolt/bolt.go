@@ -0,0 +1,294 @@
+package bolt
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+const (
+	// fileName is the name of the db file inside the db path
+	fileName = "ssv.db"
+)
+
+// bucketName is the single bucket that holds all the items, keys are stored with their prefix
+// so the keys order is the same as in badger
+var bucketName = []byte("ssv")
+
+// BoltDb struct
+type BoltDb struct {
+	db     *bolt.DB
+	logger *zap.Logger
+}
+
+// New create new instance of bolt db, the db file is created inside the db path
+func New(options basedb.Options) (basedb.IDb, error) {
+	if err := os.MkdirAll(options.Path, 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to create db dir")
+	}
+	db, err := bolt.Open(filepath.Join(options.Path, fileName), 0600, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open bolt")
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "failed to create bucket")
+	}
+
+	options.Logger.Info("bolt db initialized")
+	return &BoltDb{
+		db:     db,
+		logger: options.Logger,
+	}, nil
+}
+
+// Set save value with key to storage
+func (b *BoltDb) Set(prefix []byte, key []byte, value []byte) error {
+	return b.Update(func(txn basedb.Txn) error {
+		return txn.Set(prefix, key, value)
+	})
+}
+
+// SetMany save many values with the given keys in a single bolt transaction
+func (b *BoltDb) SetMany(prefix []byte, n int, next func(int) (basedb.Obj, error)) error {
+	return b.Update(func(txn basedb.Txn) error {
+		for i := 0; i < n; i++ {
+			item, err := next(i)
+			if err != nil {
+				return err
+			}
+			if err := txn.Set(prefix, item.Key, item.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Get return value for specified key
+func (b *BoltDb) Get(prefix []byte, key []byte) (obj basedb.Obj, found bool, err error) {
+	err = b.View(func(txn basedb.ReadTxn) error {
+		obj, found, err = txn.Get(prefix, key)
+		return err
+	})
+	return obj, found, err
+}
+
+// GetMany return values for the given keys
+func (b *BoltDb) GetMany(prefix []byte, keys [][]byte, iterator func(basedb.Obj) error) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return b.View(func(txn basedb.ReadTxn) error {
+		for _, k := range keys {
+			obj, found, err := txn.Get(prefix, k)
+			if err != nil {
+				return err
+			}
+			if !found {
+				b.logger.Debug("item not found", zap.String("key", string(k)))
+				continue
+			}
+			if err := iterator(obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete key in specific prefix
+func (b *BoltDb) Delete(prefix []byte, key []byte) error {
+	return b.Update(func(txn basedb.Txn) error {
+		return txn.Delete(prefix, key)
+	})
+}
+
+// DeleteByPrefix all items with this prefix
+func (b *BoltDb) DeleteByPrefix(prefix []byte) (int, error) {
+	count := 0
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+		// keys are collected first, as deleting while iterating skips items
+		var keys [][]byte
+		c := bucket.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			keys = append(keys, append([]byte{}, k...))
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// GetAll returns all the items of a given collection
+func (b *BoltDb) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	return b.View(func(txn basedb.ReadTxn) error {
+		return txn.GetAll(prefix, handler)
+	})
+}
+
+// CountByCollection return the object count for all keys under specified prefix(bucket)
+func (b *BoltDb) CountByCollection(prefix []byte) (int64, error) {
+	var res int64
+	err := b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketName).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			res++
+		}
+		return nil
+	})
+	return res, err
+}
+
+// RemoveAllByCollection cleans all items in a collection
+func (b *BoltDb) RemoveAllByCollection(prefix []byte) error {
+	_, err := b.DeleteByPrefix(prefix)
+	return err
+}
+
+// Update is a gateway to bolt db Update function
+// creating and managing a read-write transaction
+func (b *BoltDb) Update(fn func(basedb.Txn) error) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTxn{bucket: tx.Bucket(bucketName)})
+	})
+}
+
+// View is a gateway to bolt db View function
+// creating and managing a read-only transaction
+func (b *BoltDb) View(fn func(basedb.ReadTxn) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTxn{bucket: tx.Bucket(bucketName)})
+	})
+}
+
+// Backup writes a consistent snapshot of the db, which is a copy of the db file.
+// it is restored by placing it as the db file of an empty db path
+func (b *BoltDb) Backup(w io.Writer) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(w)
+		return err
+	})
+}
+
+// Close close db
+func (b *BoltDb) Close() {
+	if err := b.db.Close(); err != nil {
+		b.logger.Fatal("failed to close db", zap.Error(err))
+	}
+}
+
+// boltTxn wraps the bucket of a transaction, the values it returns are copied
+// as bolt values are valid only for the life of the transaction
+type boltTxn struct {
+	bucket *bolt.Bucket
+}
+
+func (t *boltTxn) Set(prefix []byte, key []byte, value []byte) error {
+	return t.bucket.Put(fullKey(prefix, key), value)
+}
+
+func (t *boltTxn) Get(prefix []byte, key []byte) (basedb.Obj, bool, error) {
+	value := t.bucket.Get(fullKey(prefix, key))
+	if value == nil {
+		return basedb.Obj{}, false, nil
+	}
+	return basedb.Obj{
+		Key:   key,
+		Value: append([]byte{}, value...),
+	}, true, nil
+}
+
+func (t *boltTxn) Delete(prefix []byte, key []byte) error {
+	return t.bucket.Delete(fullKey(prefix, key))
+}
+
+func (t *boltTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	return t.GetRange(prefix, nil, nil, false, handler)
+}
+
+func (t *boltTxn) GetRange(prefix []byte, from []byte, to []byte, reverse bool, handler func(int, basedb.Obj) error) error {
+	var lower, upper []byte
+	if from != nil {
+		lower = fullKey(prefix, from)
+	}
+	if to != nil {
+		upper = fullKey(prefix, to)
+	}
+
+	inRange := func(k []byte) bool {
+		return k != nil && bytes.HasPrefix(k, prefix) &&
+			(lower == nil || bytes.Compare(k, lower) >= 0) &&
+			(upper == nil || bytes.Compare(k, upper) < 0)
+	}
+
+	c := t.bucket.Cursor()
+	var k, v []byte
+	switch {
+	case !reverse && lower != nil:
+		k, v = c.Seek(lower)
+	case !reverse:
+		k, v = c.Seek(prefix)
+	default:
+		// the last key before the upper bound, or before the first key after the prefix
+		if upper == nil {
+			upper = prefixUpperBound(prefix)
+		}
+		if upper == nil {
+			k, v = c.Last()
+		} else if k, v = c.Seek(upper); k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+	}
+
+	for i := 0; inRange(k); i++ {
+		if err := handler(i, basedb.Obj{
+			Key:   append([]byte{}, k[len(prefix):]...),
+			Value: append([]byte{}, v...),
+		}); err != nil {
+			return err
+		}
+		if reverse {
+			k, v = c.Prev()
+		} else {
+			k, v = c.Next()
+		}
+	}
+	return nil
+}
+
+func fullKey(prefix []byte, key []byte) []byte {
+	return append(append(make([]byte, 0, len(prefix)+len(key)), prefix...), key...)
+}
+
+// prefixUpperBound returns the lowest key that is greater than all the keys with the given prefix,
+// or nil if there is no such key (an empty prefix or a prefix of 0xff bytes)
+func prefixUpperBound(prefix []byte) []byte {
+	upper := append([]byte{}, prefix...)
+	for i := len(upper) - 1; i >= 0; i-- {
+		if upper[i] < 0xff {
+			upper[i]++
+			return upper[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package bolt
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/storagetest"
+)
+
+func TestBoltConformance(t *testing.T) {
+	storagetest.RunConformance(t, func(t *testing.T) basedb.IDb {
+		db, err := New(basedb.Options{
+			Type:   "bolt-db",
+			Logger: zap.L(),
+			Path:   t.TempDir(),
+		})
+		require.NoError(t, err)
+		return db
+	})
+}
+
+func TestBoltReopen(t *testing.T) {
+	options := basedb.Options{
+		Type:   "bolt-db",
+		Logger: zap.L(),
+		Path:   t.TempDir(),
+	}
+	db, err := New(options)
+	require.NoError(t, err)
+	require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+	db.Close()
+
+	db, err = New(options)
+	require.NoError(t, err)
+	defer db.Close()
+	obj, found, err := db.Get([]byte("prefix"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+}
+
+func TestBoltBackup(t *testing.T) {
+	db, err := New(basedb.Options{
+		Type:   "bolt-db",
+		Logger: zap.L(),
+		Path:   t.TempDir(),
+	})
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.Set([]byte("prefix"), []byte("key"), []byte("value")))
+
+	var b bytes.Buffer
+	require.NoError(t, db.(*BoltDb).Backup(&b))
+
+	path := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(path, fileName), b.Bytes(), 0600))
+	restored, err := New(basedb.Options{
+		Type:   "bolt-db",
+		Logger: zap.L(),
+		Path:   path,
+	})
+	require.NoError(t, err)
+	defer restored.Close()
+	obj, found, err := restored.Get([]byte("prefix"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), obj.Value)
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// copyBatchSize is the number of items that are written to the target in a single transaction
+const copyBatchSize = 1000
+
+// Copy copies all the items of the source db into the target db, which must be empty.
+// the source is read over a single snapshot, returns the number of copied items
+func Copy(source, target basedb.IDb) (int, error) {
+	count, err := target.CountByCollection(nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not count target items")
+	}
+	if count > 0 {
+		return 0, errors.Errorf("target db is not empty, found %d items", count)
+	}
+
+	copied := 0
+	batch := make([]basedb.Obj, 0, copyBatchSize)
+	flush := func() error {
+		err := target.SetMany(nil, len(batch), func(i int) (basedb.Obj, error) {
+			return batch[i], nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not write items")
+		}
+		copied += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+	err = source.View(func(txn basedb.ReadTxn) error {
+		return txn.GetAll(nil, func(i int, obj basedb.Obj) error {
+			batch = append(batch, obj)
+			if len(batch) < copyBatchSize {
+				return nil
+			}
+			return flush()
+		})
+	})
+	if err != nil {
+		return copied, errors.Wrap(err, "could not read items")
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return copied, err
+		}
+	}
+	return copied, nil
+}
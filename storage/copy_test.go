@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestCopy(t *testing.T) {
+	source, err := GetStorageFactory(basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	})
+	require.NoError(t, err)
+	defer source.Close()
+
+	n := copyBatchSize*2 + 10
+	require.NoError(t, source.SetMany([]byte("prefix1/"), n, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte(fmt.Sprintf("%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}, nil
+	}))
+	require.NoError(t, source.Set([]byte("prefix2/"), []byte("key"), []byte("value")))
+
+	target, err := GetStorageFactory(basedb.Options{
+		Type:   "bolt-db",
+		Logger: zap.L(),
+		Path:   t.TempDir(),
+	})
+	require.NoError(t, err)
+	defer target.Close()
+
+	copied, err := Copy(source, target)
+	require.NoError(t, err)
+	require.Equal(t, n+1, copied)
+
+	count, err := target.CountByCollection([]byte("prefix1/"))
+	require.NoError(t, err)
+	require.Equal(t, int64(n), count)
+	obj, found, err := target.Get([]byte("prefix1/"), []byte("7"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value7"), obj.Value)
+	_, found, err = target.Get([]byte("prefix2/"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+
+	_, err = Copy(source, target)
+	require.EqualError(t, err, fmt.Sprintf("target db is not empty, found %d items", n+1))
+}
@@ -0,0 +1,326 @@
+package inspect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/pkg/errors"
+
+	beaconprotocol "github.com/bloxapp/ssv/protocol/v2/blockchain/beacon"
+	qbftstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// key layout of the node storages, see operator/storage, registry/storage, operator/validator and ibft/storage
+var (
+	operatorsPrefix  = []byte("operator-operators/")
+	syncOffsetKey    = []byte("operator-syncOffset")
+	nodePrefix       = []byte("operator-")
+	sharesPrefix     = []byte("share-")
+	migrationsPrefix = []byte("migrations/")
+	signerDataPrefix = []byte("signer_data-")
+
+	highestInstanceKey = []byte("highest_instance")
+	instanceKey        = []byte("instance")
+
+	qbftRoles = []spectypes.BeaconRole{
+		spectypes.BNRoleAttester,
+		spectypes.BNRoleAggregator,
+		spectypes.BNRoleProposer,
+		// sync committee contribution must precede sync committee, as its prefix is longer
+		spectypes.BNRoleSyncCommitteeContribution,
+		spectypes.BNRoleSyncCommittee,
+	}
+)
+
+// unknownCollection groups the keys that don't belong to a known collection
+const unknownCollection = "unknown"
+
+// keySizer is implemented by the db types that can iterate keys along with the sizes of their values
+// without fetching the values
+type keySizer interface {
+	KeySizes(prefix []byte, handler func(key []byte, valueSize int64) error) error
+}
+
+// checksumVerifier is implemented by the db types that can verify the checksums of their tables
+type checksumVerifier interface {
+	VerifyChecksum() error
+}
+
+// CollectionStats holds the number and size of the keys in a collection
+type CollectionStats struct {
+	Name       string `json:"name"`
+	Keys       int64  `json:"keys"`
+	KeysSize   int64  `json:"keysSize"`
+	ValuesSize int64  `json:"valuesSize"`
+}
+
+// CollectionName returns the name of the collection of the given raw key
+func CollectionName(key []byte) string {
+	switch {
+	case bytes.HasPrefix(key, operatorsPrefix):
+		return "operators"
+	case bytes.HasPrefix(key, syncOffsetKey):
+		return "sync-offset"
+	case bytes.HasPrefix(key, nodePrefix):
+		return "node"
+	case bytes.HasPrefix(key, sharesPrefix):
+		return "shares"
+	case bytes.HasPrefix(key, migrationsPrefix):
+		return "migrations"
+	}
+	// signer data is prefixed with the network name, e.g. "pratersigner_data-accounts-"
+	if i := bytes.Index(key, signerDataPrefix); i >= 0 {
+		rest := key[i+len(signerDataPrefix):]
+		if j := bytes.IndexByte(rest, '-'); j > 0 {
+			return "signer-" + string(rest[:j])
+		}
+		return "signer"
+	}
+	for _, role := range qbftRoles {
+		if bytes.HasPrefix(key, []byte(role.String())) {
+			return "qbft-" + strings.ToLower(role.String())
+		}
+	}
+	return unknownCollection
+}
+
+// Stats returns the number and size of the keys of every collection, sorted by name
+func Stats(db basedb.IDb) ([]*CollectionStats, error) {
+	stats := make(map[string]*CollectionStats)
+	count := func(key []byte, valueSize int64) error {
+		name := CollectionName(key)
+		s, ok := stats[name]
+		if !ok {
+			s = &CollectionStats{Name: name}
+			stats[name] = s
+		}
+		s.Keys++
+		s.KeysSize += int64(len(key))
+		s.ValuesSize += valueSize
+		return nil
+	}
+	var err error
+	if ks, ok := db.(keySizer); ok {
+		err = ks.KeySizes(nil, count)
+	} else {
+		err = db.GetAll(nil, func(i int, obj basedb.Obj) error {
+			return count(obj.Key, int64(len(obj.Value)))
+		})
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not iterate keys")
+	}
+
+	res := make([]*CollectionStats, 0, len(stats))
+	for _, s := range stats {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// Contents is a readable representation of the node data, share secrets and the operator key are not included
+type Contents struct {
+	SyncOffset string                          `json:"syncOffset,omitempty"`
+	Operators  []*registrystorage.OperatorData `json:"operators"`
+	Shares     []*Share                        `json:"shares"`
+	Instances  []*Instance                     `json:"instances"`
+	// Errors lists the values that could not be decoded
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Share is a readable representation of types.SSVShare
+type Share struct {
+	ValidatorPubKey     string                            `json:"validatorPubKey"`
+	OperatorID          spectypes.OperatorID              `json:"operatorId"`
+	SharePubKey         string                            `json:"sharePubKey"`
+	Committee           []*CommitteeMember                `json:"committee"`
+	Quorum              uint64                            `json:"quorum"`
+	PartialQuorum       uint64                            `json:"partialQuorum"`
+	DomainType          string                            `json:"domainType"`
+	FeeRecipientAddress string                            `json:"feeRecipientAddress"`
+	Graffiti            string                            `json:"graffiti"`
+	OwnerAddress        string                            `json:"ownerAddress"`
+	Liquidated          bool                              `json:"liquidated"`
+	BeaconMetadata      *beaconprotocol.ValidatorMetadata `json:"beaconMetadata,omitempty"`
+}
+
+// CommitteeMember is a readable representation of a committee operator
+type CommitteeMember struct {
+	OperatorID  spectypes.OperatorID `json:"operatorId"`
+	SharePubKey string               `json:"sharePubKey"`
+}
+
+// Instance is a stored QBFT instance
+type Instance struct {
+	Role            string                      `json:"role"`
+	Identifier      string                      `json:"identifier"`
+	ValidatorPubKey string                      `json:"validatorPubKey"`
+	Highest         bool                        `json:"highest"`
+	Height          specqbft.Height             `json:"height"`
+	Instance        *qbftstorage.StoredInstance `json:"instance"`
+}
+
+// Dump decodes the sync offset, operators, shares and QBFT instances in the db
+func Dump(db basedb.IDb) (*Contents, error) {
+	d := &Contents{
+		Operators: make([]*registrystorage.OperatorData, 0),
+		Shares:    make([]*Share, 0),
+		Instances: make([]*Instance, 0),
+	}
+
+	obj, found, err := db.Get(syncOffsetKey, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync offset")
+	}
+	if found {
+		d.SyncOffset = new(big.Int).SetBytes(obj.Value).String()
+	}
+
+	err = db.GetAll(operatorsPrefix, func(i int, obj basedb.Obj) error {
+		var od registrystorage.OperatorData
+		if err := json.Unmarshal(obj.Value, &od); err != nil {
+			d.addError(operatorsPrefix, obj.Key, err)
+			return nil
+		}
+		d.Operators = append(d.Operators, &od)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get operators")
+	}
+
+	err = db.GetAll(sharesPrefix, func(i int, obj basedb.Obj) error {
+		share := &types.SSVShare{}
+		if err := share.Decode(obj.Value); err != nil {
+			d.addError(sharesPrefix, obj.Key, err)
+			return nil
+		}
+		d.Shares = append(d.Shares, newShare(share))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get shares")
+	}
+
+	for _, role := range qbftRoles {
+		prefix := []byte(role.String())
+		err := db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+			instance, err := decodeInstance(role, obj)
+			if err != nil {
+				d.addError(prefix, obj.Key, err)
+				return nil
+			}
+			if instance != nil {
+				d.Instances = append(d.Instances, instance)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get %s instances", role.String())
+		}
+	}
+	return d, nil
+}
+
+// Verify verifies the checksums of the db tables if the db type supports it, and that all the known values can be decoded.
+// returns the list of problems that were found
+func Verify(db basedb.IDb) ([]string, error) {
+	if cv, ok := db.(checksumVerifier); ok {
+		if err := cv.VerifyChecksum(); err != nil {
+			return []string{fmt.Sprintf("checksum verification failed: %s", err)}, nil
+		}
+	}
+	d, err := Dump(db)
+	if err != nil {
+		return nil, err
+	}
+	return d.Errors, nil
+}
+
+func (d *Contents) addError(prefix, key []byte, err error) {
+	d.Errors = append(d.Errors, fmt.Sprintf("could not decode %s%s: %s", prefix, hex.EncodeToString(key), err))
+}
+
+// decodeInstance decodes a QBFT storage key and value, the key is an identifier followed by
+// either the highest instance key or the instance key and the height.
+// the sync committee prefix also matches sync committee contribution keys, which are skipped
+func decodeInstance(role spectypes.BeaconRole, obj basedb.Obj) (*Instance, error) {
+	if role == spectypes.BNRoleSyncCommittee && bytes.HasPrefix(obj.Key, []byte(strings.TrimPrefix(
+		spectypes.BNRoleSyncCommitteeContribution.String(), spectypes.BNRoleSyncCommittee.String()))) {
+		return nil, nil
+	}
+	if len(obj.Key) < len(spectypes.MessageID{}) {
+		return nil, errors.New("key is too short")
+	}
+	identifier := obj.Key[:len(spectypes.MessageID{})]
+	rest := obj.Key[len(identifier):]
+
+	instance := &Instance{
+		Role:            role.String(),
+		Identifier:      hex.EncodeToString(identifier),
+		ValidatorPubKey: hex.EncodeToString(spectypes.MessageIDFromBytes(identifier).GetPubKey()),
+	}
+	switch {
+	case bytes.Equal(rest, highestInstanceKey):
+		instance.Highest = true
+	case bytes.HasPrefix(rest, instanceKey) && len(rest) == len(instanceKey)+8:
+		instance.Height = specqbft.Height(binary.LittleEndian.Uint64(rest[len(instanceKey):]))
+	default:
+		return nil, errors.New("unknown qbft key")
+	}
+
+	if instance.Highest {
+		instance.Instance = &qbftstorage.StoredInstance{}
+		if err := instance.Instance.Decode(obj.Value); err != nil {
+			return nil, err
+		}
+	} else {
+		// historical instances are either whole or compact decided records
+		stored, err := qbftstorage.DecodeHistoricalInstance(obj.Value)
+		if err != nil {
+			return nil, err
+		}
+		instance.Instance = stored
+	}
+	if instance.Highest && instance.Instance.State != nil {
+		instance.Height = instance.Instance.State.Height
+	}
+	return instance, nil
+}
+
+func newShare(share *types.SSVShare) *Share {
+	s := &Share{
+		ValidatorPubKey:     hex.EncodeToString(share.ValidatorPubKey),
+		OperatorID:          share.OperatorID,
+		SharePubKey:         hex.EncodeToString(share.SharePubKey),
+		Quorum:              share.Quorum,
+		PartialQuorum:       share.PartialQuorum,
+		DomainType:          hex.EncodeToString(share.DomainType),
+		FeeRecipientAddress: "0x" + hex.EncodeToString(share.FeeRecipientAddress[:]),
+		Graffiti:            string(share.Graffiti),
+		OwnerAddress:        share.OwnerAddress,
+		Liquidated:          share.Liquidated,
+		BeaconMetadata:      share.BeaconMetadata,
+	}
+	for _, operator := range share.Committee {
+		s.Committee = append(s.Committee, &CommitteeMember{
+			OperatorID:  operator.OperatorID,
+			SharePubKey: hex.EncodeToString(operator.PubKey),
+		})
+	}
+	return s
+}
@@ -0,0 +1,117 @@
+package inspect
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	specqbft "github.com/bloxapp/ssv-spec/qbft"
+	spectypes "github.com/bloxapp/ssv-spec/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	qbftstorage "github.com/bloxapp/ssv/ibft/storage"
+	forksprotocol "github.com/bloxapp/ssv/protocol/forks"
+	protocolstorage "github.com/bloxapp/ssv/protocol/v2/qbft/storage"
+	"github.com/bloxapp/ssv/protocol/v2/types"
+	registrystorage "github.com/bloxapp/ssv/registry/storage"
+	"github.com/bloxapp/ssv/storage"
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+func TestInspect(t *testing.T) {
+	for _, dbType := range []string{"badger-memory", "bolt-db"} {
+		t.Run(dbType, func(t *testing.T) {
+			db, err := storage.GetStorageFactory(basedb.Options{
+				Type:   dbType,
+				Logger: zap.L(),
+				Path:   t.TempDir(),
+			})
+			require.NoError(t, err)
+			defer db.Close()
+			testInspect(t, db)
+		})
+	}
+}
+
+func testInspect(t *testing.T, db basedb.IDb) {
+	pk := make([]byte, 48)
+	pk[0] = 1
+	share := &types.SSVShare{
+		Share: spectypes.Share{
+			OperatorID:      1,
+			ValidatorPubKey: pk,
+			Committee:       []*spectypes.Operator{{OperatorID: 1, PubKey: pk}},
+			Quorum:          3,
+			PartialQuorum:   2,
+			DomainType:      spectypes.PrimusTestnet,
+		},
+		Metadata: types.Metadata{OwnerAddress: "0x1"},
+	}
+	value, err := share.Encode()
+	require.NoError(t, err)
+	require.NoError(t, db.Set(sharesPrefix, pk, value))
+
+	operators := registrystorage.NewOperatorsStorage(db, zap.L(), []byte("operator-"))
+	require.NoError(t, operators.SaveOperatorData(&registrystorage.OperatorData{Index: 1, PublicKey: "pk", Name: "operator"}))
+	require.NoError(t, db.Set(nodePrefix, []byte("syncOffset"), []byte{0x10}))
+
+	for _, role := range []spectypes.BeaconRole{spectypes.BNRoleSyncCommittee, spectypes.BNRoleSyncCommitteeContribution} {
+		identifier := spectypes.NewMsgID(pk, role)
+		store := qbftstorage.New(db, zap.L(), role.String(), forksprotocol.GenesisForkVersion)
+		require.NoError(t, store.SaveHighestAndHistoricalInstance(&protocolstorage.StoredInstance{
+			State: &specqbft.State{ID: identifier[:], Height: 5},
+		}))
+	}
+
+	require.NoError(t, db.Set([]byte("pratersigner_data-"), []byte("accounts-key"), []byte("secret")))
+	require.NoError(t, db.Set([]byte("other"), []byte("key"), []byte("value")))
+
+	stats, err := Stats(db)
+	require.NoError(t, err)
+	counts := make(map[string]int64)
+	for _, s := range stats {
+		counts[s.Name] = s.Keys
+	}
+	require.Equal(t, map[string]int64{
+		"operators":                        1,
+		"sync-offset":                      1,
+		"shares":                           1,
+		"signer-accounts":                  1,
+		"qbft-sync_committee":              2,
+		"qbft-sync_committee_contribution": 2,
+		unknownCollection:                  1,
+	}, counts)
+
+	d, err := Dump(db)
+	require.NoError(t, err)
+	require.Empty(t, d.Errors)
+	require.Equal(t, "16", d.SyncOffset)
+	require.Len(t, d.Operators, 1)
+	require.Equal(t, "operator", d.Operators[0].Name)
+	require.Len(t, d.Shares, 1)
+	require.Equal(t, hex.EncodeToString(pk), d.Shares[0].ValidatorPubKey)
+	require.Equal(t, "0x1", d.Shares[0].OwnerAddress)
+	require.Len(t, d.Instances, 4)
+	roles := make(map[string]int)
+	for _, instance := range d.Instances {
+		require.Equal(t, specqbft.Height(5), instance.Height)
+		require.Equal(t, hex.EncodeToString(pk), instance.ValidatorPubKey)
+		roles[instance.Role]++
+	}
+	require.Equal(t, map[string]int{"SYNC_COMMITTEE": 2, "SYNC_COMMITTEE_CONTRIBUTION": 2}, roles)
+
+	// secrets are never dumped
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret")
+
+	problems, err := Verify(db)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+
+	require.NoError(t, db.Set(sharesPrefix, []byte("broken"), []byte("not a share")))
+	problems, err = Verify(db)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+}
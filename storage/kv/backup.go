@@ -0,0 +1,45 @@
+package kv
+
+import (
+	"io"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// maxPendingWrites is the number of pending writes when loading a backup
+const maxPendingWrites = 256
+
+// Backup writes a consistent snapshot of the latest version of all keys, in badger backup format
+func (b *BadgerDb) Backup(w io.Writer) error {
+	_, err := b.db.Backup(w, 0)
+	return err
+}
+
+// Restore loads a snapshot that was created with Backup
+func (b *BadgerDb) Restore(r io.Reader) error {
+	return b.db.Load(r, maxPendingWrites)
+}
+
+// VerifyChecksum verifies the checksums of all the db tables
+func (b *BadgerDb) VerifyChecksum() error {
+	return b.db.VerifyChecksum()
+}
+
+// KeySizes iterates over the keys under the given prefix along with the sizes of their values,
+// without fetching the values
+func (b *BadgerDb) KeySizes(prefix []byte, handler func(key []byte, valueSize int64) error) error {
+	return b.db.View(func(txn *badger.Txn) error {
+		opt := badger.DefaultIteratorOptions
+		opt.Prefix = prefix
+		opt.PrefetchValues = false
+		it := txn.NewIterator(opt)
+		defer it.Close()
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			if err := handler(item.KeyCopy(nil), item.ValueSize()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -243,6 +243,14 @@ func (b *BadgerDb) Update(fn func(basedb.Txn) error) error {
 	})
 }
 
+// View is a gateway to badger db View function
+// creating and managing a read-only transaction
+func (b *BadgerDb) View(fn func(basedb.ReadTxn) error) error {
+	return b.db.View(func(txn *badger.Txn) error {
+		return fn(&badgerTxn{txn: txn})
+	})
+}
+
 func isNotFoundError(err error) bool {
 	return err != nil && (err.Error() == "not found" || err.Error() == "Key not found")
 }
@@ -277,3 +285,85 @@ func (t badgerTxn) Get(prefix []byte, key []byte) (obj basedb.Obj, found bool, e
 func (t badgerTxn) Delete(prefix []byte, key []byte) error {
 	return t.txn.Delete(append(prefix, key...))
 }
+
+func (t badgerTxn) GetAll(prefix []byte, handler func(int, basedb.Obj) error) error {
+	return t.GetRange(prefix, nil, nil, false, handler)
+}
+
+func (t badgerTxn) GetRange(prefix []byte, from []byte, to []byte, reverse bool, handler func(int, basedb.Obj) error) error {
+	var lower, upper []byte
+	if from != nil {
+		lower = append(append([]byte{}, prefix...), from...)
+	}
+	if to != nil {
+		upper = append(append([]byte{}, prefix...), to...)
+	} else {
+		upper = prefixUpperBound(prefix)
+	}
+
+	opt := badger.DefaultIteratorOptions
+	opt.Reverse = reverse
+	seek := lower
+	if reverse {
+		// reverse seek lands on the highest key that is lower than or equal to the upper bound,
+		// which has to be skipped as the upper bound is exclusive
+		seek = upper
+	} else {
+		// prefix can't be used when iterating in reverse, as the seek might land outside of it
+		opt.Prefix = prefix
+		if seek == nil {
+			seek = prefix
+		}
+	}
+	it := t.txn.NewIterator(opt)
+	defer it.Close()
+
+	i := 0
+	for it.Seek(seek); it.Valid(); it.Next() {
+		item := it.Item()
+		k := item.Key()
+		if !bytes.HasPrefix(k, prefix) {
+			if reverse && upper != nil && bytes.Compare(k, upper) >= 0 {
+				continue
+			}
+			break
+		}
+		if upper != nil && bytes.Compare(k, upper) >= 0 {
+			if reverse {
+				continue
+			}
+			break
+		}
+		if lower != nil && bytes.Compare(k, lower) < 0 {
+			if reverse {
+				break
+			}
+			continue
+		}
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		if err := handler(i, basedb.Obj{
+			Key:   item.KeyCopy(nil)[len(prefix):],
+			Value: val,
+		}); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// prefixUpperBound returns the lowest key that is greater than all the keys with the given prefix,
+// or nil if there is no such key (an empty prefix or a prefix of 0xff bytes)
+func prefixUpperBound(prefix []byte) []byte {
+	upper := append([]byte{}, prefix...)
+	for i := len(upper) - 1; i >= 0; i-- {
+		if upper[i] < 0xff {
+			upper[i]++
+			return upper[:i+1]
+		}
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap/zaptest"
 
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/storagetest"
 )
 
 func TestBadgerEndToEnd(t *testing.T) {
@@ -206,3 +207,59 @@ func getAllTest(t *testing.T, n int, db basedb.IDb) {
 	require.NoError(t, err)
 	require.Equal(t, n, count)
 }
+
+func TestBadgerBackupRestore(t *testing.T) {
+	options := basedb.Options{
+		Type:   "badger-memory",
+		Logger: zap.L(),
+		Path:   "",
+	}
+
+	db, err := New(options)
+	require.NoError(t, err)
+	defer db.Close()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, db.Set([]byte("prefix1"), []byte(fmt.Sprintf("key%d", i)), []byte("value")))
+	}
+	require.NoError(t, db.Set([]byte("prefix2"), []byte("key"), []byte("longer value")))
+	require.NoError(t, db.Delete([]byte("prefix1"), []byte("key0")))
+	require.NoError(t, db.(*BadgerDb).VerifyChecksum())
+
+	sizes := make(map[string]int64)
+	require.NoError(t, db.(*BadgerDb).KeySizes([]byte("prefix2"), func(key []byte, valueSize int64) error {
+		sizes[string(key)] = valueSize
+		return nil
+	}))
+	require.Equal(t, map[string]int64{"prefix2key": 12}, sizes)
+
+	var backup bytes.Buffer
+	require.NoError(t, db.(*BadgerDb).Backup(&backup))
+
+	restored, err := New(options)
+	require.NoError(t, err)
+	defer restored.Close()
+	require.NoError(t, restored.(*BadgerDb).Restore(&backup))
+
+	count, err := restored.CountByCollection([]byte("prefix1"))
+	require.NoError(t, err)
+	require.Equal(t, int64(99), count)
+	obj, found, err := restored.Get([]byte("prefix2"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("longer value"), obj.Value)
+	_, found, err = restored.Get([]byte("prefix1"), []byte("key0"))
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestBadgerConformance(t *testing.T) {
+	storagetest.RunConformance(t, func(t *testing.T) basedb.IDb {
+		db, err := New(basedb.Options{
+			Type:   "badger-memory",
+			Logger: zap.L(),
+			Path:   "",
+		})
+		require.NoError(t, err)
+		return db
+	})
+}
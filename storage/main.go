@@ -3,6 +3,7 @@ package storage
 import (
 	"fmt"
 	"github.com/bloxapp/ssv/storage/basedb"
+	"github.com/bloxapp/ssv/storage/bolt"
 	"github.com/bloxapp/ssv/storage/kv"
 )
 
@@ -15,6 +16,9 @@ func GetStorageFactory(options basedb.Options) (basedb.IDb, error) {
 	case "badger-memory":
 		db, err := kv.New(options)
 		return db, err
+	case "bolt-db":
+		db, err := bolt.New(options)
+		return db, err
 	}
 	return nil, fmt.Errorf("unsupported storage type passed")
 }
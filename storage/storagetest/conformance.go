@@ -0,0 +1,284 @@
+package storagetest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/storage/basedb"
+)
+
+// RunConformance runs the tests that every basedb.IDb implementation must pass,
+// newDb must return a new empty db, which is closed by the tests
+func RunConformance(t *testing.T, newDb func(t *testing.T) basedb.IDb) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, db basedb.IDb)
+	}{
+		{"SetGetDelete", testSetGetDelete},
+		{"SetMany", testSetMany},
+		{"GetMany", testGetMany},
+		{"GetAll", testGetAll},
+		{"DeleteByPrefix", testDeleteByPrefix},
+		{"CountByCollection", testCountByCollection},
+		{"RemoveAllByCollection", testRemoveAllByCollection},
+		{"Update", testUpdate},
+		{"View", testView},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			db := newDb(t)
+			defer db.Close()
+			test.test(t, db)
+		})
+	}
+}
+
+func testSetGetDelete(t *testing.T, db basedb.IDb) {
+	prefix := []byte("prefix")
+	require.NoError(t, db.Set(prefix, []byte("key"), []byte("value")))
+	obj, found, err := db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("key"), obj.Key)
+	require.Equal(t, []byte("value"), obj.Value)
+
+	require.NoError(t, db.Set(prefix, []byte("key"), []byte("other")))
+	obj, found, err = db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("other"), obj.Value)
+
+	_, found, err = db.Get([]byte("other"), []byte("key"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, db.Delete(prefix, []byte("key")))
+	_, found, err = db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.False(t, found)
+	// deleting a missing key is not an error
+	require.NoError(t, db.Delete(prefix, []byte("key")))
+}
+
+func testSetMany(t *testing.T, db basedb.IDb) {
+	prefix := []byte("prefix")
+	require.NoError(t, db.SetMany(prefix, 10, func(i int) (basedb.Obj, error) {
+		return basedb.Obj{Key: []byte{byte(i)}, Value: []byte{byte(i)}}, nil
+	}))
+	for i := 0; i < 10; i++ {
+		obj, found, err := db.Get(prefix, []byte{byte(i)})
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte{byte(i)}, obj.Value)
+	}
+
+	// a failure cancels the batch
+	err := db.SetMany(prefix, 10, func(i int) (basedb.Obj, error) {
+		if i == 5 {
+			return basedb.Obj{}, errors.New("test")
+		}
+		return basedb.Obj{Key: []byte{byte(i + 10)}, Value: []byte{byte(i)}}, nil
+	})
+	require.EqualError(t, err, "test")
+	count, err := db.CountByCollection(prefix)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), count)
+}
+
+func testGetMany(t *testing.T, db basedb.IDb) {
+	prefix := []byte("prefix")
+	for i := 0; i < 10; i++ {
+		require.NoError(t, db.Set(prefix, []byte{byte(i)}, []byte{byte(i)}))
+	}
+
+	var results []basedb.Obj
+	require.NoError(t, db.GetMany(prefix, [][]byte{{1}, {5}, {20}, {9}}, func(obj basedb.Obj) error {
+		results = append(results, obj)
+		return nil
+	}))
+	// missing keys are skipped
+	require.Equal(t, []basedb.Obj{
+		{Key: []byte{1}, Value: []byte{1}},
+		{Key: []byte{5}, Value: []byte{5}},
+		{Key: []byte{9}, Value: []byte{9}},
+	}, results)
+
+	require.NoError(t, db.GetMany(prefix, nil, func(obj basedb.Obj) error {
+		return errors.New("should not be called")
+	}))
+	err := db.GetMany(prefix, [][]byte{{1}}, func(obj basedb.Obj) error {
+		return errors.New("test")
+	})
+	require.EqualError(t, err, "test")
+}
+
+func testGetAll(t *testing.T, db basedb.IDb) {
+	prefix := []byte("prefix")
+	n := 1000
+	require.NoError(t, db.SetMany(prefix, n, func(i int) (basedb.Obj, error) {
+		key := []byte(fmt.Sprintf("%04d", i))
+		return basedb.Obj{Key: key, Value: append([]byte("value-"), key...)}, nil
+	}))
+	require.NoError(t, db.Set([]byte("prefiw"), []byte("0000"), []byte("before")))
+	require.NoError(t, db.Set([]byte("prefiy"), []byte("0000"), []byte("after")))
+
+	count := 0
+	require.NoError(t, db.GetAll(prefix, func(i int, obj basedb.Obj) error {
+		require.Equal(t, count, i)
+		require.Equal(t, []byte(fmt.Sprintf("%04d", i)), obj.Key)
+		require.Equal(t, append([]byte("value-"), obj.Key...), obj.Value)
+		count++
+		return nil
+	}))
+	require.Equal(t, n, count)
+}
+
+func testDeleteByPrefix(t *testing.T, db basedb.IDb) {
+	for i := 0; i < 10; i++ {
+		require.NoError(t, db.Set([]byte("prefix1"), []byte{byte(i)}, []byte("value")))
+	}
+	require.NoError(t, db.Set([]byte("prefix2"), []byte("key"), []byte("value")))
+
+	deleted, err := db.DeleteByPrefix([]byte("prefix1"))
+	require.NoError(t, err)
+	require.Equal(t, 10, deleted)
+	deleted, err = db.DeleteByPrefix([]byte("prefix1"))
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+
+	_, found, err := db.Get([]byte("prefix2"), []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+}
+
+func testCountByCollection(t *testing.T, db basedb.IDb) {
+	for i := 0; i < 10; i++ {
+		require.NoError(t, db.Set([]byte("prefix1"), []byte{byte(i)}, []byte("value")))
+	}
+	require.NoError(t, db.Set([]byte("prefix2"), []byte("key"), []byte("value")))
+
+	count, err := db.CountByCollection([]byte("prefix1"))
+	require.NoError(t, err)
+	require.Equal(t, int64(10), count)
+	count, err = db.CountByCollection([]byte("prefix"))
+	require.NoError(t, err)
+	require.Equal(t, int64(11), count)
+	count, err = db.CountByCollection([]byte("missing"))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+}
+
+func testRemoveAllByCollection(t *testing.T, db basedb.IDb) {
+	for i := 0; i < 10; i++ {
+		require.NoError(t, db.Set([]byte("prefix1"), []byte{byte(i)}, []byte("value")))
+	}
+	require.NoError(t, db.Set([]byte("prefix2"), []byte("key"), []byte("value")))
+
+	require.NoError(t, db.RemoveAllByCollection([]byte("prefix1")))
+	count, err := db.CountByCollection([]byte("prefix1"))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+	count, err = db.CountByCollection([]byte("prefix2"))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func testUpdate(t *testing.T, db basedb.IDb) {
+	prefix := []byte("prefix")
+	require.NoError(t, db.Set(prefix, []byte("deleted"), []byte("value")))
+	require.NoError(t, db.Update(func(txn basedb.Txn) error {
+		if err := txn.Set(prefix, []byte("key"), []byte("value")); err != nil {
+			return err
+		}
+		// the writes of the transaction are visible to its reads
+		obj, found, err := txn.Get(prefix, []byte("key"))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Equal(t, []byte("value"), obj.Value)
+		if err := txn.Delete(prefix, []byte("deleted")); err != nil {
+			return err
+		}
+		count := 0
+		require.NoError(t, txn.GetAll(prefix, func(i int, obj basedb.Obj) error {
+			require.Equal(t, []byte("key"), obj.Key)
+			count++
+			return nil
+		}))
+		require.Equal(t, 1, count)
+		return nil
+	}))
+	_, found, err := db.Get(prefix, []byte("key"))
+	require.NoError(t, err)
+	require.True(t, found)
+	_, found, err = db.Get(prefix, []byte("deleted"))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// a failed transaction is rolled back
+	err = db.Update(func(txn basedb.Txn) error {
+		if err := txn.Set(prefix, []byte("rolled-back"), []byte("value")); err != nil {
+			return err
+		}
+		return errors.New("test")
+	})
+	require.EqualError(t, err, "test")
+	_, found, err = db.Get(prefix, []byte("rolled-back"))
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func testView(t *testing.T, db basedb.IDb) {
+	prefix := []byte("col/")
+	// keys around the collection boundaries must not be included
+	require.NoError(t, db.Set([]byte("col."), []byte("a"), []byte("before")))
+	require.NoError(t, db.Set([]byte("col0"), []byte("a"), []byte("after")))
+	require.NoError(t, db.Set([]byte{'z', 0xff}, []byte{0xff}, []byte("ff")))
+	for i := 0; i < 4; i++ {
+		require.NoError(t, db.Set(prefix, []byte{byte(i)}, []byte{byte(i)}))
+	}
+
+	keys := func(txn basedb.ReadTxn, from, to []byte, reverse bool) []byte {
+		var res []byte
+		require.NoError(t, txn.GetRange(prefix, from, to, reverse, func(i int, obj basedb.Obj) error {
+			require.Equal(t, len(res), i)
+			require.Equal(t, obj.Key, obj.Value)
+			res = append(res, obj.Key...)
+			return nil
+		}))
+		return res
+	}
+	require.NoError(t, db.View(func(txn basedb.ReadTxn) error {
+		require.Equal(t, []byte{0, 1, 2, 3}, keys(txn, nil, nil, false))
+		require.Equal(t, []byte{3, 2, 1, 0}, keys(txn, nil, nil, true))
+		require.Equal(t, []byte{1, 2}, keys(txn, []byte{1}, []byte{3}, false))
+		require.Equal(t, []byte{2, 1}, keys(txn, []byte{1}, []byte{3}, true))
+		require.Equal(t, []byte{3, 2}, keys(txn, []byte{2}, nil, true))
+		require.Equal(t, []byte{0, 1}, keys(txn, nil, []byte{2}, false))
+		require.Empty(t, keys(txn, []byte{3}, []byte{3}, false))
+		require.Empty(t, keys(txn, []byte{5}, nil, false))
+
+		// a collection without an upper bound
+		var values []string
+		require.NoError(t, txn.GetRange([]byte{'z', 0xff}, nil, nil, true, func(i int, obj basedb.Obj) error {
+			require.Equal(t, []byte{0xff}, obj.Key)
+			values = append(values, string(obj.Value))
+			return nil
+		}))
+		require.Equal(t, []string{"ff"}, values)
+		return nil
+	}))
+
+	// the whole keyspace in reverse
+	var values []string
+	require.NoError(t, db.View(func(txn basedb.ReadTxn) error {
+		return txn.GetRange(nil, nil, nil, true, func(i int, obj basedb.Obj) error {
+			values = append(values, string(obj.Value))
+			return nil
+		})
+	}))
+	require.Equal(t, []string{"ff", "after", "\x03", "\x02", "\x01", "\x00", "before"}, values)
+}
@@ -0,0 +1,214 @@
+package localnetwork
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+// base ports of the operators, operator i uses base port + i
+const (
+	baseTCPPort     = 13000
+	baseUDPPort     = 12000
+	baseMetricsPort = 15000
+)
+
+// file names in the output dir
+const (
+	eventsFile     = "events.yaml"
+	validatorsFile = "validators.json"
+)
+
+// Options defines the local network to generate
+type Options struct {
+	// Operators is the number of operators (3f+1), all of them are in the committee of every validator
+	Operators uint64
+	// Validators is the number of validators
+	Validators uint64
+	// OwnerAddress is the owner of all operators and validators
+	OwnerAddress common.Address
+	// OutputDir is where the events and the share configs are written
+	OutputDir string
+	// DBDir is the parent dir of the operators dbs
+	DBDir string
+}
+
+// Operator is a generated operator
+type Operator struct {
+	ID uint64
+	// PublicKey and PrivateKey are base64 encoded PEMs
+	PublicKey  string
+	PrivateKey string
+}
+
+// Validator is a generated validator and its shares
+type Validator struct {
+	PublicKey  string               `json:"publicKey"`
+	PrivateKey string               `json:"privateKey"`
+	KeyShares  *threshold.KeyShares `json:"keyShares"`
+}
+
+// Network is a generated local network
+type Network struct {
+	Operators  []*Operator
+	Validators []*Validator
+	opts       Options
+}
+
+// shareConfig is the per operator config, it overrides the shared node config (--share-config)
+type shareConfig struct {
+	DB struct {
+		Path string `yaml:"Path"`
+	} `yaml:"db"`
+	P2P struct {
+		TCPPort int `yaml:"TcpPort"`
+		UDPPort int `yaml:"UdpPort"`
+	} `yaml:"p2p"`
+	MetricsAPIPort     int    `yaml:"MetricsAPIPort"`
+	OperatorPrivateKey string `yaml:"OperatorPrivateKey"`
+	LocalEventsPath    string `yaml:"LocalEventsPath"`
+}
+
+// Generate creates the operators keys, and the validators keys split between all the operators.
+// threshold.Init must be called before
+func Generate(opts Options) (*Network, error) {
+	k, err := threshold.Quorum(opts.Operators)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Network{opts: opts}
+	operatorIDs := make([]uint64, opts.Operators)
+	operatorPks := make([]string, opts.Operators)
+	for i := range operatorIDs {
+		pkPem, skPem, err := rsaencryption.GenerateKeys()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not generate operator keys")
+		}
+		operatorIDs[i] = uint64(i + 1)
+		operatorPks[i] = base64.StdEncoding.EncodeToString(pkPem)
+		n.Operators = append(n.Operators, &Operator{
+			ID:         operatorIDs[i],
+			PublicKey:  operatorPks[i],
+			PrivateKey: base64.StdEncoding.EncodeToString(skPem),
+		})
+	}
+
+	for i := uint64(0); i < opts.Validators; i++ {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		keyShares, err := threshold.CreateKeyShares(sk, k, operatorIDs, operatorPks)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create validator shares")
+		}
+		n.Validators = append(n.Validators, &Validator{
+			PublicKey:  sk.GetPublicKey().SerializeToHexStr(),
+			PrivateKey: sk.SerializeToHexStr(),
+			KeyShares:  keyShares,
+		})
+	}
+	return n, nil
+}
+
+// Events returns the registration events of all operators and validators
+func (n *Network) Events() ([]*eth1.Event, error) {
+	var events []*eth1.Event
+	for _, o := range n.Operators {
+		events = append(events, &eth1.Event{
+			Name: abiparser.OperatorRegistration,
+			Data: abiparser.OperatorRegistrationEvent{
+				Id:           uint32(o.ID),
+				Name:         fmt.Sprintf("operator-%d", o.ID),
+				OwnerAddress: n.opts.OwnerAddress,
+				PublicKey:    []byte(o.PublicKey),
+			},
+		})
+	}
+	for _, v := range n.Validators {
+		pk := &bls.PublicKey{}
+		if err := pk.DeserializeHexStr(v.PublicKey); err != nil {
+			return nil, errors.Wrap(err, "invalid validator public key")
+		}
+		event := abiparser.ValidatorRegistrationEvent{
+			PublicKey:    pk.Serialize(),
+			OwnerAddress: n.opts.OwnerAddress,
+		}
+		for _, share := range v.KeyShares.Shares {
+			sharePk := &bls.PublicKey{}
+			if err := sharePk.DeserializeHexStr(share.SharePublicKey); err != nil {
+				return nil, errors.Wrap(err, "invalid share public key")
+			}
+			event.OperatorIds = append(event.OperatorIds, uint32(share.OperatorID))
+			event.SharesPublicKeys = append(event.SharesPublicKeys, sharePk.Serialize())
+			event.EncryptedKeys = append(event.EncryptedKeys, []byte(share.EncryptedKey))
+		}
+		events = append(events, &eth1.Event{
+			Name: abiparser.ValidatorRegistration,
+			Data: event,
+		})
+	}
+	return events, nil
+}
+
+// Write writes the local events, a share config per operator and the validators keys into the output dir
+func (n *Network) Write() error {
+	if err := os.MkdirAll(n.opts.OutputDir, 0700); err != nil {
+		return errors.Wrap(err, "could not create output dir")
+	}
+
+	events, err := n.Events()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(events)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal events")
+	}
+	eventsPath := filepath.Join(n.opts.OutputDir, eventsFile)
+	if err := os.WriteFile(eventsPath, data, 0600); err != nil {
+		return errors.Wrap(err, "could not write events")
+	}
+
+	for _, o := range n.Operators {
+		var cfg shareConfig
+		cfg.DB.Path = filepath.Join(n.opts.DBDir, fmt.Sprintf("%d", o.ID))
+		cfg.P2P.TCPPort = baseTCPPort + int(o.ID)
+		cfg.P2P.UDPPort = baseUDPPort + int(o.ID)
+		cfg.MetricsAPIPort = baseMetricsPort + int(o.ID)
+		cfg.OperatorPrivateKey = o.PrivateKey
+		cfg.LocalEventsPath = eventsPath
+		data, err := yaml.Marshal(&cfg)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal share config")
+		}
+		if err := os.WriteFile(n.ShareConfigPath(o.ID), data, 0600); err != nil {
+			return errors.Wrap(err, "could not write share config")
+		}
+	}
+
+	data, err = json.MarshalIndent(n.Validators, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal validators")
+	}
+	if err := os.WriteFile(filepath.Join(n.opts.OutputDir, validatorsFile), data, 0600); err != nil {
+		return errors.Wrap(err, "could not write validators")
+	}
+	return nil
+}
+
+// ShareConfigPath returns the path of the share config of the given operator
+func (n *Network) ShareConfigPath(operatorID uint64) string {
+	return filepath.Join(n.opts.OutputDir, fmt.Sprintf("share%d.yaml", operatorID))
+}
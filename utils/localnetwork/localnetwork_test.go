@@ -0,0 +1,88 @@
+package localnetwork
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bloxapp/ssv/eth1"
+	"github.com/bloxapp/ssv/eth1/abiparser"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/bloxapp/ssv/utils/threshold"
+)
+
+func TestGenerate(t *testing.T) {
+	threshold.Init()
+
+	dir := t.TempDir()
+	n, err := Generate(Options{
+		Operators:    4,
+		Validators:   2,
+		OwnerAddress: common.HexToAddress("0x97a6C1f3aaB5427B901fb135ED492749191C0f1F"),
+		OutputDir:    dir,
+		DBDir:        "./data/db",
+	})
+	require.NoError(t, err)
+	require.NoError(t, n.Write())
+
+	data, err := os.ReadFile(filepath.Join(dir, eventsFile))
+	require.NoError(t, err)
+	var events []*eth1.Event
+	require.NoError(t, yaml.Unmarshal(data, &events))
+	require.Len(t, events, 6)
+
+	operatorSks := make([]*rsa.PrivateKey, 0)
+	for i, o := range n.Operators {
+		event, ok := events[i].Data.(abiparser.OperatorRegistrationEvent)
+		require.True(t, ok)
+		require.Equal(t, uint32(o.ID), event.Id)
+		require.Equal(t, o.PublicKey, string(event.PublicKey))
+
+		data, err := os.ReadFile(n.ShareConfigPath(o.ID))
+		require.NoError(t, err)
+		var cfg shareConfig
+		require.NoError(t, yaml.Unmarshal(data, &cfg))
+		require.Equal(t, filepath.Join(dir, eventsFile), cfg.LocalEventsPath)
+		require.Equal(t, baseTCPPort+int(o.ID), cfg.P2P.TCPPort)
+		require.Equal(t, filepath.Join("./data/db", strconv.FormatUint(o.ID, 10)), cfg.DB.Path)
+
+		skPem, err := base64.StdEncoding.DecodeString(cfg.OperatorPrivateKey)
+		require.NoError(t, err)
+		sk, err := rsaencryption.ConvertPemToPrivateKey(string(skPem))
+		require.NoError(t, err)
+		operatorSks = append(operatorSks, sk)
+	}
+
+	for i, v := range n.Validators {
+		event, ok := events[len(n.Operators)+i].Data.(abiparser.ValidatorRegistrationEvent)
+		require.True(t, ok)
+		require.Equal(t, []uint32{1, 2, 3, 4}, event.OperatorIds)
+		pk := &bls.PublicKey{}
+		require.NoError(t, pk.Deserialize(event.PublicKey))
+		require.Equal(t, v.PublicKey, pk.SerializeToHexStr())
+
+		// every operator decrypts its share from the event
+		for j, sk := range operatorSks {
+			decrypted, err := rsaencryption.DecodeKey(sk, string(event.EncryptedKeys[j]))
+			require.NoError(t, err)
+			shareSk := &bls.SecretKey{}
+			require.NoError(t, shareSk.SetHexString(decrypted))
+			require.Equal(t, event.SharesPublicKeys[j], shareSk.GetPublicKey().Serialize())
+		}
+
+		verified, err := threshold.VerifyKeyShares(v.KeyShares, operatorSks)
+		require.NoError(t, err)
+		require.Equal(t, 4, verified)
+	}
+
+	_, err = Generate(Options{Operators: 5})
+	require.EqualError(t, err, "invalid committee size 5, must be 3f+1 (4, 7, 10, 13, ...)")
+}
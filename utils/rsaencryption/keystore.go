@@ -0,0 +1,245 @@
+package rsaencryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KDF function names
+const (
+	KdfScrypt = "scrypt"
+	KdfPbkdf2 = "pbkdf2"
+)
+
+const (
+	keystoreVersion = 1
+	cipherFunction  = "aes-256-gcm"
+	derivedKeyLen   = 32
+	saltLen         = 32
+
+	scryptR   = 8
+	pbkdf2Prf = "hmac-sha256"
+)
+
+// the work factors of the kdf functions, tests use lighter ones
+var (
+	scryptN = 1 << 18
+	scryptP = 1
+	pbkdf2C = 1 << 18
+)
+
+// Keystore is a password encrypted operator private key, the format follows EIP-2335
+// with AES-GCM replacing both the AES-CTR cipher and the checksum module
+type Keystore struct {
+	Version   int            `json:"version"`
+	PublicKey string         `json:"pubKey"`
+	Crypto    KeystoreCrypto `json:"crypto"`
+}
+
+// KeystoreCrypto holds the KDF and cipher modules of the keystore
+type KeystoreCrypto struct {
+	Kdf    KeystoreModule `json:"kdf"`
+	Cipher KeystoreModule `json:"cipher"`
+}
+
+// KeystoreModule is a single crypto module of the keystore
+type KeystoreModule struct {
+	Function string                 `json:"function"`
+	Params   map[string]interface{} `json:"params"`
+	Message  string                 `json:"message"`
+}
+
+// EncryptKeystore encrypts the given PEM private key with the given password
+func EncryptKeystore(skPem []byte, password string, kdf string) ([]byte, error) {
+	sk, err := ConvertPemToPrivateKey(string(skPem))
+	if err != nil {
+		return nil, err
+	}
+	pk, err := ExtractPublicKey(sk)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "could not generate salt")
+	}
+	kdfModule := KeystoreModule{Function: kdf}
+	switch kdf {
+	case KdfScrypt:
+		kdfModule.Params = map[string]interface{}{
+			"dklen": derivedKeyLen,
+			"n":     scryptN,
+			"r":     scryptR,
+			"p":     scryptP,
+			"salt":  hex.EncodeToString(salt),
+		}
+	case KdfPbkdf2:
+		kdfModule.Params = map[string]interface{}{
+			"dklen": derivedKeyLen,
+			"c":     pbkdf2C,
+			"prf":   pbkdf2Prf,
+			"salt":  hex.EncodeToString(salt),
+		}
+	default:
+		return nil, errors.Errorf("unsupported kdf function %s", kdf)
+	}
+	key, err := deriveKey(kdfModule, password)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "could not generate nonce")
+	}
+
+	ks := &Keystore{
+		Version:   keystoreVersion,
+		PublicKey: pk,
+		Crypto: KeystoreCrypto{
+			Kdf: kdfModule,
+			Cipher: KeystoreModule{
+				Function: cipherFunction,
+				Params: map[string]interface{}{
+					"nonce": hex.EncodeToString(nonce),
+				},
+				Message: hex.EncodeToString(gcm.Seal(nil, nonce, skPem, nil)),
+			},
+		},
+	}
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+// DecryptKeystore decrypts the given keystore JSON with the given password and returns the PEM private key
+func DecryptKeystore(keystoreJSON []byte, password string) ([]byte, error) {
+	var ks Keystore
+	if err := json.Unmarshal(keystoreJSON, &ks); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal keystore")
+	}
+	if ks.Version != keystoreVersion {
+		return nil, errors.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	if ks.Crypto.Cipher.Function != cipherFunction {
+		return nil, errors.Errorf("unsupported cipher function %s", ks.Crypto.Cipher.Function)
+	}
+
+	key, err := deriveKey(ks.Crypto.Kdf, password)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hexParam(ks.Crypto.Cipher.Params, "nonce")
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid nonce size")
+	}
+	msg, err := hex.DecodeString(ks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode cipher message")
+	}
+	skPem, err := gcm.Open(nil, nonce, msg, nil)
+	if err != nil {
+		return nil, errors.New("invalid password")
+	}
+	return skPem, nil
+}
+
+func deriveKey(kdf KeystoreModule, password string) ([]byte, error) {
+	salt, err := hexParam(kdf.Params, "salt")
+	if err != nil {
+		return nil, err
+	}
+	dklen, err := intParam(kdf.Params, "dklen")
+	if err != nil {
+		return nil, err
+	}
+	if dklen != derivedKeyLen {
+		return nil, errors.Errorf("unsupported derived key length %d", dklen)
+	}
+
+	switch kdf.Function {
+	case KdfScrypt:
+		n, err := intParam(kdf.Params, "n")
+		if err != nil {
+			return nil, err
+		}
+		r, err := intParam(kdf.Params, "r")
+		if err != nil {
+			return nil, err
+		}
+		p, err := intParam(kdf.Params, "p")
+		if err != nil {
+			return nil, err
+		}
+		key, err := scrypt.Key([]byte(password), salt, n, r, p, dklen)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not derive scrypt key")
+		}
+		return key, nil
+	case KdfPbkdf2:
+		c, err := intParam(kdf.Params, "c")
+		if err != nil {
+			return nil, err
+		}
+		if prf, _ := kdf.Params["prf"].(string); prf != pbkdf2Prf {
+			return nil, errors.Errorf("unsupported pbkdf2 prf %s", prf)
+		}
+		return pbkdf2.Key([]byte(password), salt, c, dklen, sha256.New), nil
+	default:
+		return nil, errors.Errorf("unsupported kdf function %s", kdf.Function)
+	}
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create cipher")
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create gcm")
+	}
+	return gcm, nil
+}
+
+func hexParam(params map[string]interface{}, name string) ([]byte, error) {
+	s, ok := params[name].(string)
+	if !ok {
+		return nil, errors.Errorf("missing keystore param %s", name)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode keystore param %s", name)
+	}
+	return b, nil
+}
+
+func intParam(params map[string]interface{}, name string) (int, error) {
+	// json numbers are decoded as float64
+	switch v := params[name].(type) {
+	case float64:
+		return int(v), nil
+	case int:
+		return v, nil
+	default:
+		return 0, errors.Errorf("missing keystore param %s", name)
+	}
+}
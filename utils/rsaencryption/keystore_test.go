@@ -0,0 +1,40 @@
+package rsaencryption
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	testingspace "github.com/bloxapp/ssv/utils/rsaencryption/testingspace"
+)
+
+// useLightKdf lowers the work factors of the kdf functions for the duration of the test
+func useLightKdf(t *testing.T) {
+	n, p, c := scryptN, scryptP, pbkdf2C
+	scryptN, scryptP, pbkdf2C = 1<<12, 6, 1<<12
+	t.Cleanup(func() {
+		scryptN, scryptP, pbkdf2C = n, p, c
+	})
+}
+
+func TestKeystore(t *testing.T) {
+	useLightKdf(t)
+	for _, kdf := range []string{KdfScrypt, KdfPbkdf2} {
+		t.Run(kdf, func(t *testing.T) {
+			keystore, err := EncryptKeystore([]byte(testingspace.SkPem), "password", kdf)
+			require.NoError(t, err)
+
+			skPem, err := DecryptKeystore(keystore, "password")
+			require.NoError(t, err)
+			require.Equal(t, testingspace.SkPem, string(skPem))
+
+			_, err = DecryptKeystore(keystore, "wrong password")
+			require.EqualError(t, err, "invalid password")
+		})
+	}
+
+	t.Run("unsupported kdf", func(t *testing.T) {
+		_, err := EncryptKeystore([]byte(testingspace.SkPem), "password", "argon2")
+		require.EqualError(t, err, "unsupported kdf function argon2")
+	})
+}
@@ -54,6 +54,9 @@ func DecodeKey(sk *rsa.PrivateKey, hashBase64 string) (string, error) {
 // ConvertPemToPrivateKey return rsa private key from secret key
 func ConvertPemToPrivateKey(skPem string) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode([]byte(skPem))
+	if block == nil {
+		return nil, errors.New("Failed to decode private key pem")
+	}
 	// TODO: resolve deprecation https://github.com/golang/go/issues/8860
 	enc := x509.IsEncryptedPEMBlock(block) //nolint
 	b := block.Bytes
@@ -97,3 +100,30 @@ func ExtractPublicKey(sk *rsa.PrivateKey) (string, error) {
 
 	return base64.StdEncoding.EncodeToString(pemByte), nil
 }
+
+// ConvertPemToPublicKey return rsa public key from public key pem
+func ConvertPemToPublicKey(pkPem []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(pkPem)
+	if block == nil {
+		return nil, errors.New("Failed to decode public key pem")
+	}
+	parsedPk, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse public key")
+	}
+	pk, ok := parsedPk.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("Public key is not an rsa public key")
+	}
+	return pk, nil
+}
+
+// EncodeKey encrypts the given key with the public key, return the encrypted key as base64.
+// it is the counterpart of DecodeKey
+func EncodeKey(pk *rsa.PublicKey, key string) (string, error) {
+	encryptedKey, err := rsa.EncryptPKCS1v15(rand.Reader, pk, []byte(key))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to encrypt key")
+	}
+	return base64.StdEncoding.EncodeToString(encryptedKey), nil
+}
@@ -42,3 +42,21 @@ func TestPrivateKeyToByte(t *testing.T) {
 	require.NotNil(t, b)
 	require.Greater(t, len(b), 1024)
 }
+
+func TestEncodeKey(t *testing.T) {
+	pkPem, skPem, err := GenerateKeys()
+	require.NoError(t, err)
+	pk, err := ConvertPemToPublicKey(pkPem)
+	require.NoError(t, err)
+	encrypted, err := EncodeKey(pk, "626d6a13ae5b1458c310700941764f3841f279f9c8de5f4ba94abd01dc082517")
+	require.NoError(t, err)
+
+	sk, err := ConvertPemToPrivateKey(string(skPem))
+	require.NoError(t, err)
+	key, err := DecodeKey(sk, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "626d6a13ae5b1458c310700941764f3841f279f9c8de5f4ba94abd01dc082517", key)
+
+	_, err = ConvertPemToPublicKey([]byte("not a pem"))
+	require.Error(t, err)
+}
@@ -0,0 +1,31 @@
+package threshold
+
+import "fmt"
+
+// FaultTolerance returns the number of faulty operators (f) that a committee of 3f+1 operators can tolerate
+func FaultTolerance(committeeSize uint64) (uint64, error) {
+	if committeeSize < 4 || (committeeSize-1)%3 != 0 {
+		return 0, fmt.Errorf("invalid committee size %d, must be 3f+1 (4, 7, 10, 13, ...)", committeeSize)
+	}
+	return (committeeSize - 1) / 3, nil
+}
+
+// Quorum returns the quorum (2f+1) of a committee of 3f+1 operators,
+// which is the number of shares needed to reconstruct a signature.
+// it is both the threshold that validator keys are split with and the consensus quorum of the shares
+func Quorum(committeeSize uint64) (uint64, error) {
+	f, err := FaultTolerance(committeeSize)
+	if err != nil {
+		return 0, err
+	}
+	return 2*f + 1, nil
+}
+
+// PartialQuorum returns the partial quorum (f+1) of a committee of 3f+1 operators
+func PartialQuorum(committeeSize uint64) (uint64, error) {
+	f, err := FaultTolerance(committeeSize)
+	if err != nil {
+		return 0, err
+	}
+	return f + 1, nil
+}
@@ -0,0 +1,34 @@
+package threshold
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorum(t *testing.T) {
+	tests := []struct {
+		committeeSize uint64
+		quorum        uint64
+		partialQuorum uint64
+	}{
+		{4, 3, 2},
+		{7, 5, 3},
+		{10, 7, 4},
+		{13, 9, 5},
+	}
+	for _, test := range tests {
+		quorum, err := Quorum(test.committeeSize)
+		require.NoError(t, err)
+		require.Equal(t, test.quorum, quorum)
+		partialQuorum, err := PartialQuorum(test.committeeSize)
+		require.NoError(t, err)
+		require.Equal(t, test.partialQuorum, partialQuorum)
+	}
+
+	for _, invalid := range []uint64{0, 1, 3, 5, 6, 8} {
+		_, err := Quorum(invalid)
+		require.EqualError(t, err, "invalid committee size "+strconv.FormatUint(invalid, 10)+", must be 3f+1 (4, 7, 10, 13, ...)")
+	}
+}
@@ -0,0 +1,164 @@
+package threshold
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+// verificationMessage is signed by the shares in order to verify that they reconstruct the validator key
+var verificationMessage = []byte("ssv-verify-threshold")
+
+// KeyShares is the result of splitting a validator key between a committee of operators
+type KeyShares struct {
+	ValidatorPublicKey string      `json:"validatorPublicKey"`
+	Threshold          uint64      `json:"threshold"`
+	Shares             []*KeyShare `json:"shares"`
+}
+
+// KeyShare is the share of a single operator, the share secret is encrypted with the operator public key
+type KeyShare struct {
+	OperatorID        uint64 `json:"operatorId"`
+	OperatorPublicKey string `json:"operatorPublicKey"`
+	SharePublicKey    string `json:"sharePublicKey"`
+	EncryptedKey      string `json:"encryptedKey"`
+}
+
+// CreateKeyShares splits the secret key between the given operators, any threshold of the shares reconstructs it.
+// operator public keys are base64 encoded PEMs
+func CreateKeyShares(sk *bls.SecretKey, threshold uint64, operatorIDs []uint64, operatorPublicKeys []string) (*KeyShares, error) {
+	if len(operatorIDs) != len(operatorPublicKeys) {
+		return nil, errors.Errorf("got %d operator ids and %d operator public keys", len(operatorIDs), len(operatorPublicKeys))
+	}
+	shares, err := CreateForIDs(sk.Serialize(), threshold, operatorIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not split secret key")
+	}
+
+	keyShares := &KeyShares{
+		ValidatorPublicKey: sk.GetPublicKey().SerializeToHexStr(),
+		Threshold:          threshold,
+	}
+	for i, operatorID := range operatorIDs {
+		pk, err := decodeOperatorPublicKey(operatorPublicKeys[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key of operator %d", operatorID)
+		}
+		share := shares[operatorID]
+		encryptedKey, err := rsaencryption.EncodeKey(pk, share.SerializeToHexStr())
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not encrypt share of operator %d", operatorID)
+		}
+		keyShares.Shares = append(keyShares.Shares, &KeyShare{
+			OperatorID:        operatorID,
+			OperatorPublicKey: operatorPublicKeys[i],
+			SharePublicKey:    share.GetPublicKey().SerializeToHexStr(),
+			EncryptedKey:      encryptedKey,
+		})
+	}
+	return keyShares, nil
+}
+
+// VerifyKeyShares decrypts the shares with the given operator private keys, and verifies that every combination
+// of threshold decrypted shares reconstructs a signature of the validator key.
+// returns the number of combinations that were verified
+func VerifyKeyShares(keyShares *KeyShares, operatorKeys []*rsa.PrivateKey) (int, error) {
+	validatorPk := &bls.PublicKey{}
+	if err := validatorPk.DeserializeHexStr(keyShares.ValidatorPublicKey); err != nil {
+		return 0, errors.Wrap(err, "invalid validator public key")
+	}
+	if keyShares.Threshold == 0 || keyShares.Threshold > uint64(len(keyShares.Shares)) {
+		return 0, errors.Errorf("invalid threshold %d for %d shares", keyShares.Threshold, len(keyShares.Shares))
+	}
+
+	operatorSks := make(map[string]*rsa.PrivateKey)
+	for _, sk := range operatorKeys {
+		pk, err := rsaencryption.ExtractPublicKey(sk)
+		if err != nil {
+			return 0, err
+		}
+		operatorSks[pk] = sk
+	}
+
+	seen := make(map[uint64]bool)
+	signatures := make(map[uint64][]byte)
+	var ids []uint64
+	for _, share := range keyShares.Shares {
+		if seen[share.OperatorID] {
+			return 0, errors.Errorf("duplicate share of operator %d", share.OperatorID)
+		}
+		seen[share.OperatorID] = true
+		sk, found := operatorSks[share.OperatorPublicKey]
+		if !found {
+			continue
+		}
+		shareSk, err := decryptShare(sk, share)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid share of operator %d", share.OperatorID)
+		}
+		signatures[share.OperatorID] = shareSk.SignByte(verificationMessage).Serialize()
+		ids = append(ids, share.OperatorID)
+	}
+	if uint64(len(ids)) < keyShares.Threshold {
+		return 0, errors.Errorf("could decrypt only %d shares, %d are needed", len(ids), keyShares.Threshold)
+	}
+
+	verified := 0
+	for _, combination := range combinations(ids, int(keyShares.Threshold)) {
+		quorum := make(map[uint64][]byte, len(combination))
+		for _, id := range combination {
+			quorum[id] = signatures[id]
+		}
+		sig, err := ReconstructSignatures(quorum)
+		if err != nil {
+			return verified, errors.Wrapf(err, "could not reconstruct signature of operators %v", combination)
+		}
+		if !sig.VerifyByte(validatorPk, verificationMessage) {
+			return verified, errors.Errorf("signature of operators %v does not match the validator public key", combination)
+		}
+		verified++
+	}
+	return verified, nil
+}
+
+// decryptShare decrypts the share secret and verifies it against the share public key
+func decryptShare(operatorSk *rsa.PrivateKey, share *KeyShare) (*bls.SecretKey, error) {
+	decrypted, err := rsaencryption.DecodeKey(operatorSk, share.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	shareSk := &bls.SecretKey{}
+	if err := shareSk.SetHexString(decrypted); err != nil {
+		return nil, errors.Wrap(err, "could not set share secret key")
+	}
+	if shareSk.GetPublicKey().SerializeToHexStr() != share.SharePublicKey {
+		return nil, errors.New("share secret key does not match the share public key")
+	}
+	return shareSk, nil
+}
+
+func decodeOperatorPublicKey(pkBase64 string) (*rsa.PublicKey, error) {
+	pkPem, err := base64.StdEncoding.DecodeString(pkBase64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode base64")
+	}
+	return rsaencryption.ConvertPemToPublicKey(pkPem)
+}
+
+// combinations returns all the subsets of size k of the given ids
+func combinations(ids []uint64, k int) [][]uint64 {
+	if k == 0 {
+		return [][]uint64{{}}
+	}
+	var result [][]uint64
+	for i := 0; i <= len(ids)-k; i++ {
+		for _, rest := range combinations(ids[i+1:], k-1) {
+			result = append(result, append([]uint64{ids[i]}, rest...))
+		}
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package threshold
+
+import (
+	"crypto/rsa"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+)
+
+func generateOperatorKeys(t *testing.T, count int) ([]*rsa.PrivateKey, []string) {
+	sks := make([]*rsa.PrivateKey, count)
+	pks := make([]string, count)
+	for i := 0; i < count; i++ {
+		_, skPem, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		sks[i], err = rsaencryption.ConvertPemToPrivateKey(string(skPem))
+		require.NoError(t, err)
+		pks[i], err = rsaencryption.ExtractPublicKey(sks[i])
+		require.NoError(t, err)
+	}
+	return sks, pks
+}
+
+func TestCreateAndVerifyKeyShares(t *testing.T) {
+	Init()
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+
+	operatorSks, operatorPks := generateOperatorKeys(t, 7)
+	operatorIDs := []uint64{3, 8, 12, 20, 21, 40, 41}
+	quorum, err := Quorum(7)
+	require.NoError(t, err)
+
+	keyShares, err := CreateKeyShares(sk, quorum, operatorIDs, operatorPks)
+	require.NoError(t, err)
+	require.Equal(t, sk.GetPublicKey().SerializeToHexStr(), keyShares.ValidatorPublicKey)
+	require.Equal(t, uint64(5), keyShares.Threshold)
+	require.Len(t, keyShares.Shares, 7)
+	for i, share := range keyShares.Shares {
+		require.Equal(t, operatorIDs[i], share.OperatorID)
+		require.Equal(t, operatorPks[i], share.OperatorPublicKey)
+	}
+
+	// all 21 combinations of 5 out of 7 shares
+	verified, err := VerifyKeyShares(keyShares, operatorSks)
+	require.NoError(t, err)
+	require.Equal(t, 21, verified)
+
+	// a quorum of decrypted shares is enough
+	verified, err = VerifyKeyShares(keyShares, operatorSks[:5])
+	require.NoError(t, err)
+	require.Equal(t, 1, verified)
+
+	_, err = VerifyKeyShares(keyShares, operatorSks[:4])
+	require.EqualError(t, err, "could decrypt only 4 shares, 5 are needed")
+
+	// a share that is encrypted to the wrong operator
+	keyShares.Shares[0].EncryptedKey = keyShares.Shares[1].EncryptedKey
+	_, err = VerifyKeyShares(keyShares, operatorSks)
+	require.Error(t, err)
+}
+
+func TestVerifyKeySharesWrongValidator(t *testing.T) {
+	Init()
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	operatorSks, operatorPks := generateOperatorKeys(t, 4)
+
+	keyShares, err := CreateKeyShares(sk, 3, []uint64{1, 2, 3, 4}, operatorPks)
+	require.NoError(t, err)
+
+	other := &bls.SecretKey{}
+	other.SetByCSPRNG()
+	keyShares.ValidatorPublicKey = other.GetPublicKey().SerializeToHexStr()
+	_, err = VerifyKeyShares(keyShares, operatorSks)
+	require.EqualError(t, err, "signature of operators [1 2 3] does not match the validator public key")
+}
+
+func TestCreateForIDs(t *testing.T) {
+	Init()
+
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+
+	_, err := CreateForIDs(sk.Serialize(), 3, []uint64{1, 2, 2, 4})
+	require.EqualError(t, err, "duplicate share id 2")
+	_, err = CreateForIDs(sk.Serialize(), 3, []uint64{0, 1, 2, 3})
+	require.EqualError(t, err, "invalid share id 0")
+	_, err = CreateForIDs(sk.Serialize(), 5, []uint64{1, 2, 3, 4})
+	require.EqualError(t, err, "invalid threshold 5 for 4 shares")
+}
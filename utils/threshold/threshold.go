@@ -21,6 +21,21 @@ func Init() {
 // Create receives a bls.SecretKey hex and count.
 // Will split the secret key into count shares
 func Create(skBytes []byte, threshold uint64, count uint64) (map[uint64]*bls.SecretKey, error) {
+	ids := make([]uint64, count)
+	for i := range ids {
+		// starting from 1 because 0 is master key
+		ids[i] = uint64(i + 1)
+	}
+	return CreateForIDs(skBytes, threshold, ids)
+}
+
+// CreateForIDs splits the secret key into shares, one for each of the given ids (e.g. operator ids),
+// so that any threshold of the shares reconstructs the secret key
+func CreateForIDs(skBytes []byte, threshold uint64, ids []uint64) (map[uint64]*bls.SecretKey, error) {
+	if threshold == 0 || threshold > uint64(len(ids)) {
+		return nil, fmt.Errorf("invalid threshold %d for %d shares", threshold, len(ids))
+	}
+
 	// master key Polynomial
 	msk := make([]bls.SecretKey, threshold)
 
@@ -37,12 +52,18 @@ func Create(skBytes []byte, threshold uint64, count uint64) (map[uint64]*bls.Sec
 		msk[i] = sk
 	}
 
-	// evaluate shares - starting from 1 because 0 is master key
+	// evaluate shares, 0 is the master key and can't be used as an id
 	shares := make(map[uint64]*bls.SecretKey)
-	for i := uint64(1); i <= count; i++ {
+	for _, id := range ids {
+		if id == 0 {
+			return nil, fmt.Errorf("invalid share id 0")
+		}
+		if _, exists := shares[id]; exists {
+			return nil, fmt.Errorf("duplicate share id %d", id)
+		}
 		blsID := bls.ID{}
 
-		err := blsID.SetDecString(fmt.Sprintf("%d", i))
+		err := blsID.SetDecString(fmt.Sprintf("%d", id))
 		if err != nil {
 			return nil, err
 		}
@@ -54,7 +75,7 @@ func Create(skBytes []byte, threshold uint64, count uint64) (map[uint64]*bls.Sec
 			return nil, err
 		}
 
-		shares[i] = &sk
+		shares[id] = &sk
 	}
 	return shares, nil
 }